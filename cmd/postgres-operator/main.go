@@ -16,17 +16,21 @@ import (
 	"strings"
 	"syscall"
 	"time"
+	_ "time/tzdata" // validate backup time zones in images without zoneinfo
 	"unicode"
 
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"github.com/crunchydata/postgres-operator/internal/bridge"
 	"github.com/crunchydata/postgres-operator/internal/bridge/crunchybridgecluster"
 	"github.com/crunchydata/postgres-operator/internal/controller/pgupgrade"
 	"github.com/crunchydata/postgres-operator/internal/controller/postgrescluster"
+	"github.com/crunchydata/postgres-operator/internal/controller/postgresdatabase"
+	"github.com/crunchydata/postgres-operator/internal/controller/postgresrestoretest"
 	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
 	"github.com/crunchydata/postgres-operator/internal/controller/standalone_pgadmin"
 	"github.com/crunchydata/postgres-operator/internal/feature"
@@ -93,6 +97,22 @@ func initManager(ctx context.Context) (runtime.Options, error) {
 	// Set health probe port
 	options.HealthProbeBindAddress = ":8081"
 
+	// Serve admission webhooks when configured with a directory containing
+	// a TLS certificate and key. The Kubernetes API must be configured to
+	// call these webhooks; see "config/webhook".
+	if dir := strings.TrimSpace(os.Getenv("PGO_WEBHOOK_CERT_DIR")); len(dir) > 0 {
+		options.WebhookServer = webhook.NewServer(webhook.Options{
+			CertDir: dir,
+			Port:    9443,
+
+			// Disable http/2 for the same reasons as the metrics endpoint above.
+			TLSOpts: []func(*tls.Config){func(c *tls.Config) {
+				log.Info("enabling webhooks via http/1.1")
+				c.NextProtos = []string{"http/1.1"}
+			}},
+		})
+	}
+
 	// Enable leader elections when configured with a valid Lease.coordination.k8s.io name.
 	// - https://docs.k8s.io/concepts/architecture/leases
 	// - https://releases.k8s.io/v1.30.0/pkg/apis/coordination/validation/validation.go#L26
@@ -231,6 +251,12 @@ func main() {
 	// add all PostgreSQL Operator controllers to the runtime manager
 	addControllersToManager(mgr, log, registrar)
 
+	// add admission webhooks to the runtime manager when they are enabled
+	if options.WebhookServer != nil {
+		assertNoError((&postgrescluster.Webhook{}).SetupWithManager(mgr))
+		assertNoError(mgr.AddReadyzCheck("webhook", mgr.GetWebhookServer().StartedChecker()))
+	}
+
 	if features.Enabled(feature.BridgeIdentifiers) {
 		constructor := func() *bridge.Client {
 			client := bridge.NewClient(os.Getenv("PGO_BRIDGE_URL"), versionString)
@@ -296,6 +322,22 @@ func addControllersToManager(mgr runtime.Manager, log logging.Logger, reg regist
 		Registration: reg,
 	}
 
+	if s := os.Getenv("PGO_BACKUP_CONCURRENCY"); s != "" {
+		i, err := strconv.Atoi(s)
+		if err == nil && i <= 0 {
+			err = fmt.Errorf("got %d", i)
+		}
+		if err == nil {
+			pgReconciler.BackupQueue = &postgrescluster.BackupQueue{
+				Limit: i,
+				PerNamespace: strings.EqualFold(
+					os.Getenv("PGO_BACKUP_CONCURRENCY_SCOPE"), "namespace"),
+			}
+		} else {
+			log.Error(err, "PGO_BACKUP_CONCURRENCY must be a positive number")
+		}
+	}
+
 	if err := pgReconciler.SetupWithManager(mgr); err != nil {
 		log.Error(err, "unable to create PostgresCluster controller")
 		os.Exit(1)
@@ -324,6 +366,28 @@ func addControllersToManager(mgr runtime.Manager, log logging.Logger, reg regist
 		os.Exit(1)
 	}
 
+	databaseReconciler := &postgresdatabase.Reconciler{
+		Client:   mgr.GetClient(),
+		Owner:    naming.ControllerPostgresDatabase,
+		Recorder: mgr.GetEventRecorderFor(naming.ControllerPostgresDatabase),
+	}
+
+	if err := databaseReconciler.SetupWithManager(mgr); err != nil {
+		log.Error(err, "unable to create PostgresDatabase controller")
+		os.Exit(1)
+	}
+
+	restoreTestReconciler := &postgresrestoretest.Reconciler{
+		Client:   mgr.GetClient(),
+		Owner:    naming.ControllerPostgresRestoreTest,
+		Recorder: mgr.GetEventRecorderFor(naming.ControllerPostgresRestoreTest),
+	}
+
+	if err := restoreTestReconciler.SetupWithManager(mgr); err != nil {
+		log.Error(err, "unable to create PostgresRestoreTest controller")
+		os.Exit(1)
+	}
+
 	constructor := func() bridge.ClientInterface {
 		client := bridge.NewClient(os.Getenv("PGO_BRIDGE_URL"), versionString)
 		client.Transport = otelTransportWrapper()(http.DefaultTransport)
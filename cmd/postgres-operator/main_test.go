@@ -12,6 +12,7 @@ import (
 
 	"gotest.tools/v3/assert"
 	"gotest.tools/v3/assert/cmp"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
 func TestInitManager(t *testing.T) {
@@ -102,6 +103,19 @@ func TestInitManager(t *testing.T) {
 		assert.Assert(t, cmp.Contains(options.Cache.DefaultNamespaces, "another-one"))
 	})
 
+	t.Run("PGO_WEBHOOK_CERT_DIR", func(t *testing.T) {
+		t.Setenv("PGO_WEBHOOK_CERT_DIR", "/some/path")
+
+		options, err := initManager(ctx)
+		assert.NilError(t, err)
+
+		server, ok := options.WebhookServer.(*webhook.DefaultServer)
+		assert.Assert(t, ok, "expected a webhook server, got %T", options.WebhookServer)
+		assert.Equal(t, server.Options.CertDir, "/some/path")
+		assert.Equal(t, server.Options.Port, 9443)
+		assert.Equal(t, len(server.Options.TLSOpts), 1)
+	})
+
 	t.Run("PGO_WORKERS", func(t *testing.T) {
 		t.Run("Invalid", func(t *testing.T) {
 			for _, v := range []string{"-3", "0", "3.14"} {
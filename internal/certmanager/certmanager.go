@@ -0,0 +1,158 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certmanager
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crunchydata/postgres-operator/internal/kubernetes"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+var (
+	// CertificateAPI identifies the cert-manager API that issues certificates.
+	// - https://cert-manager.io/docs/usage/certificate
+	CertificateAPI = kubernetes.API{Group: "cert-manager.io", Kind: "Certificate"}
+
+	// BundleAPI identifies the trust-manager API that distributes certificate
+	// authorities to namespaces.
+	// - https://cert-manager.io/docs/trust/trust-manager
+	BundleAPI = kubernetes.API{Group: "trust.cert-manager.io", Kind: "Bundle"}
+)
+
+// ConditionIssued is the type of the PostgresCluster condition that is True
+// when cert-manager has issued every certificate the cluster asked for.
+const ConditionIssued = "CertManagerCertificatesIssued"
+
+// Requested returns true when cluster asks for certificates from cert-manager.
+func Requested(cluster *v1beta1.PostgresCluster) bool {
+	return cluster.Spec.CertManager != nil
+}
+
+// Enabled returns true when cluster asks for certificates from cert-manager
+// and cert-manager is installed in Kubernetes.
+func Enabled(ctx context.Context, cluster *v1beta1.PostgresCluster) bool {
+	return Requested(cluster) && kubernetes.Has(ctx, CertificateAPI)
+}
+
+// Active returns true when cluster uses certificates issued by cert-manager.
+// The certificates of the operator are used until cert-manager has issued
+// all of them, so that pods never mount an empty Secret.
+func Active(ctx context.Context, cluster *v1beta1.PostgresCluster) bool {
+	return Enabled(ctx, cluster) &&
+		meta.IsStatusConditionTrue(cluster.Status.Conditions, ConditionIssued)
+}
+
+// Issued returns true when secret contains the certificate, private key, and
+// certificate authority that cert-manager writes.
+func Issued(secret *corev1.Secret) bool {
+	return secret != nil &&
+		len(secret.Data[corev1.TLSCertKey]) > 0 &&
+		len(secret.Data[corev1.TLSPrivateKeyKey]) > 0 &&
+		len(secret.Data["ca.crt"]) > 0
+}
+
+// Certificate returns a cert-manager Certificate that asks the issuer of
+// cluster to store a certificate for commonName and dnsNames in a Secret of
+// the same name. The Secret contains the "tls.crt", "tls.key", and "ca.crt"
+// files expected by PostgreSQL and PgBouncer.
+// - https://cert-manager.io/docs/reference/api-docs/#cert-manager.io/v1.Certificate
+func Certificate(
+	cluster *v1beta1.PostgresCluster, meta metav1.ObjectMeta,
+	commonName string, dnsNames []string, usages ...string,
+) *unstructured.Unstructured {
+	issuer := cluster.Spec.CertManager.IssuerRef
+
+	spec := map[string]any{
+		"secretName": meta.Name,
+		"commonName": commonName,
+		"issuerRef":  map[string]any{"name": issuer.Name},
+		"privateKey": map[string]any{
+			// These match the certificates of [pki.RootCertificateAuthority].
+			"algorithm":      "ECDSA",
+			"size":           int64(256),
+			"rotationPolicy": "Always",
+		},
+		"secretTemplate": map[string]any{
+			"labels": toAny(meta.Labels),
+		},
+	}
+	if issuer.Kind != "" {
+		_ = unstructured.SetNestedField(spec, issuer.Kind, "issuerRef", "kind")
+	}
+	if issuer.Group != "" {
+		_ = unstructured.SetNestedField(spec, issuer.Group, "issuerRef", "group")
+	}
+	if len(dnsNames) > 0 {
+		spec["dnsNames"] = toAnySlice(dnsNames)
+	}
+	if len(usages) > 0 {
+		spec["usages"] = toAnySlice(usages)
+	}
+
+	certificate := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	certificate.SetAPIVersion("cert-manager.io/v1")
+	certificate.SetKind("Certificate")
+	certificate.SetNamespace(meta.Namespace)
+	certificate.SetName(meta.Name)
+	certificate.SetAnnotations(meta.Annotations)
+	certificate.SetLabels(meta.Labels)
+
+	return certificate
+}
+
+// Bundle returns a trust-manager Bundle that copies authority into a ConfigMap
+// in the namespace of cluster. The ConfigMap has the same name as the Bundle
+// and contains the PEM-encoded certificates in its "ca.crt" key.
+// - https://cert-manager.io/docs/trust/trust-manager/api-reference
+func Bundle(
+	cluster *v1beta1.PostgresCluster, meta metav1.ObjectMeta, authority []byte,
+) *unstructured.Unstructured {
+	bundle := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"sources": []any{
+				map[string]any{"inLine": string(authority)},
+			},
+			"target": map[string]any{
+				"configMap": map[string]any{"key": "ca.crt"},
+				"namespaceSelector": map[string]any{
+					"matchLabels": map[string]any{
+						"kubernetes.io/metadata.name": cluster.Namespace,
+					},
+				},
+			},
+		},
+	}}
+	bundle.SetAPIVersion("trust.cert-manager.io/v1alpha1")
+	bundle.SetKind("Bundle")
+	bundle.SetName(meta.Name)
+	bundle.SetAnnotations(meta.Annotations)
+	bundle.SetLabels(meta.Labels)
+
+	return bundle
+}
+
+// toAny converts in to a map that can be stored in an unstructured object.
+func toAny(in map[string]string) map[string]any {
+	out := make(map[string]any, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
+
+// toAnySlice converts in to a slice that can be stored in an unstructured object.
+func toAnySlice(in []string) []any {
+	out := make([]any, len(in))
+	for i := range in {
+		out[i] = in[i]
+	}
+	return out
+}
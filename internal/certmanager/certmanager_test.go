@@ -0,0 +1,147 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certmanager
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crunchydata/postgres-operator/internal/kubernetes"
+	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+func TestEnabled(t *testing.T) {
+	ctx := context.Background()
+	cluster := new(v1beta1.PostgresCluster)
+
+	assert.Assert(t, !Requested(cluster))
+	assert.Assert(t, !Enabled(ctx, cluster))
+
+	cluster.Spec.CertManager = new(v1beta1.CertManagerSpec)
+	assert.Assert(t, Requested(cluster))
+	assert.Assert(t, !Enabled(ctx, cluster), "expected discovery")
+
+	ctx = kubernetes.NewAPIContext(ctx, kubernetes.NewAPISet(
+		kubernetes.API{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"},
+	))
+	assert.Assert(t, Enabled(ctx, cluster))
+
+	// Certificates are used after cert-manager has issued them.
+	assert.Assert(t, !Active(ctx, cluster))
+	cluster.Status.Conditions = []metav1.Condition{{
+		Type: ConditionIssued, Status: metav1.ConditionTrue,
+	}}
+	assert.Assert(t, Active(ctx, cluster))
+}
+
+func TestIssued(t *testing.T) {
+	assert.Assert(t, !Issued(nil))
+	assert.Assert(t, !Issued(new(corev1.Secret)))
+
+	secret := &corev1.Secret{Data: map[string][]byte{
+		"tls.crt": []byte("cert"), "tls.key": []byte("key"),
+	}}
+	assert.Assert(t, !Issued(secret))
+
+	secret.Data["ca.crt"] = []byte("authority")
+	assert.Assert(t, Issued(secret))
+}
+
+func TestCertificate(t *testing.T) {
+	cluster := new(v1beta1.PostgresCluster)
+	cluster.Spec.CertManager = &v1beta1.CertManagerSpec{
+		IssuerRef: v1beta1.CertManagerIssuerReference{
+			Name: "some-issuer", Kind: "ClusterIssuer", Group: "cert-manager.io",
+		},
+	}
+
+	meta := metav1.ObjectMeta{
+		Namespace: "ns1", Name: "hippo-tls",
+		Labels: map[string]string{"some": "label"},
+	}
+
+	t.Run("DNSNames", func(t *testing.T) {
+		certificate := Certificate(cluster, meta, "one", []string{"one", "two"})
+
+		assert.Assert(t, cmp.MarshalMatches(certificate, `
+apiVersion: cert-manager.io/v1
+kind: Certificate
+metadata:
+  labels:
+    some: label
+  name: hippo-tls
+  namespace: ns1
+spec:
+  commonName: one
+  dnsNames:
+  - one
+  - two
+  issuerRef:
+    group: cert-manager.io
+    kind: ClusterIssuer
+    name: some-issuer
+  privateKey:
+    algorithm: ECDSA
+    rotationPolicy: Always
+    size: 256
+  secretName: hippo-tls
+  secretTemplate:
+    labels:
+      some: label
+		`))
+	})
+
+	t.Run("Usages", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.CertManager.IssuerRef.Kind = ""
+		cluster.Spec.CertManager.IssuerRef.Group = ""
+
+		certificate := Certificate(cluster, meta, "_crunchyrepl", nil, "client auth")
+
+		assert.Assert(t, cmp.MarshalMatches(certificate.Object["spec"], `
+commonName: _crunchyrepl
+issuerRef:
+  name: some-issuer
+privateKey:
+  algorithm: ECDSA
+  rotationPolicy: Always
+  size: 256
+secretName: hippo-tls
+secretTemplate:
+  labels:
+    some: label
+usages:
+- client auth
+		`))
+	})
+}
+
+func TestBundle(t *testing.T) {
+	cluster := new(v1beta1.PostgresCluster)
+	cluster.Namespace = "ns1"
+
+	bundle := Bundle(cluster, metav1.ObjectMeta{Name: "ns1-hippo-ca"}, []byte("PEM"))
+
+	assert.Assert(t, cmp.MarshalMatches(bundle, `
+apiVersion: trust.cert-manager.io/v1alpha1
+kind: Bundle
+metadata:
+  name: ns1-hippo-ca
+spec:
+  sources:
+  - inLine: PEM
+  target:
+    configMap:
+      key: ca.crt
+    namespaceSelector:
+      matchLabels:
+        kubernetes.io/metadata.name: ns1
+	`))
+}
@@ -0,0 +1,206 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgrescluster
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crunchydata/postgres-operator/internal/logging"
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+// backupQueueInterval is how often a cluster with queued backups checks
+// whether they can start. Scheduled backup Jobs belong to CronJobs, so their
+// progress does not trigger a reconcile of the cluster.
+const backupQueueInterval = 30 * time.Second
+
+// BackupQueue limits the number of pgBackRest backup Jobs that run at the same
+// time. Scheduled backups beyond the limit are created suspended and wait, in
+// the order they were created, for other backups to finish.
+type BackupQueue struct {
+	// Limit is the maximum number of backup Jobs that run at the same time.
+	// There is no limit when it is zero or less.
+	Limit int
+
+	// PerNamespace applies Limit to each namespace rather than to all the
+	// namespaces watched by the operator.
+	PerNamespace bool
+
+	mutex sync.Mutex
+
+	// admitted are Jobs this process resumed that may still appear suspended
+	// in the cache.
+	admitted map[types.UID]struct{}
+}
+
+// enabled returns whether or not q limits backup Jobs.
+func (q *BackupQueue) enabled() bool { return q != nil && q.Limit > 0 }
+
+// observe sorts jobs into those that are running and those that are queued.
+// Queued Jobs are returned in the order they should start.
+func (q *BackupQueue) observe(jobs []batchv1.Job) (running int, queued []*batchv1.Job) {
+	for i := range jobs {
+		job := &jobs[i]
+
+		// Verification reads the repository but is not a backup.
+		if job.Labels[naming.LabelPGBackRestCronJob] == verify ||
+			job.Labels[naming.LabelPGBackRestBackup] == "" ||
+			jobCompleted(job) || jobFailed(job) {
+			continue
+		}
+
+		_, admitted := q.admitted[job.UID]
+		suspended := job.Spec.Suspend != nil && *job.Spec.Suspend
+
+		switch {
+		case suspended && admitted:
+			running++
+		case suspended && job.DeletionTimestamp == nil &&
+			job.Labels[naming.LabelPGBackRestBackup] == string(naming.BackupScheduled):
+			queued = append(queued, job)
+		case !suspended:
+			// The cache has caught up to this Job being resumed.
+			delete(q.admitted, job.UID)
+			running++
+		}
+	}
+
+	// Backups start in the order they were scheduled. Break ties consistently.
+	sort.SliceStable(queued, func(i, j int) bool {
+		a, b := queued[i], queued[j]
+		if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+			return a.CreationTimestamp.Before(&b.CreationTimestamp)
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	return running, queued
+}
+
+// +kubebuilder:rbac:groups="batch",resources="jobs",verbs={list,patch,delete}
+
+// reconcileBackupQueue starts queued backup Jobs when fewer than the limit are
+// running and records the position of the cluster's queued backups in its
+// status. It returns how long until the queue should be checked again, or zero
+// when the cluster has no backups waiting.
+func (r *Reconciler) reconcileBackupQueue(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+) (time.Duration, error) {
+	log := logging.FromContext(ctx).WithValues("reconcileResource", "backupQueue")
+	status := cluster.Status.PGBackRest
+
+	// Queued backups should not start while the cluster is shutdown or a
+	// standby. Those waiting have not started; delete them like the CronJobs
+	// would have, had they been suspended sooner.
+	halted := (cluster.Spec.Shutdown != nil && *cluster.Spec.Shutdown) ||
+		(cluster.Spec.Standby != nil && cluster.Spec.Standby.Enabled)
+
+	// Without a limit, only the cluster's Jobs that were queued when there was
+	// a limit need attention.
+	options := []client.ListOption{
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels{
+			naming.LabelCluster:          cluster.Name,
+			naming.LabelPGBackRestBackup: string(naming.BackupScheduled),
+		},
+	}
+	if r.BackupQueue.enabled() {
+		options = []client.ListOption{client.HasLabels{naming.LabelPGBackRestBackup}}
+		if r.BackupQueue.PerNamespace {
+			options = append(options, client.InNamespace(cluster.Namespace))
+		}
+	}
+
+	jobs := &batchv1.JobList{}
+	if err := errors.WithStack(r.Client.List(ctx, jobs, options...)); err != nil {
+		return 0, err
+	}
+
+	queue := r.BackupQueue
+	if !queue.enabled() {
+		queue = &BackupQueue{}
+	}
+
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	if queue.admitted == nil {
+		queue.admitted = make(map[types.UID]struct{})
+	}
+
+	running, queued := queue.observe(jobs.Items)
+	ours := func(job *batchv1.Job) bool {
+		return job.Namespace == cluster.Namespace &&
+			job.Labels[naming.LabelCluster] == cluster.Name
+	}
+
+	var err error
+	var waiting []*batchv1.Job
+	for _, job := range queued {
+		switch {
+		case err != nil:
+			waiting = append(waiting, job)
+
+		case halted && ours(job):
+			err = errors.WithStack(client.IgnoreNotFound(r.Client.Delete(ctx, job,
+				client.Preconditions{UID: &job.UID},
+				client.PropagationPolicy(metav1.DeletePropagationBackground))))
+
+		case !queue.enabled() || running < queue.Limit:
+			err = errors.WithStack(r.patch(ctx, job, client.RawPatch(
+				client.Merge.Type(), []byte(`{"spec":{"suspend":false}}`))))
+			if err == nil {
+				queue.admitted[job.UID] = struct{}{}
+				running++
+				log.V(1).Info("started queued backup", "job", client.ObjectKeyFromObject(job))
+			} else {
+				waiting = append(waiting, job)
+			}
+
+		default:
+			waiting = append(waiting, job)
+		}
+	}
+
+	// Record the position of each of the cluster's queued backups. Each
+	// CronJob has at most one unfinished Job, and a queued Job has not started.
+	var requeue time.Duration
+	if status != nil {
+		for i := range status.ScheduledBackups {
+			status.ScheduledBackups[i].Queued = false
+			status.ScheduledBackups[i].QueuePosition = 0
+		}
+	}
+	for position, job := range waiting {
+		if !ours(job) {
+			continue
+		}
+		requeue = backupQueueInterval
+
+		for i := 0; status != nil && i < len(status.ScheduledBackups); i++ {
+			sbs := &status.ScheduledBackups[i]
+			if len(job.OwnerReferences) > 0 &&
+				sbs.CronJobName == job.OwnerReferences[0].Name &&
+				sbs.StartTime == nil && sbs.CompletionTime == nil {
+				sbs.Queued = true
+				sbs.QueuePosition = int32(position + 1) // #nosec G115 the queue is small
+			}
+		}
+	}
+
+	return requeue, err
+}
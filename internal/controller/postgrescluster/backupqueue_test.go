@@ -0,0 +1,185 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgrescluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+func TestBackupQueueObserve(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	job := func(name string, created time.Duration, suspend bool, labels map[string]string) batchv1.Job {
+		var job batchv1.Job
+		job.Namespace, job.Name, job.UID = "ns1", name, types.UID(name)
+		job.CreationTimestamp = metav1.NewTime(start.Add(created))
+		job.Labels = labels
+		job.Spec.Suspend = initialize.Bool(suspend)
+		return job
+	}
+
+	scheduled := map[string]string{
+		naming.LabelPGBackRestBackup:  string(naming.BackupScheduled),
+		naming.LabelPGBackRestCronJob: full,
+	}
+	manual := map[string]string{
+		naming.LabelPGBackRestBackup: string(naming.BackupManual),
+	}
+
+	finished := job("finished", 0, false, scheduled)
+	finished.Status.Conditions = []batchv1.JobCondition{{
+		Type: batchv1.JobComplete, Status: corev1.ConditionTrue,
+	}}
+
+	jobs := []batchv1.Job{
+		finished,
+		job("running", time.Minute, false, scheduled),
+		job("manual", time.Minute, false, manual),
+		job("later", 3*time.Minute, true, scheduled),
+		job("sooner", 2*time.Minute, true, scheduled),
+		job("admitted", 0, true, scheduled),
+		job("verify", 0, true, map[string]string{
+			naming.LabelPGBackRestBackup:  string(naming.BackupScheduled),
+			naming.LabelPGBackRestCronJob: verify,
+		}),
+	}
+
+	q := &BackupQueue{Limit: 2, admitted: map[types.UID]struct{}{
+		"admitted": {}, "running": {},
+	}}
+
+	running, queued := q.observe(jobs)
+	assert.Equal(t, running, 3, "expected running, manual, and admitted")
+	assert.Equal(t, len(queued), 2)
+	assert.Equal(t, queued[0].Name, "sooner")
+	assert.Equal(t, queued[1].Name, "later")
+
+	// Jobs that appear resumed are no longer tracked.
+	assert.DeepEqual(t, q.admitted, map[types.UID]struct{}{"admitted": {}})
+}
+
+func TestReconcileBackupQueue(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	job := func(namespace, cluster, cronjob string, created time.Duration) *batchv1.Job {
+		job := &batchv1.Job{}
+		job.Namespace, job.Name = namespace, cronjob+"-1"
+		job.UID = types.UID(namespace + "-" + job.Name)
+		job.CreationTimestamp = metav1.NewTime(start.Add(created))
+		job.Labels = naming.PGBackRestCronJobLabels(cluster, "repo1", full)
+		job.OwnerReferences = []metav1.OwnerReference{{Kind: "CronJob", Name: cronjob}}
+		job.Spec.Suspend = initialize.Bool(true)
+		return job
+	}
+
+	cluster := func(namespace, name string) *v1beta1.PostgresCluster {
+		cluster := &v1beta1.PostgresCluster{}
+		cluster.Namespace, cluster.Name = namespace, name
+		cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+			ScheduledBackups: []v1beta1.PGBackRestScheduledBackupStatus{
+				{CronJobName: name + "-repo1-full"},
+			},
+		}
+		return cluster
+	}
+
+	suspended := func(t testing.TB, c client.Client, namespace, name string) bool {
+		job := &batchv1.Job{}
+		assert.NilError(t, c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, job))
+		return job.Spec.Suspend != nil && *job.Spec.Suspend
+	}
+
+	t.Run("Global", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(runtime.Scheme).WithObjects(
+			job("ns1", "hippo", "hippo-repo1-full", 0),
+			job("ns2", "rhino", "rhino-repo1-full", time.Minute),
+		).Build()
+
+		r := &Reconciler{Client: c, BackupQueue: &BackupQueue{Limit: 1}}
+
+		// The second cluster waits behind the first.
+		rhino := cluster("ns2", "rhino")
+		requeue, err := r.reconcileBackupQueue(ctx, rhino)
+		assert.NilError(t, err)
+		assert.Equal(t, requeue, backupQueueInterval)
+		assert.Assert(t, !suspended(t, c, "ns1", "hippo-repo1-full-1"))
+		assert.Assert(t, suspended(t, c, "ns2", "rhino-repo1-full-1"))
+		assert.Equal(t, rhino.Status.PGBackRest.ScheduledBackups[0].Queued, true)
+		assert.Equal(t, rhino.Status.PGBackRest.ScheduledBackups[0].QueuePosition, int32(1))
+
+		// The first cluster has nothing waiting.
+		hippo := cluster("ns1", "hippo")
+		requeue, err = r.reconcileBackupQueue(ctx, hippo)
+		assert.NilError(t, err)
+		assert.Equal(t, requeue, time.Duration(0))
+		assert.Equal(t, hippo.Status.PGBackRest.ScheduledBackups[0].Queued, false)
+		assert.Assert(t, suspended(t, c, "ns2", "rhino-repo1-full-1"))
+	})
+
+	t.Run("PerNamespace", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(runtime.Scheme).WithObjects(
+			job("ns1", "hippo", "hippo-repo1-full", 0),
+			job("ns2", "rhino", "rhino-repo1-full", time.Minute),
+		).Build()
+
+		r := &Reconciler{Client: c, BackupQueue: &BackupQueue{Limit: 1, PerNamespace: true}}
+
+		// Each namespace runs one backup.
+		requeue, err := r.reconcileBackupQueue(ctx, cluster("ns2", "rhino"))
+		assert.NilError(t, err)
+		assert.Equal(t, requeue, time.Duration(0))
+		assert.Assert(t, suspended(t, c, "ns1", "hippo-repo1-full-1"))
+		assert.Assert(t, !suspended(t, c, "ns2", "rhino-repo1-full-1"))
+	})
+
+	t.Run("NoLimit", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(runtime.Scheme).WithObjects(
+			job("ns1", "hippo", "hippo-repo1-full", 0),
+			job("ns1", "hippo", "hippo-repo1-diff", time.Minute),
+		).Build()
+
+		// Backups queued under a previous limit start.
+		r := &Reconciler{Client: c}
+		requeue, err := r.reconcileBackupQueue(ctx, cluster("ns1", "hippo"))
+		assert.NilError(t, err)
+		assert.Equal(t, requeue, time.Duration(0))
+		assert.Assert(t, !suspended(t, c, "ns1", "hippo-repo1-full-1"))
+		assert.Assert(t, !suspended(t, c, "ns1", "hippo-repo1-diff-1"))
+	})
+
+	t.Run("Shutdown", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(runtime.Scheme).WithObjects(
+			job("ns1", "hippo", "hippo-repo1-full", 0),
+		).Build()
+
+		r := &Reconciler{Client: c, BackupQueue: &BackupQueue{Limit: 1}}
+
+		// Queued backups of a cluster that is shutdown never start.
+		hippo := cluster("ns1", "hippo")
+		hippo.Spec.Shutdown = initialize.Bool(true)
+
+		_, err := r.reconcileBackupQueue(ctx, hippo)
+		assert.NilError(t, err)
+
+		jobs := &batchv1.JobList{}
+		assert.NilError(t, c.List(ctx, jobs))
+		assert.Equal(t, len(jobs.Items), 0)
+	})
+}
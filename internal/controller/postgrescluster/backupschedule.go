@@ -0,0 +1,188 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgrescluster
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+// cronDescriptors are the schedules Kubernetes accepts in place of five fields.
+// - https://docs.k8s.io/concepts/workloads/controllers/cron-jobs/#schedule-syntax
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// backupScheduleJitter returns the number of minutes by which the backup
+// schedules of cluster are delayed. It is derived from the UID of cluster so
+// that it does not change but differs between clusters.
+func backupScheduleJitter(cluster *v1beta1.PostgresCluster) int {
+	scheduling := cluster.Spec.Backups.PGBackRest.Scheduling
+	if scheduling == nil || scheduling.JitterMinutes == nil || *scheduling.JitterMinutes <= 0 {
+		return 0
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(cluster.UID))
+	return int(hash.Sum32() % uint32(*scheduling.JitterMinutes+1)) // #nosec G115 validated to be 0–59
+}
+
+// backupScheduleTimeZone returns the time zone of the backup schedules of
+// cluster, if any.
+func backupScheduleTimeZone(cluster *v1beta1.PostgresCluster) *string {
+	if scheduling := cluster.Spec.Backups.PGBackRest.Scheduling; scheduling != nil {
+		return scheduling.TimeZone
+	}
+	return nil
+}
+
+// jitterSchedule returns schedule with its minute moved later by minutes.
+// The delay is limited to the end of the hour so that no run moves into the
+// next hour, or earlier by wrapping within it. Schedules that run every minute,
+// or at minutes that cannot be moved without changing their meaning, are
+// returned unchanged.
+func jitterSchedule(schedule string, minutes int) string {
+	if minutes <= 0 {
+		return schedule
+	}
+	if expanded, ok := cronDescriptors[strings.TrimSpace(schedule)]; ok {
+		schedule = expanded
+	}
+
+	fields := strings.Fields(schedule)
+	if len(fields) != 5 {
+		return schedule
+	}
+
+	parts := strings.Split(fields[0], ",")
+	for _, part := range parts {
+		if n, err := strconv.Atoi(part); err == nil && n >= 0 && n < 60 {
+			minutes = min(minutes, 59-n)
+		}
+	}
+	for i, part := range parts {
+		if n, err := strconv.Atoi(part); err == nil && n >= 0 && n < 60 {
+			parts[i] = strconv.Itoa(n + minutes)
+			continue
+		}
+
+		// Steps over the whole hour, such as "*/15", start at the offset
+		// within the step, such as "5-59/15".
+		if step, found := strings.CutPrefix(part, "*/"); found {
+			if n, err := strconv.Atoi(step); err == nil && n > 0 && n < 60 {
+				parts[i] = fmt.Sprintf("%d-59/%d", minutes%n, n)
+				continue
+			}
+		}
+		return schedule
+	}
+
+	fields[0] = strings.Join(parts, ",")
+	return strings.Join(fields, " ")
+}
+
+// backupSchedule returns the CronJob schedule of a backup of cluster that is
+// configured to run on schedule.
+func backupSchedule(cluster *v1beta1.PostgresCluster, schedule string) string {
+	return jitterSchedule(schedule, backupScheduleJitter(cluster))
+}
+
+// setScheduledBackupNextTimes records in the status of cluster when each of
+// its backup schedules next runs. Verification is not a backup and is omitted.
+// It returns how long until the soonest of those, or zero when there are none.
+func setScheduledBackupNextTimes(cluster *v1beta1.PostgresCluster, now time.Time) time.Duration {
+	if cluster.Status.PGBackRest == nil {
+		return 0
+	}
+
+	// CronJobs are suspended when the cluster is shutdown or a standby.
+	if (cluster.Spec.Shutdown != nil && *cluster.Spec.Shutdown) ||
+		(cluster.Spec.Standby != nil && cluster.Spec.Standby.Enabled) {
+		return 0
+	}
+	status := cluster.Status.PGBackRest
+
+	// Kubernetes interprets schedules without a time zone in the time zone of
+	// the controller manager. Assume that is UTC.
+	location := time.UTC
+	if zone := backupScheduleTimeZone(cluster); zone != nil {
+		var err error
+		if location, err = time.LoadLocation(*zone); err != nil {
+			// The CronJob cannot be created; see the events of the cluster.
+			return 0
+		}
+	}
+
+	var soonest time.Duration
+	for _, repo := range cluster.Spec.Backups.PGBackRest.Repos {
+		if repo.BackupSchedules == nil {
+			continue
+		}
+
+		for _, scheduled := range []struct {
+			backupType string
+			schedule   *string
+		}{
+			{full, repo.BackupSchedules.Full},
+			{differential, repo.BackupSchedules.Differential},
+			{incremental, repo.BackupSchedules.Incremental},
+		} {
+			if scheduled.schedule == nil {
+				continue
+			}
+
+			parsed, err := cron.ParseStandard(backupSchedule(cluster, *scheduled.schedule))
+			if err != nil {
+				continue
+			}
+
+			next := parsed.Next(now.In(location))
+			if next.IsZero() {
+				continue
+			}
+			if until := next.Sub(now); soonest == 0 || until < soonest {
+				soonest = until
+			}
+
+			// Set the time on every Job of the schedule. When there are none
+			// yet, add an entry for the schedule.
+			found := false
+			for i := range status.ScheduledBackups {
+				if sbs := &status.ScheduledBackups[i]; sbs.RepoName == repo.Name &&
+					sbs.Type == scheduled.backupType {
+					found = true
+					sbs.NextScheduleTime = &metav1.Time{Time: next.UTC()}
+				}
+			}
+			if !found {
+				status.ScheduledBackups = append(status.ScheduledBackups,
+					v1beta1.PGBackRestScheduledBackupStatus{
+						CronJobName: naming.PGBackRestCronJob(cluster, scheduled.backupType, repo.Name).Name,
+						RepoName:    repo.Name,
+						Type:        scheduled.backupType,
+
+						NextScheduleTime: &metav1.Time{Time: next.UTC()},
+					})
+			}
+		}
+	}
+
+	return soonest
+}
@@ -0,0 +1,156 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgrescluster
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+func TestJitterSchedule(t *testing.T) {
+	for _, tt := range []struct {
+		schedule string
+		minutes  int
+		expected string
+	}{
+		{schedule: "0 1 * * *", minutes: 0, expected: "0 1 * * *"},
+		{schedule: "0 1 * * *", minutes: 17, expected: "17 1 * * *"},
+		{schedule: "0,30 * * * *", minutes: 7, expected: "7,37 * * * *"},
+		{schedule: "*/15 * * * *", minutes: 20, expected: "5-59/15 * * * *"},
+		{schedule: "@daily", minutes: 9, expected: "9 0 * * *"},
+		{schedule: "@hourly", minutes: 59, expected: "59 * * * *"},
+
+		// The delay stops at the end of the hour rather than wrapping.
+		{schedule: "50 1 * * 0", minutes: 15, expected: "59 1 * * 0"},
+		{schedule: "0,40 * * * *", minutes: 30, expected: "19,59 * * * *"},
+		{schedule: "59 23 * * *", minutes: 10, expected: "59 23 * * *"},
+
+		// These are unchanged.
+		{schedule: "* * * * *", minutes: 5, expected: "* * * * *"},
+		{schedule: "10-20 * * * *", minutes: 5, expected: "10-20 * * * *"},
+		{schedule: "CRON_TZ=UTC 0 1 * * *", minutes: 5, expected: "CRON_TZ=UTC 0 1 * * *"},
+	} {
+		assert.Equal(t, jitterSchedule(tt.schedule, tt.minutes), tt.expected,
+			"schedule %q, minutes %d", tt.schedule, tt.minutes)
+	}
+}
+
+func TestBackupScheduleJitter(t *testing.T) {
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.UID = "a7f8ec08-ba6c-4c9e-9a38-9ddae6c0fb96"
+
+	assert.Equal(t, backupScheduleJitter(cluster), 0)
+
+	cluster.Spec.Backups.PGBackRest.Scheduling = &v1beta1.PGBackRestBackupScheduling{
+		JitterMinutes: initialize.Int32(30),
+	}
+
+	// The delay is within the limit and does not change.
+	jitter := backupScheduleJitter(cluster)
+	assert.Assert(t, jitter >= 0 && jitter <= 30, "got %d", jitter)
+	assert.Equal(t, backupScheduleJitter(cluster.DeepCopy()), jitter)
+
+	// Clusters are spread out.
+	seen := map[int]bool{}
+	for _, uid := range []string{"one", "two", "three", "four", "five", "six"} {
+		other := cluster.DeepCopy()
+		other.UID = types.UID(uid)
+		seen[backupScheduleJitter(other)] = true
+	}
+	assert.Assert(t, len(seen) > 1, "expected different delays, got %v", seen)
+}
+
+func TestSetScheduledBackupNextTimes(t *testing.T) {
+	now := time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.Name = "hippo"
+	cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{{
+		Name: "repo1",
+		BackupSchedules: &v1beta1.PGBackRestBackupSchedules{
+			Full:         initialize.String("0 1 * * 0"),
+			Differential: initialize.String("0 1 * * 1-6"),
+			Verify:       initialize.String("0 3 * * *"),
+		},
+	}}
+
+	t.Run("NoStatus", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		assert.Equal(t, setScheduledBackupNextTimes(cluster, now), time.Duration(0))
+	})
+
+	t.Run("UTC", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+			ScheduledBackups: []v1beta1.PGBackRestScheduledBackupStatus{
+				{CronJobName: "hippo-repo1-diff", RepoName: "repo1", Type: differential},
+				{CronJobName: "hippo-repo1-diff", RepoName: "repo1", Type: differential},
+			},
+		}
+
+		// The next backup is a differential on Wednesday.
+		requeue := setScheduledBackupNextTimes(cluster, now)
+		assert.Equal(t, requeue, 19*time.Hour+53*time.Minute+53*time.Second)
+
+		scheduled := cluster.Status.PGBackRest.ScheduledBackups
+		assert.Equal(t, len(scheduled), 3, "expected an entry for the full schedule")
+
+		for _, sbs := range scheduled[:2] {
+			assert.Equal(t, sbs.NextScheduleTime.Time,
+				time.Date(2025, 3, 5, 1, 0, 0, 0, time.UTC))
+		}
+		assert.Equal(t, scheduled[2].CronJobName, "hippo-repo1-full")
+		assert.Equal(t, scheduled[2].RepoName, "repo1")
+		assert.Equal(t, scheduled[2].Type, full)
+		assert.Equal(t, scheduled[2].NextScheduleTime.Time,
+			time.Date(2025, 3, 9, 1, 0, 0, 0, time.UTC))
+	})
+
+	t.Run("TimeZoneAndJitter", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.UID = "a7f8ec08-ba6c-4c9e-9a38-9ddae6c0fb96"
+		cluster.Spec.Backups.PGBackRest.Scheduling = &v1beta1.PGBackRestBackupScheduling{
+			TimeZone:      initialize.String("America/New_York"),
+			JitterMinutes: initialize.Int32(30),
+		}
+		cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{}
+
+		setScheduledBackupNextTimes(cluster, now)
+		jitter := time.Duration(backupScheduleJitter(cluster)) * time.Minute
+
+		// New York is five hours behind UTC in early March.
+		scheduled := cluster.Status.PGBackRest.ScheduledBackups
+		assert.Equal(t, len(scheduled), 2)
+		assert.Equal(t, scheduled[1].Type, differential)
+		assert.Equal(t, scheduled[1].NextScheduleTime.Time,
+			time.Date(2025, 3, 4, 6, 0, 0, 0, time.UTC).Add(jitter))
+	})
+
+	t.Run("InvalidTimeZone", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Backups.PGBackRest.Scheduling = &v1beta1.PGBackRestBackupScheduling{
+			TimeZone: initialize.String("Mars/Olympus_Mons"),
+		}
+		cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{}
+
+		assert.Equal(t, setScheduledBackupNextTimes(cluster, now), time.Duration(0))
+		assert.Equal(t, len(cluster.Status.PGBackRest.ScheduledBackups), 0)
+	})
+
+	t.Run("Shutdown", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Shutdown = initialize.Bool(true)
+		cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{}
+
+		assert.Equal(t, setScheduledBackupNextTimes(cluster, now), time.Duration(0))
+		assert.Equal(t, len(cluster.Status.PGBackRest.ScheduledBackups), 0)
+	})
+}
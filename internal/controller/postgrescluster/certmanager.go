@@ -0,0 +1,203 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgrescluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crunchydata/postgres-operator/internal/certmanager"
+	"github.com/crunchydata/postgres-operator/internal/kubernetes"
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+// +kubebuilder:rbac:groups="cert-manager.io",resources="certificates",verbs={create,patch}
+// +kubebuilder:rbac:groups="",resources="secrets",verbs={get}
+
+// reconcileCertManagerCertificate asks cert-manager to issue a certificate for
+// commonName and dnsNames into a Secret described by meta. It returns that
+// Secret, which is empty until cert-manager has issued the certificate.
+func (r *Reconciler) reconcileCertManagerCertificate(
+	ctx context.Context, cluster *v1beta1.PostgresCluster, meta metav1.ObjectMeta,
+	purpose, commonName string, dnsNames []string, usages ...string,
+) (*corev1.Secret, error) {
+	meta.Annotations = naming.Merge(cluster.Spec.Metadata.GetAnnotationsOrNil())
+	meta.Labels = naming.Merge(
+		cluster.Spec.Metadata.GetLabelsOrNil(),
+		map[string]string{
+			naming.LabelCluster:            cluster.Name,
+			naming.LabelClusterCertificate: purpose,
+		})
+
+	intent := certmanager.Certificate(cluster, meta, commonName, dnsNames, usages...)
+
+	err := errors.WithStack(r.setControllerReference(cluster, intent))
+	if err == nil {
+		err = errors.WithStack(r.apply(ctx, intent))
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Namespace: meta.Namespace, Name: meta.Name,
+	}}
+	if err == nil {
+		err = errors.WithStack(client.IgnoreNotFound(
+			r.Client.Get(ctx, client.ObjectKeyFromObject(secret), secret)))
+	}
+	return secret, err
+}
+
+// +kubebuilder:rbac:groups="trust.cert-manager.io",resources="bundles",verbs={create,patch}
+
+// reconcileCertManagerBundle asks trust-manager to copy the certificate
+// authority in secret to a ConfigMap in the namespace of cluster. It does
+// nothing when trust-manager is not installed or the authority is unknown.
+func (r *Reconciler) reconcileCertManagerBundle(
+	ctx context.Context, cluster *v1beta1.PostgresCluster, secret *corev1.Secret,
+) error {
+	authority := secret.Data[rootCertFile]
+
+	if !kubernetes.Has(ctx, certmanager.BundleAPI) || len(authority) == 0 {
+		return nil
+	}
+
+	metadata := naming.CertManagerTrustBundle(cluster)
+	metadata.Annotations = naming.Merge(cluster.Spec.Metadata.GetAnnotationsOrNil())
+	metadata.Labels = naming.Merge(
+		cluster.Spec.Metadata.GetLabelsOrNil(),
+		map[string]string{
+			naming.LabelCluster: cluster.Name,
+		})
+
+	// Bundles are not namespaced, so they cannot be owned by the cluster.
+	// They are deleted by [Reconciler.deleteCertManagerBundle] instead.
+	return errors.WithStack(r.apply(ctx, certmanager.Bundle(cluster, metadata, authority)))
+}
+
+// +kubebuilder:rbac:groups="trust.cert-manager.io",resources="bundles",verbs={delete}
+
+// deleteCertManagerBundle removes the trust-manager Bundle of cluster, if any.
+func (r *Reconciler) deleteCertManagerBundle(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+) error {
+	if !kubernetes.Has(ctx, certmanager.BundleAPI) || (!certmanager.Requested(cluster) &&
+		meta.FindStatusCondition(cluster.Status.Conditions, certmanager.ConditionIssued) == nil) {
+		return nil
+	}
+
+	bundle := certmanager.Bundle(cluster, naming.CertManagerTrustBundle(cluster), nil)
+	err := client.IgnoreNotFound(r.Client.Delete(ctx, bundle))
+	if meta.IsNoMatchError(err) {
+		err = nil
+	}
+	return errors.WithStack(err)
+}
+
+// certManagerCertificates returns the Certificates, and their Secrets, that
+// cert-manager issues for cluster. Custom certificates take their place.
+func certManagerCertificates(cluster *v1beta1.PostgresCluster) []metav1.ObjectMeta {
+	var result []metav1.ObjectMeta
+	if cluster.Spec.CustomTLSSecret == nil {
+		result = append(result, naming.CertManagerClusterCertificate(cluster))
+	}
+	if cluster.Spec.CustomReplicationClientTLSSecret == nil {
+		result = append(result, naming.CertManagerReplicationCertificate(cluster))
+	}
+	if cluster.Spec.Proxy != nil && cluster.Spec.Proxy.PGBouncer != nil &&
+		cluster.Spec.Proxy.PGBouncer.CustomTLSSecret == nil {
+		result = append(result, naming.CertManagerPGBouncerCertificate(cluster))
+	}
+	return result
+}
+
+// +kubebuilder:rbac:groups="",resources="secrets",verbs={get,delete}
+// +kubebuilder:rbac:groups="cert-manager.io",resources="certificates",verbs={delete}
+
+// reconcileCertManager sets the condition that switches cluster to the
+// certificates of cert-manager once it has issued all of them. When cluster
+// no longer asks for them, it deletes the Certificates, their Secrets, and
+// the trust-manager Bundle.
+func (r *Reconciler) reconcileCertManager(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+) error {
+	if !certmanager.Enabled(ctx, cluster) {
+		if meta.FindStatusCondition(cluster.Status.Conditions, certmanager.ConditionIssued) == nil {
+			return nil
+		}
+
+		err := r.deleteCertManagerBundle(ctx, cluster)
+		for _, metadata := range []metav1.ObjectMeta{
+			naming.CertManagerClusterCertificate(cluster),
+			naming.CertManagerReplicationCertificate(cluster),
+			naming.CertManagerPGBouncerCertificate(cluster),
+		} {
+			certificate := &unstructured.Unstructured{}
+			certificate.SetAPIVersion("cert-manager.io/v1")
+			certificate.SetKind("Certificate")
+			certificate.SetNamespace(metadata.Namespace)
+			certificate.SetName(metadata.Name)
+			secret := &corev1.Secret{ObjectMeta: metadata}
+
+			if err == nil {
+				err = client.IgnoreNotFound(r.Client.Delete(ctx, certificate))
+				if meta.IsNoMatchError(err) {
+					err = nil
+				}
+			}
+			if err == nil {
+				err = client.IgnoreNotFound(
+					r.Client.Get(ctx, client.ObjectKeyFromObject(secret), secret))
+			}
+
+			// cert-manager leaves the Secret of a deleted Certificate. Delete
+			// it when it has the labels of the Certificate.
+			if err == nil && secret.Labels[naming.LabelCluster] == cluster.Name &&
+				secret.Labels[naming.LabelClusterCertificate] != "" {
+				uid, version := secret.UID, secret.ResourceVersion
+				err = client.IgnoreNotFound(r.Client.Delete(ctx, secret,
+					client.Preconditions{UID: &uid, ResourceVersion: &version}))
+			}
+		}
+		if err == nil {
+			meta.RemoveStatusCondition(&cluster.Status.Conditions, certmanager.ConditionIssued)
+		}
+		return errors.WithStack(err)
+	}
+
+	condition := metav1.Condition{
+		Type:               certmanager.ConditionIssued,
+		ObservedGeneration: cluster.GetGeneration(),
+		Status:             metav1.ConditionTrue,
+		Reason:             "Issued",
+		Message:            "cert-manager has issued every certificate",
+	}
+
+	for _, metadata := range certManagerCertificates(cluster) {
+		secret := &corev1.Secret{ObjectMeta: metadata}
+		err := errors.WithStack(client.IgnoreNotFound(
+			r.Client.Get(ctx, client.ObjectKeyFromObject(secret), secret)))
+		if err != nil {
+			return err
+		}
+		if !certmanager.Issued(secret) {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = "Pending"
+			condition.Message = fmt.Sprintf(
+				"Waiting for cert-manager to issue %q; using the operator's certificates until then",
+				metadata.Name)
+			break
+		}
+	}
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+	return nil
+}
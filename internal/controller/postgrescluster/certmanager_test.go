@@ -0,0 +1,89 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgrescluster
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/crunchydata/postgres-operator/internal/certmanager"
+	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
+	"github.com/crunchydata/postgres-operator/internal/kubernetes"
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+func TestReconcileCertManager(t *testing.T) {
+	ctx := kubernetes.NewAPIContext(context.Background(), kubernetes.NewAPISet(
+		kubernetes.API{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"},
+	))
+
+	cluster := v1beta1.NewPostgresCluster()
+	cluster.Namespace, cluster.Name = "ns1", "hippo"
+	cluster.Spec.CertManager = &v1beta1.CertManagerSpec{
+		IssuerRef: v1beta1.CertManagerIssuerReference{Name: "ca"},
+	}
+
+	issued := func(metadata metav1.ObjectMeta) *corev1.Secret {
+		secret := &corev1.Secret{ObjectMeta: metadata}
+		secret.Labels = map[string]string{
+			naming.LabelCluster:            "hippo",
+			naming.LabelClusterCertificate: "some-purpose",
+		}
+		secret.Data = map[string][]byte{
+			"tls.crt": []byte("cert"), "tls.key": []byte("key"), "ca.crt": []byte("ca"),
+		}
+		return secret
+	}
+
+	serverSecret := issued(naming.CertManagerClusterCertificate(cluster))
+	r := &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(runtime.Scheme).
+			WithObjects(serverSecret).Build(),
+	}
+
+	// The operator's certificates are used until cert-manager issues them all.
+	assert.NilError(t, r.reconcileCertManager(ctx, cluster))
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, certmanager.ConditionIssued)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Status, metav1.ConditionFalse)
+	assert.Equal(t, condition.Reason, "Pending")
+	assert.Assert(t, !certmanager.Active(ctx, cluster))
+
+	replicationSecret := issued(naming.CertManagerReplicationCertificate(cluster))
+	assert.NilError(t, r.Client.Create(ctx, replicationSecret))
+
+	assert.NilError(t, r.reconcileCertManager(ctx, cluster))
+	condition = meta.FindStatusCondition(cluster.Status.Conditions, certmanager.ConditionIssued)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Status, metav1.ConditionTrue)
+	assert.Assert(t, certmanager.Active(ctx, cluster))
+
+	t.Run("Removed", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.CertManager = nil
+
+		// A Secret without the labels of a Certificate is not deleted.
+		replicationSecret.Labels = nil
+		assert.NilError(t, r.Client.Update(ctx, replicationSecret))
+
+		assert.NilError(t, r.reconcileCertManager(ctx, cluster))
+		assert.Assert(t, meta.FindStatusCondition(cluster.Status.Conditions,
+			certmanager.ConditionIssued) == nil)
+
+		err := r.Client.Get(ctx, client.ObjectKeyFromObject(serverSecret), &corev1.Secret{})
+		assert.Assert(t, apierrors.IsNotFound(err), "got %v", err)
+		assert.NilError(t, r.Client.Get(ctx,
+			client.ObjectKeyFromObject(replicationSecret), &corev1.Secret{}))
+	})
+}
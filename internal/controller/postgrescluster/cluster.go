@@ -19,6 +19,7 @@ import (
 	"github.com/crunchydata/postgres-operator/internal/initialize"
 	"github.com/crunchydata/postgres-operator/internal/naming"
 	"github.com/crunchydata/postgres-operator/internal/patroni"
+	"github.com/crunchydata/postgres-operator/internal/pgbackrest"
 	"github.com/crunchydata/postgres-operator/internal/pki"
 	"github.com/crunchydata/postgres-operator/internal/postgres"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
@@ -405,6 +406,7 @@ func (r *Reconciler) reconcileDataSource(ctx context.Context,
 	case dataSource != nil:
 		configs = []string{dataSource.ClusterName, dataSource.RepoName}
 		configs = append(configs, dataSource.Options...)
+		configs = append(configs, pgbackrest.RecoveryTargetOptions(dataSource.Target)...)
 	case cloudDataSource != nil:
 		configs = []string{cloudDataSource.Stanza, cloudDataSource.Repo.Name}
 		configs = append(configs, cloudDataSource.Options...)
@@ -419,6 +421,13 @@ func (r *Reconciler) reconcileDataSource(ctx context.Context,
 			(configHash != restoreJob.GetAnnotations()[naming.PGBackRestConfigHash])
 	}
 
+	// Check the recovery target of a new in-place restore before tearing anything down.
+	// The catalog of the cluster is cleared once it is prepared for restore.
+	if restoreInPlaceRequested && restoreIDChanged && !restoringInPlace &&
+		!r.validateRecoveryTarget(cluster, cluster, dataSource) {
+		return false, nil
+	}
+
 	// Proceed with preparing the cluster for restore (e.g. tearing down runners, the DCS,
 	// etc.) if:
 	// - A restore is already in progress, but the cluster has not yet been prepared
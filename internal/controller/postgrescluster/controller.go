@@ -24,6 +24,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -55,6 +56,10 @@ type Reconciler struct {
 	) error
 	Recorder     record.EventRecorder
 	Registration registration.Registration
+
+	// BackupQueue limits the number of backup Jobs that run at the same time.
+	// There is no limit when it is nil.
+	BackupQueue *BackupQueue
 }
 
 // +kubebuilder:rbac:groups="",resources="events",verbs={create,patch}
@@ -304,6 +309,9 @@ func (r *Reconciler) Reconcile(
 	if err == nil {
 		clusterConfigMap, err = r.reconcileClusterConfigMap(ctx, cluster, pgHBAs, pgParameters)
 	}
+	if err == nil {
+		err = r.reconcileCertManager(ctx, cluster)
+	}
 	if err == nil {
 		clusterReplicationSecret, err = r.reconcileReplicationSecret(ctx, cluster, rootCA)
 	}
@@ -347,7 +355,31 @@ func (r *Reconciler) Reconcile(
 		err = r.reconcilePostgresDatabases(ctx, cluster, instances)
 	}
 	if err == nil {
-		err = r.reconcilePostgresUsers(ctx, cluster, instances)
+		var requeue time.Duration
+		if requeue, err = r.reconcilePostgresUsers(ctx, cluster, instances, rootCA); requeue > 0 &&
+			(result.RequeueAfter == 0 || requeue < result.RequeueAfter) {
+			result.RequeueAfter = requeue
+		}
+	}
+	if err == nil {
+		// Reconcile again when a password is due to rotate or be revoked.
+		if requeue := postgresUserRotationRequeue(cluster, time.Now()); requeue > 0 &&
+			(result.RequeueAfter == 0 || requeue < result.RequeueAfter) {
+			result.RequeueAfter = requeue
+		}
+
+		// Reconcile again to check whether the root certificate rotation can advance.
+		if requeue := rootCertificateRotationRequeue(cluster); requeue > 0 &&
+			(result.RequeueAfter == 0 || requeue < result.RequeueAfter) {
+			result.RequeueAfter = requeue
+		}
+	}
+	if err == nil {
+		var requeue time.Duration
+		if requeue, err = r.reconcilePostgresExtensions(ctx, cluster, instances); requeue > 0 &&
+			(result.RequeueAfter == 0 || requeue < result.RequeueAfter) {
+			result.RequeueAfter = requeue
+		}
 	}
 
 	if err == nil {
@@ -355,7 +387,8 @@ func (r *Reconciler) Reconcile(
 		if next, err = r.reconcilePGBackRest(ctx, cluster,
 			instances, rootCA, backupsSpecFound); err == nil && !next.IsZero() {
 			result.Requeue = result.Requeue || next.Requeue
-			if next.RequeueAfter > 0 {
+			if next.RequeueAfter > 0 &&
+				(result.RequeueAfter == 0 || next.RequeueAfter < result.RequeueAfter) {
 				result.RequeueAfter = next.RequeueAfter
 			}
 		}
@@ -383,6 +416,14 @@ func (r *Reconciler) Reconcile(
 		// Pods takes precedence.
 		err = r.handlePatroniRestarts(ctx, cluster, instances)
 	}
+	if err == nil {
+		err = r.reconcileCertificateStatus(ctx, cluster, instances,
+			primaryCertificate, clusterReplicationSecret)
+	}
+	if err == nil {
+		// This is after every certificate has been issued by the root.
+		err = r.reconcileRootCertificateLoaded(ctx, cluster, instances, rootCA)
+	}
 
 	// at this point everything reconciled successfully, and we can update the
 	// observedGeneration
@@ -486,5 +527,9 @@ func (r *Reconciler) SetupWithManager(mgr manager.Manager) error {
 		Watches(&corev1.Pod{}, r.watchPods()).
 		Watches(&appsv1.StatefulSet{},
 			r.controllerRefHandlerFuncs()). // watch all StatefulSets
+		Watches(&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, secret client.Object) []reconcile.Request {
+				return runtime.Requests(r.findPostgresClustersForSecret(ctx, client.ObjectKeyFromObject(secret))...)
+			})).
 		Complete(r)
 }
@@ -85,6 +85,13 @@ func (r *Reconciler) handleDelete(
 		return nil, err
 	}
 
+	// Bundles are not namespaced, so they are not garbage collected.
+	if err := r.deleteCertManagerBundle(ctx, cluster); err != nil {
+		return nil, err
+	}
+
+	deleteCertificateMetrics(cluster)
+
 	// Our finalizer logic is finished; remove our finalizer.
 	// The Finalizers field is shared by multiple controllers, but the
 	// server-side merge strategy does not work on our custom resource due to a
@@ -1456,7 +1456,7 @@ func (r *Reconciler) reconcileInstanceCertificates(
 	}
 	if err == nil {
 		err = patroni.InstanceCertificates(ctx,
-			root.Certificate, leafCert.Certificate,
+			root.Trust(), leafCert.Certificate,
 			leafCert.PrivateKey, instanceCerts)
 	}
 	if err == nil && backupsSpecFound {
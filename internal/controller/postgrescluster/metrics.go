@@ -0,0 +1,40 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgrescluster
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+var (
+	// certificateExpiration is when each certificate of a cluster expires.
+	certificateExpiration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "postgres_operator_certificate_expiration_timestamp_seconds",
+		Help: "When a certificate of a PostgresCluster expires, in seconds since the Unix epoch.",
+	}, []string{"namespace", "cluster", "certificate", "secret"})
+
+	// certificateExpiring is one for each certificate of a cluster that
+	// expires soon and zero for the others.
+	certificateExpiring = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "postgres_operator_certificate_expiring",
+		Help: "Whether a certificate of a PostgresCluster expires within 30 days.",
+	}, []string{"namespace", "cluster", "certificate", "secret"})
+)
+
+func init() {
+	// The manager serves these alongside the controller-runtime metrics.
+	metrics.Registry.MustRegister(certificateExpiration, certificateExpiring)
+}
+
+// deleteCertificateMetrics removes the certificate metrics of cluster.
+func deleteCertificateMetrics(cluster *v1beta1.PostgresCluster) {
+	labels := prometheus.Labels{"namespace": cluster.Namespace, "cluster": cluster.Name}
+
+	certificateExpiration.DeletePartialMatch(labels)
+	certificateExpiring.DeletePartialMatch(labels)
+}
@@ -16,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/crunchydata/postgres-operator/internal/certmanager"
 	"github.com/crunchydata/postgres-operator/internal/initialize"
 	"github.com/crunchydata/postgres-operator/internal/logging"
 	"github.com/crunchydata/postgres-operator/internal/naming"
@@ -367,6 +368,19 @@ func (r *Reconciler) reconcileReplicationSecret(
 		return custom, err
 	}
 
+	// when cert-manager is requested and installed, it issues the certificate;
+	// the operator issues one until cert-manager has issued them all
+	if certmanager.Enabled(ctx, cluster) {
+		secret, err := r.reconcileCertManagerCertificate(ctx, cluster,
+			naming.CertManagerReplicationCertificate(cluster), "replication-client-tls",
+			postgres.ReplicationUser, []string{postgres.ReplicationUser},
+			"client auth", "digital signature", "key encipherment")
+
+		if err != nil || certmanager.Active(ctx, cluster) {
+			return secret, err
+		}
+	}
+
 	existing := &corev1.Secret{ObjectMeta: naming.ReplicationClientCertSecret(cluster)}
 	err := errors.WithStack(client.IgnoreNotFound(
 		r.Client.Get(ctx, client.ObjectKeyFromObject(existing), existing)))
@@ -412,7 +426,7 @@ func (r *Reconciler) reconcileReplicationSecret(
 		err = errors.WithStack(err)
 	}
 	if err == nil {
-		intent.Data[naming.ReplicationCACert], err = root.Certificate.MarshalText()
+		intent.Data[naming.ReplicationCACert], err = root.Trust().MarshalText()
 		err = errors.WithStack(err)
 	}
 	if err == nil {
@@ -10,6 +10,7 @@ import (
 	"io"
 	"reflect"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -51,6 +52,10 @@ const (
 	// the manual backup for the current backup ID (as provided via annotation) was successful
 	ConditionManualBackupSuccessful = "PGBackRestManualBackupSuccessful"
 
+	// ConditionManualExpireSuccessful is the type used in a condition to indicate whether or not
+	// the manual expire for the current expire ID (as provided via annotation) was successful
+	ConditionManualExpireSuccessful = "PGBackRestManualExpireSuccessful"
+
 	// ConditionReplicaCreate is the type used in a condition to indicate whether or not
 	// pgBackRest can be utilized for replica creation
 	ConditionReplicaCreate = "PGBackRestReplicaCreate"
@@ -67,6 +72,14 @@ const (
 	// and in-place pgBackRest restore is in progress
 	ConditionPGBackRestRestoreProgressing = "PGBackRestoreProgressing"
 
+	// ConditionBackupsVerified is the type used in a condition to indicate whether or not
+	// the most recent "pgbackrest verify" of each repository found its backups usable
+	ConditionBackupsVerified = "BackupsVerified"
+
+	// ConditionArchivingHealthy is the type used in a condition to indicate whether or not
+	// the writable instance is archiving WAL to its repositories
+	ConditionArchivingHealthy = "ArchivingHealthy"
+
 	// EventRepoHostNotFound is used to indicate that a pgBackRest repository was not
 	// found when reconciling
 	EventRepoHostNotFound = "RepoDeploymentNotFound"
@@ -87,10 +100,34 @@ const (
 	// CronJob fails to create successfully
 	EventUnableToCreatePGBackRestCronJob = "UnableToCreatePGBackRestCronJob"
 
+	// EventBackupVerificationFailed is the event reason utilized when "pgbackrest verify"
+	// finds missing or corrupt files in a repository
+	EventBackupVerificationFailed = "BackupVerificationFailed"
+
+	// EventArchivingFailed is the event reason utilized when PostgreSQL has been unable to
+	// archive WAL for longer than archiveFailureThreshold
+	EventArchivingFailed = "ArchivingFailed"
+
+	// EventWALDropped is the event reason utilized when pgBackRest drops WAL
+	// because too much is waiting to be archived
+	EventWALDropped = "WALDropped"
+
+	// EventRestorePointCreated is the event reason utilized when a named restore point is
+	// created in PostgreSQL
+	EventRestorePointCreated = "RestorePointCreated"
+
+	// EventInvalidRestorePoint is the event reason utilized when a named restore point
+	// cannot be created
+	EventInvalidRestorePoint = "InvalidRestorePoint"
+
 	// ReasonReadyForRestore is the reason utilized within ConditionPGBackRestRestoreProgressing
 	// to indicate that the restore Job can proceed because the cluster is now ready to be
 	// restored (i.e. it has been properly prepared for a restore).
 	ReasonReadyForRestore = "ReadyForRestore"
+
+	// ReasonInvalidRecoveryTarget is the reason utilized within ConditionPGBackRestRestoreProgressing
+	// to indicate that the backups of the data source cannot reach the requested recovery target.
+	ReasonInvalidRecoveryTarget = "InvalidRecoveryTarget"
 )
 
 // backup types
@@ -100,16 +137,32 @@ const (
 	incremental  = "incr"
 )
 
+// verify is the pgBackRest command that checks the backups and WAL in a repository
+const verify = "verify"
+
 // regexRepoIndex is the regex used to obtain the repo index from a pgBackRest repo name
 var regexRepoIndex = regexp.MustCompile(`\d+`)
 
+// regexTypeOrTargetOption matches the pgBackRest "--type" and "--target"
+// options, NOT options that begin with them (e.g. "--target-timeline").
+var regexTypeOrTargetOption = regexp.MustCompile(`--(type|target)([ =]|$)`)
+
+// regexRestorePointName matches the names of restore points that can be the
+// target of a restore.
+var regexRestorePointName = regexp.MustCompile(`^[A-Za-z0-9_.:@-]{1,63}$`)
+
+// restorePointLimit is how many restore points are recorded in status.
+const restorePointLimit = 20
+
 // RepoResources is used to store various resources for pgBackRest repositories and
 // repository hosts
 type RepoResources struct {
 	hosts                   []*appsv1.StatefulSet
 	cronjobs                []*batchv1.CronJob
 	manualBackupJobs        []*batchv1.Job
+	manualExpireJobs        []*batchv1.Job
 	replicaCreateBackupJobs []*batchv1.Job
+	verifyJobs              []*batchv1.Job
 	pvcs                    []*corev1.PersistentVolumeClaim
 	sas                     []*corev1.ServiceAccount
 	roles                   []*rbacv1.Role
@@ -366,6 +419,18 @@ func (r *Reconciler) cleanupRepoResources(ctx context.Context,
 					delete = false
 				}
 			}
+		case hasLabel(naming.LabelPGBackRestExpire):
+			if !backupsSpecFound {
+				break
+			}
+			// If an expire Job is identified for a repo that no longer exists in the spec then
+			// delete it.  Otherwise add it to the slice and continue.
+			for _, repo := range postgresCluster.Spec.Backups.PGBackRest.Repos {
+				if repo.Name == owned.GetLabels()[naming.LabelPGBackRestRepo] {
+					ownedNoDelete = append(ownedNoDelete, owned)
+					delete = false
+				}
+			}
 		case hasLabel(naming.LabelPGBackRestRestore):
 			if !backupsSpecFound {
 				break
@@ -420,6 +485,8 @@ func backupScheduleFound(repo v1beta1.PGBackRestRepo, backupType string) bool {
 			return repo.BackupSchedules.Differential != nil
 		case incremental:
 			return repo.BackupSchedules.Incremental != nil
+		case verify:
+			return repo.BackupSchedules.Verify != nil
 		default:
 			return false
 		}
@@ -454,7 +521,8 @@ func unstructuredToRepoResources(kind string, repoResources *RepoResources,
 		if err != nil {
 			return errors.WithStack(err)
 		}
-		// we care about replica create backup jobs and manual backup jobs
+		// we care about replica create backup jobs, manual backup jobs, manual expire jobs,
+		// and verification jobs
 		for i, job := range jobList.Items {
 			switch job.GetLabels()[naming.LabelPGBackRestBackup] {
 			case string(naming.BackupReplicaCreate):
@@ -464,6 +532,14 @@ func unstructuredToRepoResources(kind string, repoResources *RepoResources,
 				repoResources.manualBackupJobs =
 					append(repoResources.manualBackupJobs, &jobList.Items[i])
 			}
+			if job.GetLabels()[naming.LabelPGBackRestCronJob] == verify {
+				repoResources.verifyJobs =
+					append(repoResources.verifyJobs, &jobList.Items[i])
+			}
+			if _, ok := job.GetLabels()[naming.LabelPGBackRestExpire]; ok {
+				repoResources.manualExpireJobs =
+					append(repoResources.manualExpireJobs, &jobList.Items[i])
+			}
 		}
 	case "ConfigMapList":
 		// Repository host now uses mTLS for encryption, authentication, and authorization.
@@ -536,7 +612,7 @@ func (r *Reconciler) setScheduledJobStatus(ctx context.Context,
 	for _, job := range jobList.Items {
 		// we only care about the scheduled backup Jobs created by the
 		// associated CronJobs
-		if job.GetLabels()[naming.LabelPGBackRestCronJob] != "" {
+		if cronjob := job.GetLabels()[naming.LabelPGBackRestCronJob]; cronjob != "" && cronjob != verify {
 			sbs := v1beta1.PGBackRestScheduledBackupStatus{}
 
 			if len(job.OwnerReferences) > 0 {
@@ -776,15 +852,45 @@ func (r *Reconciler) generateBackupJobSpecIntent(ctx context.Context, postgresCl
 	repo v1beta1.PGBackRestRepo, serviceAccountName string,
 	labels, annotations map[string]string, opts ...string) *batchv1.JobSpec {
 
+	var cmdOpts []string
+
+	// If VolumeSnapshots are enabled, use archive-copy and archive-check options
+	if postgresCluster.Spec.Backups.Snapshots != nil && feature.Enabled(ctx, feature.VolumeSnapshots) {
+		cmdOpts = append(cmdOpts, "--archive-copy=y", "--archive-check=y")
+	}
+
+	return r.generatePGBackRestJobSpecIntent(ctx, postgresCluster, repo, "backup",
+		serviceAccountName, labels, annotations, append(cmdOpts, opts...)...)
+}
+
+// generateVerifyJobSpecIntent generates a JobSpec for a pgBackRest verify job. When
+// verification fails, the end of its log is kept in the termination message of the
+// container so the files it found to be corrupt can be reported in status.
+func (r *Reconciler) generateVerifyJobSpecIntent(ctx context.Context, postgresCluster *v1beta1.PostgresCluster,
+	repo v1beta1.PGBackRestRepo, serviceAccountName string,
+	labels, annotations map[string]string) *batchv1.JobSpec {
+
+	// pgBackRest logs each corrupt file at the "info" level.
+	jobSpec := r.generatePGBackRestJobSpecIntent(ctx, postgresCluster, repo, verify,
+		serviceAccountName, labels, annotations, "--log-level-console=info")
+
+	jobSpec.Template.Spec.Containers[0].TerminationMessagePolicy =
+		corev1.TerminationMessageFallbackToLogsOnError
+
+	return jobSpec
+}
+
+// generatePGBackRestJobSpecIntent generates a JobSpec for a job that runs the pgBackRest
+// command against repo
+func (r *Reconciler) generatePGBackRestJobSpecIntent(ctx context.Context, postgresCluster *v1beta1.PostgresCluster,
+	repo v1beta1.PGBackRestRepo, command, serviceAccountName string,
+	labels, annotations map[string]string, opts ...string) *batchv1.JobSpec {
+
 	repoIndex := regexRepoIndex.FindString(repo.Name)
 	cmdOpts := []string{
 		"--stanza=" + pgbackrest.DefaultStanzaName,
 		"--repo=" + repoIndex,
 	}
-	// If VolumeSnapshots are enabled, use archive-copy and archive-check options
-	if postgresCluster.Spec.Backups.Snapshots != nil && feature.Enabled(ctx, feature.VolumeSnapshots) {
-		cmdOpts = append(cmdOpts, "--archive-copy=y", "--archive-check=y")
-	}
 
 	cmdOpts = append(cmdOpts, opts...)
 
@@ -797,12 +903,12 @@ func (r *Reconciler) generateBackupJobSpecIntent(ctx context.Context, postgresCl
 
 	// If the repo that we are backing up to is a local volume, we will configure
 	// the job to use the pgbackrest go binary to exec into the repo host and run
-	// the backup. If the repo is a cloud-based repo, we will run the pgbackrest
-	// backup command directly in the job pod.
+	// the command. If the repo is a cloud-based repo, we will run the pgbackrest
+	// command directly in the job pod.
 	if repo.Volume != nil {
 		container.Command = []string{"/opt/crunchy/bin/pgbackrest"}
 		container.Env = []corev1.EnvVar{
-			{Name: "COMMAND", Value: "backup"},
+			{Name: "COMMAND", Value: command},
 			{Name: "COMMAND_OPTS", Value: strings.Join(cmdOpts, " ")},
 			{Name: "COMPARE_HASH", Value: "true"},
 			{Name: "CONTAINER", Value: naming.PGBackRestRepoContainerName},
@@ -810,7 +916,7 @@ func (r *Reconciler) generateBackupJobSpecIntent(ctx context.Context, postgresCl
 			{Name: "SELECTOR", Value: naming.PGBackRestDedicatedSelector(postgresCluster.GetName()).String()},
 		}
 	} else {
-		container.Command = []string{"/bin/pgbackrest", "backup"}
+		container.Command = []string{"/bin/pgbackrest", command}
 		container.Command = append(container.Command, cmdOpts...)
 	}
 
@@ -1150,6 +1256,47 @@ func (r *Reconciler) prepareForRestore(ctx context.Context,
 	return nil
 }
 
+// validateRecoveryTarget returns whether or not the recovery target of dataSource is within
+// the recovery window that sourceCluster reports for the repository of dataSource. When it is
+// not, it records why in the status and events of cluster.
+func (r *Reconciler) validateRecoveryTarget(
+	cluster, sourceCluster *v1beta1.PostgresCluster, dataSource *v1beta1.PostgresClusterDataSource,
+) bool {
+	if dataSource == nil || dataSource.Target == nil {
+		return true
+	}
+
+	var window *v1beta1.PGBackRestRecoveryWindow
+	if sourceCluster != nil && sourceCluster.Status.PGBackRest != nil {
+		for _, repo := range sourceCluster.Status.PGBackRest.Repos {
+			if repo.Name == dataSource.RepoName {
+				window = repo.RecoveryWindow
+			}
+		}
+	}
+
+	if err := pgbackrest.CheckRecoveryTarget(dataSource.Target, window, time.Now()); err != nil {
+		message := fmt.Sprintf("Unable to restore from %s: %v", dataSource.RepoName, err)
+
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, ReasonInvalidRecoveryTarget, message)
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			ObservedGeneration: cluster.GetGeneration(),
+			Type:               ConditionPGBackRestRestoreProgressing,
+			Status:             metav1.ConditionFalse,
+			Reason:             ReasonInvalidRecoveryTarget,
+			Message:            message,
+		})
+		return false
+	}
+
+	if condition := meta.FindStatusCondition(cluster.Status.Conditions,
+		ConditionPGBackRestRestoreProgressing); condition != nil &&
+		condition.Reason == ReasonInvalidRecoveryTarget {
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, ConditionPGBackRestRestoreProgressing)
+	}
+	return true
+}
+
 // +kubebuilder:rbac:groups="batch",resources="jobs",verbs={patch}
 
 // reconcileRestoreJob is responsible for reconciling a Job that performs a pgBackRest restore in
@@ -1186,19 +1333,29 @@ func (r *Reconciler) reconcileRestoreJob(ctx context.Context,
 			msg = "Option '--link-map' is not allowed: the operator will automatically set this " +
 				"option "
 		}
+		if dataSource.Target != nil && msg == "" && regexTypeOrTargetOption.MatchString(opt) {
+			msg = "Options '--type' and '--target' are not allowed with the 'target' field: " +
+				"please use only the 'target' field instead."
+		}
 		if msg != "" {
 			r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "InvalidDataSource", msg, repoName)
 			return nil
 		}
 	}
 
+	// Check the recovery target against the backups of the source cluster before creating
+	// a Job that would fail.
+	if !r.validateRecoveryTarget(cluster, sourceCluster, dataSource) {
+		return nil
+	}
+
 	pgdata := postgres.DataDirectory(cluster)
 	// combine options provided by user in the spec with those populated by the operator for a
 	// successful restore
-	opts := append(options, []string{
+	opts := slices.Concat(options, pgbackrest.RecoveryTargetOptions(dataSource.Target), []string{
 		"--stanza=" + stanzaName,
 		"--pg1-path=" + pgdata,
-		"--repo=" + regexRepoIndex.FindString(repoName)}...)
+		"--repo=" + regexRepoIndex.FindString(repoName)})
 
 	// Look specifically for the "--target" flag, NOT flags that contain
 	// "--target" (e.g. "--target-timeline")
@@ -1433,6 +1590,8 @@ func (r *Reconciler) reconcilePGBackRest(ctx context.Context,
 	// clear the status and exit
 	if !backupsSpecFound {
 		postgresCluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{}
+		meta.RemoveStatusCondition(&postgresCluster.Status.Conditions, ConditionBackupsVerified)
+		meta.RemoveStatusCondition(&postgresCluster.Status.Conditions, ConditionArchivingHealthy)
 		return result, nil
 	}
 
@@ -1557,6 +1716,55 @@ func (r *Reconciler) reconcilePGBackRest(ctx context.Context,
 		result.Requeue = true
 	}
 
+	// Remove backups as defined in the spec, and triggered by the end-user via annotation
+	if err := r.reconcileManualExpire(ctx, postgresCluster, repoResources.manualExpireJobs,
+		sa); err != nil {
+		log.Error(err, "unable to reconcile manual expire")
+		result.Requeue = true
+	}
+
+	// Record the backups in each repository once backups complete
+	if err := r.reconcileBackupCatalog(ctx, postgresCluster, instances,
+		repoResources); err != nil {
+		log.Error(err, "unable to reconcile backup catalog")
+		result.Requeue = true
+	}
+
+	// Record the results of scheduled verification Jobs
+	if err := r.reconcileBackupVerification(ctx, postgresCluster,
+		repoResources.verifyJobs); err != nil {
+		log.Error(err, "unable to reconcile backup verification")
+		result.Requeue = true
+	}
+
+	// Create a named restore point as requested by the end-user via annotation
+	if err := r.reconcileRestorePoint(ctx, postgresCluster, instances); err != nil {
+		log.Error(err, "unable to reconcile restore point")
+		result.Requeue = true
+	}
+
+	// Start any queued backups that can run and check the queue again periodically
+	if requeue, err := r.reconcileBackupQueue(ctx, postgresCluster); err != nil {
+		log.Error(err, "unable to reconcile backup queue")
+		result.Requeue = true
+	} else if requeue > 0 && (result.RequeueAfter == 0 || requeue < result.RequeueAfter) {
+		result.RequeueAfter = requeue
+	}
+
+	// Record when scheduled backups next run and refresh that after they do
+	if requeue := setScheduledBackupNextTimes(postgresCluster, time.Now()); requeue > 0 &&
+		(result.RequeueAfter == 0 || requeue < result.RequeueAfter) {
+		result.RequeueAfter = requeue
+	}
+
+	// Record the progress of WAL archiving and check it again periodically
+	if requeue, err := r.reconcileArchiveStatus(ctx, postgresCluster, instances); err != nil {
+		log.Error(err, "unable to reconcile WAL archive status")
+		result.Requeue = true
+	} else if requeue > 0 && (result.RequeueAfter == 0 || requeue < result.RequeueAfter) {
+		result.RequeueAfter = requeue
+	}
+
 	return result, nil
 }
 
@@ -2053,6 +2261,53 @@ func (r *Reconciler) copyConfigurationResources(ctx context.Context, cluster,
 			sourceCluster.Spec.Backups.PGBackRest.Configuration[i].ConfigMap.Name = configMapCopyName
 		}
 	}
+
+	// Copy the private keys of any SFTP repositories. These are required; the
+	// restore cannot reach the repository without them.
+	for i := range sourceCluster.Spec.Backups.PGBackRest.Repos {
+		sftp := sourceCluster.Spec.Backups.PGBackRest.Repos[i].SFTP
+		if sftp == nil {
+			continue
+		}
+
+		secretCopy := &corev1.Secret{}
+		if err := errors.WithStack(r.Client.Get(ctx, client.ObjectKey{
+			Name:      sftp.PrivateKey.Name,
+			Namespace: sourceCluster.Namespace,
+		}, secretCopy)); err != nil {
+			return err
+		}
+
+		// Set a unique name for the Secret copy using the original Secret
+		// name and the repository index number.
+		secretCopyName := fmt.Sprintf(naming.RestoreConfigCopySuffix, sftp.PrivateKey.Name, i)
+
+		// set the new name and namespace
+		secretCopy.ObjectMeta = metav1.ObjectMeta{
+			Name:      secretCopyName,
+			Namespace: cluster.Namespace,
+		}
+		secretCopy.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+		secretCopy.Annotations = naming.Merge(
+			cluster.Spec.Metadata.GetAnnotationsOrNil(),
+			cluster.Spec.Backups.PGBackRest.Metadata.GetAnnotationsOrNil(),
+		)
+		secretCopy.Labels = naming.Merge(
+			cluster.Spec.Metadata.GetLabelsOrNil(),
+			cluster.Spec.Backups.PGBackRest.Metadata.GetLabelsOrNil(),
+			// this label allows for cleanup when the restore completes
+			naming.PGBackRestRestoreJobLabels(cluster.Name),
+		)
+		if err := r.setControllerReference(cluster, secretCopy); err != nil {
+			return err
+		}
+		if err := errors.WithStack(r.apply(ctx, secretCopy)); err != nil {
+			return err
+		}
+		// update the copy of the source PostgresCluster so the restore Job
+		// projects the new Secret
+		sftp.PrivateKey.Name = secretCopyName
+	}
 	return nil
 }
 
@@ -2126,11 +2381,15 @@ func (r *Reconciler) reconcilePGBackRestSecret(ctx context.Context,
 	err := errors.WithStack(client.IgnoreNotFound(
 		r.Client.Get(ctx, client.ObjectKeyFromObject(intent), existing)))
 
+	var passphrases map[string]string
+	if err == nil {
+		passphrases, err = r.reconcilePGBackRestCipherSecret(ctx, cluster)
+	}
 	if err == nil {
 		err = r.setControllerReference(cluster, intent)
 	}
 	if err == nil {
-		err = pgbackrest.Secret(ctx, cluster, repoHost, rootCA, existing, intent)
+		err = pgbackrest.Secret(ctx, cluster, repoHost, rootCA, passphrases, existing, intent)
 	}
 
 	// Delete the Secret when it exists and there is nothing we want to keep in it.
@@ -2146,6 +2405,79 @@ func (r *Reconciler) reconcilePGBackRestSecret(ctx context.Context,
 	return err
 }
 
+// +kubebuilder:rbac:groups="",resources="secrets",verbs={get}
+// +kubebuilder:rbac:groups="",resources="secrets",verbs={create,patch}
+
+// reconcilePGBackRestCipherSecret returns the passphrase of each encrypted
+// repository by name. Those the user chose are read from their Secrets. The
+// others are generated into a Secret that is not owned by cluster, so that
+// backups remain readable after the cluster is deleted.
+func (r *Reconciler) reconcilePGBackRestCipherSecret(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+) (map[string]string, error) {
+	passphrases := make(map[string]string)
+	generated := false
+
+	var err error
+	for _, repo := range cluster.Spec.Backups.PGBackRest.Repos {
+		if repo.Encryption == nil {
+			continue
+		}
+		if repo.Encryption.Passphrase == nil {
+			generated = true
+			continue
+		}
+
+		selector := repo.Encryption.Passphrase
+		secret := &corev1.Secret{}
+		if err == nil {
+			err = errors.WithStack(client.IgnoreNotFound(r.Client.Get(ctx,
+				client.ObjectKey{Namespace: cluster.Namespace, Name: selector.Name}, secret)))
+		}
+		if pass := secret.Data[selector.Key]; len(pass) > 0 {
+			passphrases[repo.Name] = string(pass)
+		} else if err == nil {
+			r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "CipherPassphraseNotFound",
+				"Unable to find the passphrase of %s in Secret %q, key %q",
+				repo.Name, selector.Name, selector.Key)
+		}
+	}
+	if !generated || err != nil {
+		return passphrases, err
+	}
+
+	intent := &corev1.Secret{ObjectMeta: naming.PGBackRestCipherSecret(cluster)}
+	intent.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+	intent.Type = corev1.SecretTypeOpaque
+	intent.Annotations = naming.Merge(
+		cluster.Spec.Metadata.GetAnnotationsOrNil(),
+		cluster.Spec.Backups.PGBackRest.Metadata.GetAnnotationsOrNil())
+	intent.Labels = naming.Merge(
+		cluster.Spec.Metadata.GetLabelsOrNil(),
+		cluster.Spec.Backups.PGBackRest.Metadata.GetLabelsOrNil(),
+		map[string]string{naming.LabelCluster: cluster.Name},
+	)
+
+	// This Secret has no owner so that it outlives cluster. Without these
+	// passphrases, its backups cannot be read.
+	existing := &corev1.Secret{}
+	err = errors.WithStack(client.IgnoreNotFound(
+		r.Client.Get(ctx, client.ObjectKeyFromObject(intent), existing)))
+
+	if err == nil {
+		err = pgbackrest.CipherSecret(cluster, existing, intent)
+	}
+	if err == nil {
+		err = errors.WithStack(r.apply(ctx, intent))
+	}
+	for _, repo := range cluster.Spec.Backups.PGBackRest.Repos {
+		if err == nil && repo.Encryption != nil && repo.Encryption.Passphrase == nil {
+			passphrases[repo.Name] = pgbackrest.CipherPassphrase(repo, intent)
+		}
+	}
+	return passphrases, err
+}
+
 // +kubebuilder:rbac:groups="",resources="serviceaccounts",verbs={create,patch}
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources="roles",verbs={create,patch}
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources="rolebindings",verbs={create,patch}
@@ -2520,6 +2852,185 @@ func (r *Reconciler) reconcileManualBackup(ctx context.Context,
 
 // +kubebuilder:rbac:groups="batch",resources="jobs",verbs={create,patch,delete}
 
+// reconcileManualExpire is responsible for reconciling pgBackRest expire commands that are
+// initiated manually by the end-user.  The command removes a single backup set, or the backups
+// beyond a retention policy that is given in place of the policy of the repository.
+func (r *Reconciler) reconcileManualExpire(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster, manualExpireJobs []*batchv1.Job,
+	serviceAccount *corev1.ServiceAccount) error {
+
+	expireAnnotation := postgresCluster.GetAnnotations()[naming.PGBackRestExpire]
+	expireStatus := postgresCluster.Status.PGBackRest.Expire
+
+	// first update status and cleanup according to any existing expire Jobs observed in the
+	// environment
+	var currentExpireJob *batchv1.Job
+	if len(manualExpireJobs) > 0 {
+
+		currentExpireJob = manualExpireJobs[0]
+		completed := jobCompleted(currentExpireJob)
+		failed := jobFailed(currentExpireJob)
+		expireID := currentExpireJob.GetAnnotations()[naming.PGBackRestExpire]
+
+		if expireStatus != nil && expireStatus.ID == expireID {
+			if completed {
+				meta.SetStatusCondition(&postgresCluster.Status.Conditions, metav1.Condition{
+					ObservedGeneration: postgresCluster.GetGeneration(),
+					Type:               ConditionManualExpireSuccessful,
+					Status:             metav1.ConditionTrue,
+					Reason:             "ManualExpireComplete",
+					Message:            "Manual expire completed successfully",
+				})
+			} else if failed {
+				meta.SetStatusCondition(&postgresCluster.Status.Conditions, metav1.Condition{
+					ObservedGeneration: postgresCluster.GetGeneration(),
+					Type:               ConditionManualExpireSuccessful,
+					Status:             metav1.ConditionFalse,
+					Reason:             "ManualExpireFailed",
+					Message:            "Manual expire did not complete successfully",
+				})
+			}
+
+			// update the expire status based on the current status of the expire Job
+			expireStatus.StartTime = currentExpireJob.Status.StartTime
+			expireStatus.CompletionTime = currentExpireJob.Status.CompletionTime
+			expireStatus.Succeeded = currentExpireJob.Status.Succeeded
+			expireStatus.Failed = currentExpireJob.Status.Failed
+			expireStatus.Active = currentExpireJob.Status.Active
+			if completed || failed {
+				expireStatus.Finished = true
+			}
+		}
+
+		// Like manual backups, a finished Job that is not annotated per the current value of the
+		// "pgbackrest-expire" annotation is deleted so that a new Job can be generated with the
+		// new expire ID.  Jobs that are in progress complete first.
+		if completed || failed {
+			if expireAnnotation != "" && expireID != expireAnnotation {
+				return errors.WithStack(r.Client.Delete(ctx, currentExpireJob,
+					client.PropagationPolicy(metav1.DeletePropagationBackground)))
+			}
+		}
+	}
+
+	// nothing to reconcile if an expire has not been requested
+	if expireAnnotation == "" || postgresCluster.Spec.Backups.PGBackRest.Expire == nil {
+		return nil
+	}
+
+	// if there is an existing status, see if a new expire id has been provided, and if so reset
+	// the status and proceed with reconciling a new expire
+	if expireStatus == nil || expireStatus.ID != expireAnnotation {
+		expireStatus = &v1beta1.PGBackRestJobStatus{
+			ID: expireAnnotation,
+		}
+		// Remove an existing manual expire condition if present.  It will be
+		// created again as needed based on the newly reconciled expire Job.
+		meta.RemoveStatusCondition(&postgresCluster.Status.Conditions,
+			ConditionManualExpireSuccessful)
+
+		postgresCluster.Status.PGBackRest.Expire = expireStatus
+	}
+
+	// if the status shows the Job is no longer in progress, then simply exit (which means a Job
+	// that has reached a "completed" or "failed" status is no longer reconciled)
+	if expireStatus.Finished {
+		return nil
+	}
+
+	// The repositories of a standby cluster belong to its primary cluster.
+	if postgresCluster.Spec.Standby != nil && postgresCluster.Spec.Standby.Enabled {
+		r.Recorder.Event(postgresCluster, corev1.EventTypeWarning, "InvalidManualExpire",
+			"Backups cannot be expired by a standby cluster")
+		return nil
+	}
+
+	// determine if the dedicated repository host is ready (if enabled) using the repo host ready
+	// condition, and return if not
+	if pgbackrest.RepoHostVolumeDefined(postgresCluster) {
+		repoCondition := meta.FindStatusCondition(postgresCluster.Status.Conditions, ConditionRepoHostReady)
+		if repoCondition == nil || repoCondition.Status != metav1.ConditionTrue {
+			return nil
+		}
+	}
+
+	// Verify that status exists for the repo configured for the expire, and that a stanza has
+	// been created, before proceeding.  Subsequent events will trigger the reconciles needed to
+	// try again.
+	var statusFound, stanzaCreated bool
+	repoName := postgresCluster.Spec.Backups.PGBackRest.Expire.RepoName
+	for _, repo := range postgresCluster.Status.PGBackRest.Repos {
+		if repo.Name == repoName {
+			statusFound = true
+			stanzaCreated = repo.StanzaCreated
+		}
+	}
+	if !statusFound {
+		r.Recorder.Eventf(postgresCluster, corev1.EventTypeWarning, "InvalidExpireRepo",
+			"Unable to find status for %q as configured for a manual expire.  Please ensure "+
+				"this repo is defined in the spec.", repoName)
+		return nil
+	}
+	if !stanzaCreated {
+		r.Recorder.Eventf(postgresCluster, corev1.EventTypeWarning, "StanzaNotCreated",
+			"Stanza not created for %q as specified for a manual expire", repoName)
+		return nil
+	}
+
+	var repo v1beta1.PGBackRestRepo
+	for i := range postgresCluster.Spec.Backups.PGBackRest.Repos {
+		if postgresCluster.Spec.Backups.PGBackRest.Repos[i].Name == repoName {
+			repo = postgresCluster.Spec.Backups.PGBackRest.Repos[i]
+		}
+	}
+	if repo.Name == "" {
+		return errors.Errorf("repo %q is not defined for this cluster", repoName)
+	}
+
+	// Remove one backup set, or apply a retention policy in place of the one configured for the
+	// repo. Without either, the configured retention policy is applied.
+	var expireOpts []string
+	if set := postgresCluster.Spec.Backups.PGBackRest.Expire.Set; set != "" {
+		expireOpts = append(expireOpts, "--set="+set)
+	}
+	expireOpts = append(expireOpts, pgbackrest.RetentionOptions(repoName,
+		postgresCluster.Spec.Backups.PGBackRest.Expire.Retention)...)
+
+	// create the expire Job
+	expireJob := &batchv1.Job{}
+	expireJob.ObjectMeta = naming.PGBackRestExpireJob(postgresCluster)
+	if currentExpireJob != nil {
+		expireJob.Name = currentExpireJob.Name
+	}
+
+	var labels, annotations map[string]string
+	labels = naming.Merge(postgresCluster.Spec.Metadata.GetLabelsOrNil(),
+		postgresCluster.Spec.Backups.PGBackRest.Metadata.GetLabelsOrNil(),
+		naming.PGBackRestExpireJobLabels(postgresCluster.GetName(), repoName))
+	annotations = naming.Merge(postgresCluster.Spec.Metadata.GetAnnotationsOrNil(),
+		postgresCluster.Spec.Backups.PGBackRest.Metadata.GetAnnotationsOrNil(),
+		map[string]string{
+			naming.PGBackRestExpire: expireAnnotation,
+		})
+	expireJob.Labels = labels
+	expireJob.Annotations = annotations
+
+	spec := r.generatePGBackRestJobSpecIntent(ctx, postgresCluster, repo, "expire",
+		serviceAccount.GetName(), labels, annotations, expireOpts...)
+	expireJob.Spec = *spec
+
+	// set gvk and ownership refs
+	expireJob.SetGroupVersionKind(batchv1.SchemeGroupVersion.WithKind("Job"))
+	if err := r.setControllerReference(postgresCluster, expireJob); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// server-side apply the expire Job intent
+	return errors.WithStack(r.apply(ctx, expireJob))
+}
+
+// +kubebuilder:rbac:groups="batch",resources="jobs",verbs={create,patch,delete}
+
 // reconcileReplicaCreateBackup is responsible for reconciling a full pgBackRest backup for the
 // cluster as required to create replicas
 func (r *Reconciler) reconcileReplicaCreateBackup(ctx context.Context,
@@ -2732,6 +3243,25 @@ func (r *Reconciler) reconcileRepos(ctx context.Context,
 		getRepoVolumeStatus(postgresCluster.Status.PGBackRest.Repos, repoVols, extConfigHashes,
 			replicaCreateRepo.Name)
 
+	// report the retention policy in effect for each repository
+	global := postgresCluster.Spec.Backups.PGBackRest.Global
+	for i := range postgresCluster.Status.PGBackRest.Repos {
+		status := &postgresCluster.Status.PGBackRest.Repos[i]
+		invalid := status.InvalidRetention
+		status.Retention, status.InvalidRetention = nil, nil
+		for _, repo := range postgresCluster.Spec.Backups.PGBackRest.Repos {
+			if repo.Name == status.Name {
+				status.Retention = pgbackrest.RetentionPolicy(repo, global)
+				status.InvalidRetention = pgbackrest.InvalidRetentionOptions(repo, global)
+			}
+		}
+		if len(status.InvalidRetention) > 0 && !slices.Equal(invalid, status.InvalidRetention) {
+			r.Recorder.Eventf(postgresCluster, corev1.EventTypeWarning, "InvalidRetention",
+				"pgBackRest does not accept the values of %s in global",
+				strings.Join(status.InvalidRetention, ", "))
+		}
+	}
+
 	return replicaCreateRepo, utilerrors.NewAggregate(errors)
 }
 
@@ -2859,6 +3389,439 @@ func (r *Reconciler) reconcileStanzaCreate(ctx context.Context,
 	return false, nil
 }
 
+// stanzaExecutor returns a function that runs commands in the database container
+// of the writable instance of postgresCluster. It returns nil until a pgBackRest
+// stanza exists and there is a writable instance.
+func (r *Reconciler) stanzaExecutor(
+	postgresCluster *v1beta1.PostgresCluster, instances *observedInstances,
+) postgres.Executor {
+	if !slices.ContainsFunc(postgresCluster.Status.PGBackRest.Repos,
+		func(repo v1beta1.RepoStatus) bool { return repo.StanzaCreated },
+	) {
+		return nil
+	}
+
+	var writablePod *corev1.Pod
+	for _, instance := range instances.forCluster {
+		if writable, known := instance.IsWritable(); writable && known {
+			writablePod = instance.Pods[0]
+			break
+		}
+	}
+	if writablePod == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+		command ...string) error {
+		return r.PodExec(ctx, writablePod.Namespace, writablePod.Name,
+			naming.ContainerDatabase, stdin, stdout, stderr, command...)
+	}
+}
+
+// backupCatalogInterval is how long the backup catalog in status can go
+// without being refreshed when no backups complete.
+const backupCatalogInterval = time.Hour
+
+// backupCatalogLimit is how many backups are recorded in status for each repository.
+const backupCatalogLimit = 10
+
+// +kubebuilder:rbac:groups="",resources="pods/exec",verbs={create}
+
+// reconcileBackupCatalog records the backups and recovery window of each
+// repository in status after backups complete. It runs "pgbackrest info" in
+// the writable instance, which sees every repository.
+func (r *Reconciler) reconcileBackupCatalog(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster, instances *observedInstances,
+	repoResources *RepoResources,
+) error {
+	now := time.Now()
+	status := postgresCluster.Status.PGBackRest
+
+	jobs := slices.Concat(repoResources.manualBackupJobs,
+		repoResources.replicaCreateBackupJobs, repoResources.manualExpireJobs)
+
+	exec := r.stanzaExecutor(postgresCluster, instances)
+	if exec == nil || !backupCatalogStale(status, jobs, now) {
+		return nil
+	}
+
+	// Record the attempt even when it fails so that a broken repository is
+	// not queried on every reconcile. It is tried again after the interval
+	// or when another backup completes.
+	info, err := pgbackrest.Executor(exec).Info(ctx)
+	if err != nil {
+		status.CatalogTime = &metav1.Time{Time: now}
+		return err
+	}
+
+	for i := range status.Repos {
+		status.Repos[i].Backups, status.Repos[i].RecoveryWindow =
+			info.Catalog(status.Repos[i].Name, backupCatalogLimit)
+	}
+	status.CatalogTime = &metav1.Time{Time: now}
+
+	return nil
+}
+
+// backupCatalogStale returns true when the backup catalog in status should be
+// refreshed: a backup Job completed since the last refresh or the last refresh
+// was long ago. Backups expire when backups complete, so the same condition
+// covers expiration.
+func backupCatalogStale(
+	status *v1beta1.PGBackRestStatus, jobs []*batchv1.Job, now time.Time,
+) bool {
+	if status.CatalogTime == nil || now.Sub(status.CatalogTime.Time) >= backupCatalogInterval {
+		return true
+	}
+
+	completed := []*metav1.Time{}
+	if status.ManualBackup != nil {
+		completed = append(completed, status.ManualBackup.CompletionTime)
+	}
+	if status.Expire != nil {
+		completed = append(completed, status.Expire.CompletionTime)
+	}
+	for _, scheduled := range status.ScheduledBackups {
+		completed = append(completed, scheduled.CompletionTime)
+	}
+	for _, job := range jobs {
+		completed = append(completed, job.Status.CompletionTime)
+	}
+	for _, t := range completed {
+		if t != nil && !t.Before(status.CatalogTime) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// +kubebuilder:rbac:groups="",resources="pods",verbs={list}
+
+// reconcileBackupVerification records the result of the most recent verification
+// Job of each repository in status and summarizes them in the BackupsVerified
+// condition. A failed verification is also recorded as a warning event.
+func (r *Reconciler) reconcileBackupVerification(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster, jobs []*batchv1.Job,
+) error {
+	status := postgresCluster.Status.PGBackRest
+	previous := status.Verifications
+	status.Verifications = nil
+
+	var scheduled, failed []string
+	for _, repo := range postgresCluster.Spec.Backups.PGBackRest.Repos {
+		if !backupScheduleFound(repo, verify) {
+			continue
+		}
+		scheduled = append(scheduled, repo.Name)
+
+		// Find the most recent verification Job that finished.
+		var latest *batchv1.Job
+		for _, job := range jobs {
+			if job.GetLabels()[naming.LabelPGBackRestRepo] == repo.Name &&
+				(jobCompleted(job) || jobFailed(job)) &&
+				(latest == nil || latest.CreationTimestamp.Before(&job.CreationTimestamp)) {
+				latest = job
+			}
+		}
+
+		var last *v1beta1.PGBackRestVerificationStatus
+		for i := range previous {
+			if previous[i].RepoName == repo.Name {
+				last = &previous[i]
+			}
+		}
+
+		if latest == nil {
+			if last != nil {
+				status.Verifications = append(status.Verifications, *last)
+				if !last.Verified {
+					failed = append(failed, repo.Name)
+				}
+			}
+			continue
+		}
+
+		verification := v1beta1.PGBackRestVerificationStatus{
+			RepoName: repo.Name,
+			JobName:  latest.Name,
+			Verified: jobCompleted(latest),
+		}
+		for _, condition := range latest.Status.Conditions {
+			if (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) &&
+				condition.Status == corev1.ConditionTrue {
+				verification.CompletionTime = initialize.Pointer(condition.LastTransitionTime)
+			}
+		}
+
+		if !verification.Verified {
+			failed = append(failed, repo.Name)
+
+			// Keep what was found earlier; the Pods of a Job may be gone.
+			if last != nil && last.JobName == latest.Name {
+				verification.CorruptFiles = last.CorruptFiles
+			} else {
+				files, err := r.verificationCorruptFiles(ctx, latest)
+				if err != nil {
+					return err
+				}
+				verification.CorruptFiles = files
+
+				message := fmt.Sprintf("pgBackRest verification of %q failed", repo.Name)
+				if len(files) > 0 {
+					message += fmt.Sprintf("; corrupt files include %q", files[0])
+				}
+				r.Recorder.Event(postgresCluster, corev1.EventTypeWarning,
+					EventBackupVerificationFailed, message)
+			}
+		}
+
+		status.Verifications = append(status.Verifications, verification)
+	}
+
+	condition := metav1.Condition{
+		ObservedGeneration: postgresCluster.GetGeneration(),
+		Type:               ConditionBackupsVerified,
+	}
+	switch {
+	case len(scheduled) == 0:
+		meta.RemoveStatusCondition(&postgresCluster.Status.Conditions, ConditionBackupsVerified)
+		return nil
+	case len(failed) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "VerificationFailed"
+		condition.Message = "pgBackRest found missing or corrupt files in " +
+			strings.Join(failed, ", ")
+	case len(status.Verifications) < len(scheduled):
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = "VerificationPending"
+		condition.Message = "Waiting for pgBackRest to verify every scheduled repository"
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "Verified"
+		condition.Message = "pgBackRest verified the backups in every scheduled repository"
+	}
+	meta.SetStatusCondition(&postgresCluster.Status.Conditions, condition)
+
+	return nil
+}
+
+// verificationCorruptFiles returns the files that a failed verification Job
+// reported as missing or corrupt. Its containers keep the end of their logs in
+// their termination messages.
+func (r *Reconciler) verificationCorruptFiles(
+	ctx context.Context, job *batchv1.Job,
+) ([]string, error) {
+	pods := &corev1.PodList{}
+	if err := r.Client.List(ctx, pods,
+		client.InNamespace(job.Namespace),
+		client.MatchingLabels{batchv1.JobNameLabel: job.Name},
+	); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var files []string
+	for _, pod := range pods.Items {
+		for _, container := range pod.Status.ContainerStatuses {
+			if terminated := container.State.Terminated; terminated != nil {
+				for _, file := range pgbackrest.VerifyCorruptFiles(terminated.Message) {
+					if !slices.Contains(files, file) {
+						files = append(files, file)
+					}
+				}
+			}
+		}
+	}
+
+	// This matches the maximum in the API.
+	if len(files) > 20 {
+		files = files[:20]
+	}
+	return files, nil
+}
+
+// reconcileRestorePoint creates the named restore point requested by the
+// annotation on postgresCluster, when it is not already in status. It does
+// nothing until a stanza exists and there is a writable instance.
+func (r *Reconciler) reconcileRestorePoint(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster, instances *observedInstances,
+) error {
+	name := postgresCluster.GetAnnotations()[naming.PGBackRestRestorePoint]
+	status := postgresCluster.Status.PGBackRest
+
+	if name == "" || slices.ContainsFunc(status.RestorePoints,
+		func(point v1beta1.PGBackRestRestorePointStatus) bool { return point.Name == name },
+	) {
+		return nil
+	}
+
+	if !regexRestorePointName.MatchString(name) {
+		r.Recorder.Eventf(postgresCluster, corev1.EventTypeWarning, EventInvalidRestorePoint,
+			"Restore point name %q must be 1 to 63 letters, digits, or any of '_.:@-'", name)
+		return nil
+	}
+
+	// The restore point is useful only when its WAL can be archived.
+	exec := r.stanzaExecutor(postgresCluster, instances)
+	if exec == nil {
+		return nil
+	}
+
+	lsn, created, err := postgres.CreateRestorePoint(ctx, exec, name)
+	if err != nil {
+		r.Recorder.Eventf(postgresCluster, corev1.EventTypeWarning, EventInvalidRestorePoint,
+			"Unable to create restore point %q", name)
+		return err
+	}
+
+	status.RestorePoints = append(status.RestorePoints, v1beta1.PGBackRestRestorePointStatus{
+		Name: name, LSN: lsn, Time: metav1.NewTime(created),
+	})
+	if len(status.RestorePoints) > restorePointLimit {
+		status.RestorePoints = status.RestorePoints[len(status.RestorePoints)-restorePointLimit:]
+	}
+
+	r.Recorder.Eventf(postgresCluster, corev1.EventTypeNormal, EventRestorePointCreated,
+		"Created restore point %q at %s", name, lsn)
+	return nil
+}
+
+const (
+	// archiveStatusInterval is how often the WAL archive status of the writable
+	// instance is recorded in status.
+	archiveStatusInterval = 5 * time.Minute
+
+	// archiveFailureThreshold is how long WAL archiving can fail before each
+	// check of the archive status emits a warning event.
+	archiveFailureThreshold = 15 * time.Minute
+)
+
+// +kubebuilder:rbac:groups="",resources="pods/exec",verbs={create}
+
+// reconcileArchiveStatus records the statistics of the WAL archiver and the
+// number of WAL files waiting to be archived by the writable instance, and
+// it sets the ArchivingHealthy condition from them. It returns how long until
+// the status should be recorded again, or zero when there is no stanza or
+// writable instance yet.
+func (r *Reconciler) reconcileArchiveStatus(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster, instances *observedInstances,
+) (time.Duration, error) {
+	now := time.Now()
+	status := postgresCluster.Status.PGBackRest
+
+	// WAL is archived only after a stanza exists.
+	exec := r.stanzaExecutor(postgresCluster, instances)
+	if exec == nil {
+		return 0, nil
+	}
+
+	if status.Archive != nil && status.Archive.ObservedTime != nil {
+		if elapsed := now.Sub(status.Archive.ObservedTime.Time); elapsed < archiveStatusInterval {
+			return archiveStatusInterval - elapsed, nil
+		}
+	}
+
+	archive, err := postgres.WALArchiveStatus(ctx, exec,
+		naming.PGBackRestPGDataLogPath+"/"+pgbackrest.DefaultStanzaName+"-archive-push-async.log")
+	if err != nil {
+		return 0, err
+	}
+
+	// Remember a dropped WAL file after it leaves the end of the log, and
+	// when it was first seen.
+	if previous := status.Archive; previous != nil && previous.LastDroppedWAL != "" &&
+		(archive.LastDroppedWAL == "" || archive.LastDroppedWAL == previous.LastDroppedWAL) {
+		archive.LastDroppedWAL = previous.LastDroppedWAL
+		archive.LastDroppedTime = previous.LastDroppedTime
+	} else if archive.LastDroppedWAL != "" {
+		archive.LastDroppedTime = &metav1.Time{Time: now}
+		r.Recorder.Eventf(postgresCluster, corev1.EventTypeWarning, EventWALDropped,
+			"pgBackRest dropped WAL file %q because too much WAL was waiting to be archived",
+			archive.LastDroppedWAL)
+	}
+
+	archive.ObservedTime = &metav1.Time{Time: now}
+	status.Archive = archive
+
+	// Recovery across dropped WAL is possible only from backups taken after it.
+	var lastBackup time.Time
+	for _, repo := range status.Repos {
+		for _, backup := range repo.Backups {
+			if backup.StopTime.After(lastBackup) {
+				lastBackup = backup.StopTime.Time
+			}
+		}
+	}
+
+	condition := archivingCondition(archive, lastBackup)
+	condition.ObservedGeneration = postgresCluster.GetGeneration()
+
+	// Warn when archiving has failed since before the threshold. The condition
+	// changes to False at the first check that sees the failure.
+	if previous := meta.FindStatusCondition(postgresCluster.Status.Conditions,
+		ConditionArchivingHealthy); condition.Reason == "ArchiveCommandFailing" &&
+		previous != nil && previous.Reason == condition.Reason &&
+		now.Sub(previous.LastTransitionTime.Time) >= archiveFailureThreshold {
+
+		r.Recorder.Eventf(postgresCluster, corev1.EventTypeWarning, EventArchivingFailed,
+			"WAL archiving has been failing for %v: %s",
+			now.Sub(previous.LastTransitionTime.Time).Round(time.Minute), condition.Message)
+	}
+	meta.SetStatusCondition(&postgresCluster.Status.Conditions, condition)
+
+	return archiveStatusInterval, nil
+}
+
+// archivingCondition returns the ArchivingHealthy condition that describes
+// archive. Archiving is failing when the most recent attempt failed. It is
+// unhealthy, too, when WAL was dropped after lastBackup completed.
+func archivingCondition(
+	archive *v1beta1.PGBackRestArchiveStatus, lastBackup time.Time,
+) metav1.Condition {
+	condition := metav1.Condition{Type: ConditionArchivingHealthy}
+
+	describe := func(wal string, when *metav1.Time) string {
+		if when == nil {
+			return "none"
+		}
+		return fmt.Sprintf("%q at %s", wal, when.UTC().Format(time.RFC3339))
+	}
+
+	failing := archive.LastFailedTime != nil && (archive.LastArchivedTime == nil ||
+		archive.LastFailedTime.After(archive.LastArchivedTime.Time))
+
+	dropped := archive.LastDroppedTime != nil && archive.LastDroppedTime.After(lastBackup)
+
+	if failing {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ArchiveCommandFailing"
+		condition.Message = fmt.Sprintf(
+			"Last failed WAL %s; last archived WAL %s; %d WAL files waiting",
+			describe(archive.LastFailedWAL, archive.LastFailedTime),
+			describe(archive.LastArchivedWAL, archive.LastArchivedTime),
+			archive.Pending)
+	} else if dropped {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "WALDropped"
+		condition.Message = fmt.Sprintf(
+			"Dropped WAL %s because archive-push-queue-max was exceeded and "+
+				"no backup has completed since; %d WAL files waiting",
+			describe(archive.LastDroppedWAL, archive.LastDroppedTime),
+			archive.Pending)
+	} else {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "Archiving"
+		condition.Message = fmt.Sprintf(
+			"Last archived WAL %s; last failed WAL %s; %d WAL files waiting",
+			describe(archive.LastArchivedWAL, archive.LastArchivedTime),
+			describe(archive.LastFailedWAL, archive.LastFailedTime),
+			archive.Pending)
+	}
+
+	return condition
+}
+
 // getRepoHostStatus is responsible for returning the pgBackRest status for the
 // provided pgBackRest repository host
 func getRepoHostStatus(repoHost *appsv1.StatefulSet) *v1beta1.RepoHostStatus {
@@ -3016,6 +3979,13 @@ func (r *Reconciler) reconcileScheduledBackups(
 					requeue = true
 				}
 			}
+			if repo.BackupSchedules.Verify != nil {
+				if err := r.reconcilePGBackRestCronJob(ctx, cluster, repo,
+					verify, repo.BackupSchedules.Verify, sa, cronjobs); err != nil {
+					log.Error(err, "unable to reconcile verification for "+repo.Name)
+					requeue = true
+				}
+			}
 		}
 	}
 	return requeue
@@ -3024,7 +3994,7 @@ func (r *Reconciler) reconcileScheduledBackups(
 // +kubebuilder:rbac:groups="batch",resources="cronjobs",verbs={create,patch}
 
 // reconcilePGBackRestCronJob creates the CronJob for the given repo, pgBackRest
-// backup type and schedule
+// backup type (or verify) and schedule
 func (r *Reconciler) reconcilePGBackRestCronJob(
 	ctx context.Context, cluster *v1beta1.PostgresCluster, repo v1beta1.PGBackRestRepo,
 	backupType string, schedule *string, serviceAccount *corev1.ServiceAccount,
@@ -3100,11 +4070,23 @@ func (r *Reconciler) reconcilePGBackRestCronJob(
 		return nil
 	}
 
-	// set backup type (i.e. "full", "diff", "incr")
-	backupOpts := []string{"--type=" + backupType}
+	var jobSpec *batchv1.JobSpec
+	if backupType == verify {
+		jobSpec = r.generateVerifyJobSpecIntent(ctx, cluster, repo,
+			serviceAccount.GetName(), labels, annotations)
+	} else {
+		// set backup type (i.e. "full", "diff", "incr")
+		backupOpts := []string{"--type=" + backupType}
 
-	jobSpec := r.generateBackupJobSpecIntent(ctx, cluster, repo,
-		serviceAccount.GetName(), labels, annotations, backupOpts...)
+		jobSpec = r.generateBackupJobSpecIntent(ctx, cluster, repo,
+			serviceAccount.GetName(), labels, annotations, backupOpts...)
+
+		// Scheduled backups wait in a queue when the operator limits how many
+		// run at once. See [Reconciler.reconcileBackupQueue].
+		if r.BackupQueue.enabled() {
+			jobSpec.Suspend = initialize.Bool(true)
+		}
+	}
 
 	// Suspend cronjobs when shutdown or read-only. Any jobs that have already
 	// started will continue.
@@ -3115,7 +4097,8 @@ func (r *Reconciler) reconcilePGBackRestCronJob(
 	pgBackRestCronJob := &batchv1.CronJob{
 		ObjectMeta: objectmeta,
 		Spec: batchv1.CronJobSpec{
-			Schedule:          *schedule,
+			Schedule:          backupSchedule(cluster, *schedule),
+			TimeZone:          backupScheduleTimeZone(cluster),
 			Suspend:           &suspend,
 			ConcurrencyPolicy: batchv1.ForbidConcurrent,
 			JobTemplate: batchv1.JobTemplateSpec{
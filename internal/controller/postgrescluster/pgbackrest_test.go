@@ -30,6 +30,8 @@ import (
 	"k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -602,6 +604,12 @@ topologySpreadConstraints:
 		// create a PostgresCluster without backups to test with
 		postgresCluster := fakePostgresCluster(clusterName, ns.GetName(), clusterUID, true)
 		postgresCluster.Spec.Backups = v1beta1.Backups{}
+		meta.SetStatusCondition(&postgresCluster.Status.Conditions, metav1.Condition{
+			Type: ConditionBackupsVerified, Status: metav1.ConditionTrue, Reason: "Verified",
+		})
+		meta.SetStatusCondition(&postgresCluster.Status.Conditions, metav1.Condition{
+			Type: ConditionArchivingHealthy, Status: metav1.ConditionTrue, Reason: "Archiving",
+		})
 
 		// create the 'observed' instances and set the leader
 		instances := &observedInstances{
@@ -624,6 +632,12 @@ topologySpreadConstraints:
 		}
 		assert.Equal(t, result, reconcile.Result{})
 
+		// Conditions about backups are removed along with their status.
+		assert.Assert(t, meta.FindStatusCondition(postgresCluster.Status.Conditions,
+			ConditionBackupsVerified) == nil)
+		assert.Assert(t, meta.FindStatusCondition(postgresCluster.Status.Conditions,
+			ConditionArchivingHealthy) == nil)
+
 		t.Run("verify pgbackrest dedicated repo StatefulSet", func(t *testing.T) {
 
 			// Verify the sts doesn't exist
@@ -1995,6 +2009,39 @@ func TestReconcilePostgresClusterDataSource(t *testing.T) {
 				expectedClusterCondition: nil,
 				expectedCommandPieces:    []string{"--stanza=", "--pg1-path=", "--repo=", "--delta", "--target=some-date", "--target-action=promote"},
 			},
+		}, {
+			desc: "invalid option: type with target field",
+			dataSource: &v1beta1.DataSource{PostgresCluster: &v1beta1.PostgresClusterDataSource{
+				ClusterName: "invalid-type-with-target", RepoName: "repo1",
+				Options: []string{"--type=time"},
+				Target:  &v1beta1.PGBackRestRecoveryTarget{Name: "before-upgrade"},
+			}},
+			clusterBootstrapped: false,
+			sourceClusterName:   "invalid-type-with-target",
+			sourceClusterRepos:  []v1beta1.PGBackRestRepo{{Name: "repo1"}},
+			result: testResult{
+				configCount: 1, jobCount: 0, pvcCount: 1,
+				invalidSourceRepo: false, invalidSourceCluster: false, invalidOptions: true,
+				expectedClusterCondition: nil,
+				expectedEventMessage: "Options '--type' and '--target' are not allowed with the 'target' field: " +
+					"please use only the 'target' field instead.",
+			},
+		}, {
+			desc: "valid option: target-timeline with target field",
+			dataSource: &v1beta1.DataSource{PostgresCluster: &v1beta1.PostgresClusterDataSource{
+				ClusterName: "valid-target-timeline-with-target", RepoName: "repo1",
+				Options: []string{"--target-timeline=1"},
+				Target:  &v1beta1.PGBackRestRecoveryTarget{Name: "before-upgrade"},
+			}},
+			clusterBootstrapped: false,
+			sourceClusterName:   "valid-target-timeline-with-target",
+			sourceClusterRepos:  []v1beta1.PGBackRestRepo{{Name: "repo1"}},
+			result: testResult{
+				configCount: 1, jobCount: 1, pvcCount: 1,
+				invalidSourceRepo: false, invalidSourceCluster: false, invalidOptions: false,
+				expectedClusterCondition: nil,
+				expectedCommandPieces:    []string{"--type=name", "--target='before-upgrade'", "--target-timeline=1", "--target-action=promote"},
+			},
 		}, {
 			desc: "cluster bootstrapped init condition missing",
 			dataSource: &v1beta1.DataSource{PostgresCluster: &v1beta1.PostgresClusterDataSource{
@@ -2599,6 +2646,36 @@ func TestCopyConfigurationResources(t *testing.T) {
 		assert.Assert(t, apierrors.IsNotFound(checkSecret(secret.Name+"-restorecopy-0", ns2.Name)))
 		assert.Assert(t, apierrors.IsNotFound(checkConfigMap(configMap.Name+"-restorecopy-1", ns2.Name)))
 	})
+	t.Run("SFTP private key", func(t *testing.T) {
+		secret := secret("7")
+		if err := tClient.Create(ctx, secret); err != nil {
+			t.Fatal(err)
+		}
+
+		sc := sourceCluster("7")
+		sc.Spec.Backups.PGBackRest.Configuration = nil
+		sc.Spec.Backups.PGBackRest.Repos = append(sc.Spec.Backups.PGBackRest.Repos,
+			v1beta1.PGBackRestRepo{
+				Name: "repo2",
+				SFTP: &v1beta1.RepoSFTP{
+					Host:               "sftp.example.com",
+					User:               "pgbackrest",
+					HostKeyFingerprint: "SHA256:47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU",
+					PrivateKey:         v1beta1.SecretKeyRef{Name: secret.Name, Key: "id_ed25519"},
+				},
+			})
+
+		nc := cluster("7", sc.Name, sc.Namespace)
+		if err := tClient.Create(ctx, nc); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NilError(t, r.copyConfigurationResources(ctx, nc, sc))
+
+		assert.NilError(t, checkSecret(secret.Name+"-restorecopy-1", ns2.Name))
+		assert.Equal(t, sc.Spec.Backups.PGBackRest.Repos[1].SFTP.PrivateKey.Name,
+			secret.Name+"-restorecopy-1", "expected the copy to be projected")
+	})
 }
 
 func TestGenerateBackupJobIntent(t *testing.T) {
@@ -4294,3 +4371,998 @@ func TestBackupsEnabled(t *testing.T) {
 		assert.Assert(t, backupsReconciliationAllowed)
 	})
 }
+
+func TestBackupCatalogStale(t *testing.T) {
+	now := time.Date(2025, time.January, 2, 3, 4, 5, 0, time.UTC)
+	earlier := metav1.NewTime(now.Add(-10 * time.Minute))
+	later := metav1.NewTime(now.Add(-5 * time.Minute))
+
+	t.Run("Never", func(t *testing.T) {
+		status := &v1beta1.PGBackRestStatus{
+			Repos: []v1beta1.RepoStatus{{Name: "repo1", StanzaCreated: true}},
+		}
+		assert.Assert(t, backupCatalogStale(status, nil, now))
+	})
+
+	t.Run("Interval", func(t *testing.T) {
+		status := &v1beta1.PGBackRestStatus{
+			Repos:       []v1beta1.RepoStatus{{Name: "repo1", StanzaCreated: true}},
+			CatalogTime: &earlier,
+		}
+		assert.Assert(t, !backupCatalogStale(status, nil, now))
+		assert.Assert(t, backupCatalogStale(status, nil, now.Add(backupCatalogInterval)))
+	})
+
+	t.Run("Completed", func(t *testing.T) {
+		status := &v1beta1.PGBackRestStatus{
+			Repos:       []v1beta1.RepoStatus{{Name: "repo1", StanzaCreated: true}},
+			CatalogTime: &later,
+		}
+
+		status.ManualBackup = &v1beta1.PGBackRestJobStatus{CompletionTime: &earlier}
+		status.ScheduledBackups = []v1beta1.PGBackRestScheduledBackupStatus{{}}
+		assert.Assert(t, !backupCatalogStale(status, nil, now))
+
+		status.ScheduledBackups[0].CompletionTime = &later
+		assert.Assert(t, backupCatalogStale(status, nil, now))
+
+		status.ScheduledBackups = nil
+		status.Expire = &v1beta1.PGBackRestJobStatus{CompletionTime: &later}
+		assert.Assert(t, backupCatalogStale(status, nil, now))
+
+		status.Expire = nil
+		job := &batchv1.Job{}
+		job.Status.CompletionTime = &later
+		assert.Assert(t, backupCatalogStale(status, []*batchv1.Job{job}, now))
+	})
+}
+
+func TestReconcileBackupCatalog(t *testing.T) {
+	ctx := context.Background()
+	reconciler := &Reconciler{}
+
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+		Repos: []v1beta1.RepoStatus{
+			{Name: "repo1", StanzaCreated: true},
+			{Name: "repo2", StanzaCreated: true},
+		},
+	}
+
+	primary := &corev1.Pod{}
+	primary.Namespace, primary.Name = "ns1", "hippo-abcd-0"
+	primary.Annotations = map[string]string{"status": `{"role":"primary"}`}
+
+	instances := &observedInstances{forCluster: []*Instance{
+		{Name: "hippo-efgh", Pods: []*corev1.Pod{{}}},
+		{Name: "hippo-abcd", Pods: []*corev1.Pod{primary}},
+	}}
+
+	calls := 0
+	reconciler.PodExec = func(
+		ctx context.Context, namespace, pod, container string,
+		stdin io.Reader, stdout, stderr io.Writer, command ...string,
+	) error {
+		calls++
+
+		assert.Equal(t, namespace, "ns1")
+		assert.Equal(t, pod, "hippo-abcd-0")
+		assert.Equal(t, container, naming.ContainerDatabase)
+		assert.Equal(t, strings.Join(command, " "),
+			"pgbackrest info --output=json --stanza=db")
+
+		_, err := io.WriteString(stdout, `[{"name":"db","backup":[{
+			"database":{"repo-key":2},"label":"20250101-000000F","type":"full",
+			"timestamp":{"start":1735689600,"stop":1735689660}
+		}]}]`)
+		return err
+	}
+
+	t.Run("NoStanza", func(t *testing.T) {
+		cluster := &v1beta1.PostgresCluster{}
+		cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+			Repos: []v1beta1.RepoStatus{{Name: "repo1"}},
+		}
+
+		assert.NilError(t, reconciler.reconcileBackupCatalog(ctx, cluster, instances, &RepoResources{}))
+		assert.Equal(t, calls, 0)
+		assert.Assert(t, cluster.Status.PGBackRest.CatalogTime == nil)
+	})
+
+	assert.NilError(t, reconciler.reconcileBackupCatalog(ctx, cluster, instances, &RepoResources{}))
+	assert.Equal(t, calls, 1)
+
+	status := cluster.Status.PGBackRest
+	assert.Assert(t, status.CatalogTime != nil)
+	assert.Assert(t, status.Repos[0].Backups == nil)
+	assert.Assert(t, status.Repos[0].RecoveryWindow == nil)
+	assert.Equal(t, len(status.Repos[1].Backups), 1)
+	assert.Equal(t, status.Repos[1].Backups[0].Label, "20250101-000000F")
+	assert.Assert(t, cmp.MarshalMatches(status.Repos[1].RecoveryWindow, `
+end: "2025-01-01T00:01:00Z"
+start: "2025-01-01T00:01:00Z"
+	`))
+
+	// Nothing changed, so the catalog is not refreshed.
+	assert.NilError(t, reconciler.reconcileBackupCatalog(ctx, cluster, instances, &RepoResources{}))
+	assert.Equal(t, calls, 1)
+
+	t.Run("Error", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Status.PGBackRest.CatalogTime = nil
+
+		calls := 0
+		reconciler := &Reconciler{PodExec: func(
+			context.Context, string, string, string, io.Reader, io.Writer, io.Writer, ...string,
+		) error {
+			calls++
+			return errors.New("boom")
+		}}
+
+		// The attempt is recorded and the backups are kept.
+		assert.ErrorContains(t, reconciler.reconcileBackupCatalog(ctx, cluster, instances, &RepoResources{}), "boom")
+		assert.Assert(t, cluster.Status.PGBackRest.CatalogTime != nil)
+		assert.Equal(t, len(cluster.Status.PGBackRest.Repos[1].Backups), 1)
+
+		// It is not tried again right away.
+		assert.NilError(t, reconciler.reconcileBackupCatalog(ctx, cluster, instances, &RepoResources{}))
+		assert.Equal(t, calls, 1)
+	})
+}
+
+func TestGenerateVerifyJobSpecIntent(t *testing.T) {
+	ctx := context.Background()
+	r := &Reconciler{}
+
+	cluster := v1beta1.PostgresCluster{}
+	cluster.Name = "hippo-test"
+	cluster.Default()
+
+	t.Run("CloudRepo", func(t *testing.T) {
+		spec := r.generateVerifyJobSpecIntent(ctx, &cluster,
+			v1beta1.PGBackRestRepo{Name: "repo2"}, "", nil, nil)
+
+		container := spec.Template.Spec.Containers[0]
+		assert.DeepEqual(t, container.Command, []string{
+			"/bin/pgbackrest", "verify", "--stanza=db", "--repo=2", "--log-level-console=info",
+		})
+		assert.Equal(t, container.TerminationMessagePolicy,
+			corev1.TerminationMessageFallbackToLogsOnError)
+	})
+
+	t.Run("VolumeRepo", func(t *testing.T) {
+		spec := r.generateVerifyJobSpecIntent(ctx, &cluster,
+			v1beta1.PGBackRestRepo{Name: "repo1", Volume: &v1beta1.RepoPVC{}}, "", nil, nil)
+
+		container := spec.Template.Spec.Containers[0]
+		assert.Assert(t, cmp.MarshalMatches(container.Env[:2], `
+- name: COMMAND
+  value: verify
+- name: COMMAND_OPTS
+  value: --stanza=db --repo=1 --log-level-console=info
+		`))
+		assert.Equal(t, container.TerminationMessagePolicy,
+			corev1.TerminationMessageFallbackToLogsOnError)
+	})
+}
+
+func TestReconcileBackupVerification(t *testing.T) {
+	ctx := context.Background()
+	now := metav1.NewTime(time.Date(2025, time.January, 2, 3, 4, 5, 0, time.UTC))
+
+	finished := func(name, repo string, conditionType batchv1.JobConditionType) *batchv1.Job {
+		job := &batchv1.Job{}
+		job.Namespace, job.Name = "ns1", name
+		job.CreationTimestamp = metav1.NewTime(now.Add(-time.Hour))
+		job.Labels = naming.PGBackRestCronJobLabels("hippo", repo, verify)
+		job.Status.Conditions = []batchv1.JobCondition{{
+			Type: conditionType, Status: corev1.ConditionTrue, LastTransitionTime: now,
+		}}
+		return job
+	}
+
+	pod := &corev1.Pod{}
+	pod.Namespace, pod.Name = "ns1", "hippo-repo2-verify-xyz-abc"
+	pod.Labels = map[string]string{batchv1.JobNameLabel: "hippo-repo2-verify-xyz"}
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name: naming.PGBackRestRepoContainerName,
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+			ExitCode: 28,
+			Message:  "P01   INFO: invalid checksum '20250101-000000F/pg_data/base/5/1259.gz'\n",
+		}},
+	}}
+
+	recorder := events.NewRecorder(t, runtime.Scheme)
+	r := &Reconciler{
+		Client:   fake.NewClientBuilder().WithScheme(runtime.Scheme).WithObjects(pod).Build(),
+		Recorder: recorder,
+	}
+
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.Namespace, cluster.Name = "ns1", "hippo"
+	cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{}
+	cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{
+		{Name: "repo1", BackupSchedules: &v1beta1.PGBackRestBackupSchedules{
+			Verify: initialize.String("@daily"),
+		}},
+		{Name: "repo2", BackupSchedules: &v1beta1.PGBackRestBackupSchedules{
+			Verify: initialize.String("@weekly"),
+		}},
+		{Name: "repo3"},
+	}
+
+	t.Run("Pending", func(t *testing.T) {
+		assert.NilError(t, r.reconcileBackupVerification(ctx, cluster, []*batchv1.Job{
+			finished("hippo-repo1-verify-abc", "repo1", batchv1.JobComplete),
+		}))
+
+		assert.Assert(t, cmp.MarshalMatches(cluster.Status.PGBackRest.Verifications, `
+- completionTime: "2025-01-02T03:04:05Z"
+  jobName: hippo-repo1-verify-abc
+  repo: repo1
+  verified: true
+		`))
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionBackupsVerified)
+		assert.Assert(t, condition != nil)
+		assert.Equal(t, condition.Status, metav1.ConditionUnknown)
+		assert.Equal(t, len(recorder.Events), 0)
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		jobs := []*batchv1.Job{
+			finished("hippo-repo2-verify-xyz", "repo2", batchv1.JobFailed),
+		}
+		assert.NilError(t, r.reconcileBackupVerification(ctx, cluster, jobs))
+
+		// The earlier result of repo1 is kept.
+		assert.Assert(t, cmp.MarshalMatches(cluster.Status.PGBackRest.Verifications, `
+- completionTime: "2025-01-02T03:04:05Z"
+  jobName: hippo-repo1-verify-abc
+  repo: repo1
+  verified: true
+- completionTime: "2025-01-02T03:04:05Z"
+  corruptFiles:
+  - 20250101-000000F/pg_data/base/5/1259.gz
+  jobName: hippo-repo2-verify-xyz
+  repo: repo2
+  verified: false
+		`))
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionBackupsVerified)
+		assert.Assert(t, condition != nil)
+		assert.Equal(t, condition.Status, metav1.ConditionFalse)
+		assert.Equal(t, condition.Reason, "VerificationFailed")
+		assert.Assert(t, cmp.Contains(condition.Message, "repo2"))
+
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Type, corev1.EventTypeWarning)
+		assert.Equal(t, recorder.Events[0].Reason, EventBackupVerificationFailed)
+		assert.Assert(t, cmp.Contains(recorder.Events[0].Note, "1259.gz"))
+
+		// The same Job is not reported twice.
+		assert.NilError(t, r.reconcileBackupVerification(ctx, cluster, jobs))
+		assert.Equal(t, len(recorder.Events), 1)
+	})
+
+	t.Run("Verified", func(t *testing.T) {
+		assert.NilError(t, r.reconcileBackupVerification(ctx, cluster, []*batchv1.Job{
+			finished("hippo-repo2-verify-xyz", "repo2", batchv1.JobFailed),
+			func() *batchv1.Job {
+				job := finished("hippo-repo2-verify-later", "repo2", batchv1.JobComplete)
+				job.CreationTimestamp = now
+				return job
+			}(),
+		}))
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionBackupsVerified)
+		assert.Assert(t, condition != nil)
+		assert.Equal(t, condition.Status, metav1.ConditionTrue)
+		assert.Equal(t, cluster.Status.PGBackRest.Verifications[1].JobName,
+			"hippo-repo2-verify-later")
+	})
+
+	t.Run("Unscheduled", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{{Name: "repo1"}}
+
+		assert.NilError(t, r.reconcileBackupVerification(ctx, cluster, nil))
+		assert.Assert(t, cluster.Status.PGBackRest.Verifications == nil)
+		assert.Assert(t, meta.FindStatusCondition(cluster.Status.Conditions,
+			ConditionBackupsVerified) == nil)
+	})
+}
+
+func TestValidateRecoveryTarget(t *testing.T) {
+	recorder := events.NewRecorder(t, runtime.Scheme)
+	r := &Reconciler{Recorder: recorder}
+
+	source := &v1beta1.PostgresCluster{}
+	source.Namespace, source.Name = "ns1", "hippo"
+	source.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+		Repos: []v1beta1.RepoStatus{{
+			Name: "repo1",
+			RecoveryWindow: &v1beta1.PGBackRestRecoveryWindow{
+				Start: metav1.NewTime(time.Date(2025, time.January, 1, 0, 1, 0, 0, time.UTC)),
+				End:   metav1.NewTime(time.Date(2025, time.January, 3, 0, 0, 30, 0, time.UTC)),
+			},
+		}},
+	}
+
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.Namespace, cluster.Name = "ns1", "rhino"
+
+	t.Run("NoTarget", func(t *testing.T) {
+		assert.Assert(t, r.validateRecoveryTarget(cluster, source, nil))
+		assert.Assert(t, r.validateRecoveryTarget(cluster, source,
+			&v1beta1.PostgresClusterDataSource{RepoName: "repo1"}))
+		assert.Equal(t, len(recorder.Events), 0)
+	})
+
+	early := &v1beta1.PostgresClusterDataSource{
+		RepoName: "repo1",
+		Target: &v1beta1.PGBackRestRecoveryTarget{
+			Time: &metav1.Time{Time: time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	t.Run("OutOfRange", func(t *testing.T) {
+		assert.Assert(t, !r.validateRecoveryTarget(cluster, source, early))
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions,
+			ConditionPGBackRestRestoreProgressing)
+		assert.Assert(t, condition != nil)
+		assert.Equal(t, condition.Status, metav1.ConditionFalse)
+		assert.Equal(t, condition.Reason, ReasonInvalidRecoveryTarget)
+		assert.Equal(t, condition.Message, "Unable to restore from repo1: "+
+			"target time 2024-12-31T00:00:00Z is before the oldest backup finished at 2025-01-01T00:01:00Z")
+
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, ReasonInvalidRecoveryTarget)
+		assert.Equal(t, recorder.Events[0].Regarding.Name, "rhino")
+	})
+
+	t.Run("UnknownWindow", func(t *testing.T) {
+		other := early.DeepCopy()
+		other.RepoName = "repo2"
+		assert.Assert(t, r.validateRecoveryTarget(cluster, source, other))
+
+		// The condition from the previous target is removed.
+		assert.Assert(t, meta.FindStatusCondition(cluster.Status.Conditions,
+			ConditionPGBackRestRestoreProgressing) == nil)
+	})
+
+	t.Run("InRange", func(t *testing.T) {
+		valid := early.DeepCopy()
+		valid.Target.Time.Time = time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC)
+		assert.Assert(t, r.validateRecoveryTarget(cluster, source, valid))
+
+		// Other reasons are left alone.
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			Type: ConditionPGBackRestRestoreProgressing, Status: metav1.ConditionTrue,
+			Reason: ReasonReadyForRestore,
+		})
+		assert.Assert(t, r.validateRecoveryTarget(cluster, source, valid))
+		assert.Assert(t, meta.IsStatusConditionTrue(cluster.Status.Conditions,
+			ConditionPGBackRestRestoreProgressing))
+	})
+}
+
+func TestReconcileRestorePoint(t *testing.T) {
+	ctx := context.Background()
+
+	primary := &corev1.Pod{}
+	primary.Namespace, primary.Name = "ns1", "hippo-abcd-0"
+	primary.Annotations = map[string]string{"status": `{"role":"primary"}`}
+
+	instances := &observedInstances{forCluster: []*Instance{
+		{Name: "hippo-efgh", Pods: []*corev1.Pod{{}}},
+		{Name: "hippo-abcd", Pods: []*corev1.Pod{primary}},
+	}}
+
+	newCluster := func(name string) *v1beta1.PostgresCluster {
+		cluster := &v1beta1.PostgresCluster{}
+		cluster.Namespace, cluster.Name = "ns1", "hippo"
+		cluster.Annotations = map[string]string{naming.PGBackRestRestorePoint: name}
+		cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+			Repos: []v1beta1.RepoStatus{{Name: "repo1", StanzaCreated: true}},
+		}
+		return cluster
+	}
+
+	newReconciler := func(t *testing.T, calls *int, result error) (*Reconciler, *events.Recorder) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		return &Reconciler{
+			Recorder: recorder,
+			PodExec: func(
+				ctx context.Context, namespace, pod, container string,
+				stdin io.Reader, stdout, stderr io.Writer, command ...string,
+			) error {
+				*calls++
+				assert.Equal(t, pod, "hippo-abcd-0")
+				assert.Equal(t, container, naming.ContainerDatabase)
+				assert.Assert(t, cmp.Contains(command, "--set=name=before-upgrade"))
+
+				_, _ = io.WriteString(stdout, "0/3000090 1735776000\n")
+				return result
+			},
+		}, recorder
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		var calls int
+		r, recorder := newReconciler(t, &calls, nil)
+		cluster := newCluster("before-upgrade")
+
+		assert.NilError(t, r.reconcileRestorePoint(ctx, cluster, instances))
+		assert.Equal(t, calls, 1)
+		assert.Assert(t, cmp.MarshalMatches(cluster.Status.PGBackRest.RestorePoints, `
+- lsn: 0/3000090
+  name: before-upgrade
+  time: "2025-01-02T00:00:00Z"
+		`))
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, EventRestorePointCreated)
+
+		// The annotation has been handled, so nothing happens.
+		assert.NilError(t, r.reconcileRestorePoint(ctx, cluster, instances))
+		assert.Equal(t, calls, 1)
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		var calls int
+		r, _ := newReconciler(t, &calls, nil)
+		cluster := newCluster("before-upgrade")
+		for i := range restorePointLimit {
+			cluster.Status.PGBackRest.RestorePoints = append(cluster.Status.PGBackRest.RestorePoints,
+				v1beta1.PGBackRestRestorePointStatus{Name: fmt.Sprint("point", i)})
+		}
+
+		assert.NilError(t, r.reconcileRestorePoint(ctx, cluster, instances))
+		assert.Equal(t, len(cluster.Status.PGBackRest.RestorePoints), restorePointLimit)
+		assert.Equal(t, cluster.Status.PGBackRest.RestorePoints[0].Name, "point1")
+		assert.Equal(t, cluster.Status.PGBackRest.RestorePoints[restorePointLimit-1].Name, "before-upgrade")
+	})
+
+	t.Run("InvalidName", func(t *testing.T) {
+		var calls int
+		r, recorder := newReconciler(t, &calls, nil)
+		cluster := newCluster("no spaces")
+
+		assert.NilError(t, r.reconcileRestorePoint(ctx, cluster, instances))
+		assert.Equal(t, calls, 0)
+		assert.Assert(t, cluster.Status.PGBackRest.RestorePoints == nil)
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, EventInvalidRestorePoint)
+	})
+
+	t.Run("NoStanza", func(t *testing.T) {
+		var calls int
+		r, _ := newReconciler(t, &calls, nil)
+		cluster := newCluster("before-upgrade")
+		cluster.Status.PGBackRest.Repos[0].StanzaCreated = false
+
+		assert.NilError(t, r.reconcileRestorePoint(ctx, cluster, instances))
+		assert.Equal(t, calls, 0)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		var calls int
+		r, recorder := newReconciler(t, &calls, errors.New("exit status 3"))
+		cluster := newCluster("before-upgrade")
+
+		assert.ErrorContains(t, r.reconcileRestorePoint(ctx, cluster, instances), "exit status 3")
+		assert.Assert(t, cluster.Status.PGBackRest.RestorePoints == nil)
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, EventInvalidRestorePoint)
+	})
+}
+
+func TestReconcileArchiveStatus(t *testing.T) {
+	ctx := context.Background()
+
+	primary := &corev1.Pod{}
+	primary.Namespace, primary.Name = "ns1", "hippo-abcd-0"
+	primary.Annotations = map[string]string{"status": `{"role":"primary"}`}
+
+	instances := &observedInstances{forCluster: []*Instance{
+		{Name: "hippo-abcd", Pods: []*corev1.Pod{primary}},
+	}}
+
+	var calls int
+	r := &Reconciler{
+		PodExec: func(
+			ctx context.Context, namespace, pod, container string,
+			stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			calls++
+			assert.Equal(t, pod, "hippo-abcd-0")
+			assert.Equal(t, container, naming.ContainerDatabase)
+
+			_, _ = io.WriteString(stdout, `{"pending":3,"archivedCount":10,"failedCount":1}`+"\n")
+			return nil
+		},
+	}
+
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+		Repos: []v1beta1.RepoStatus{{Name: "repo1"}},
+	}
+
+	// Nothing is archived before there is a stanza.
+	requeue, err := r.reconcileArchiveStatus(ctx, cluster, instances)
+	assert.NilError(t, err)
+	assert.Equal(t, requeue, time.Duration(0))
+	assert.Equal(t, calls, 0)
+
+	cluster.Status.PGBackRest.Repos[0].StanzaCreated = true
+
+	requeue, err = r.reconcileArchiveStatus(ctx, cluster, instances)
+	assert.NilError(t, err)
+	assert.Equal(t, requeue, archiveStatusInterval)
+	assert.Equal(t, calls, 1)
+
+	archive := cluster.Status.PGBackRest.Archive
+	assert.Assert(t, archive != nil)
+	assert.Equal(t, archive.Pending, int64(3))
+	assert.Equal(t, archive.ArchivedCount, int64(10))
+	assert.Equal(t, archive.FailedCount, int64(1))
+	assert.Assert(t, archive.ObservedTime != nil)
+
+	// The status is recent, so it is not recorded again.
+	requeue, err = r.reconcileArchiveStatus(ctx, cluster, instances)
+	assert.NilError(t, err)
+	assert.Assert(t, requeue > 0 && requeue <= archiveStatusInterval)
+	assert.Equal(t, calls, 1)
+
+	// The status is recorded again after the interval.
+	archive.ObservedTime.Time = archive.ObservedTime.Add(-archiveStatusInterval)
+	_, err = r.reconcileArchiveStatus(ctx, cluster, instances)
+	assert.NilError(t, err)
+	assert.Equal(t, calls, 2)
+
+	// Nothing has failed since the last success.
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionArchivingHealthy)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Status, metav1.ConditionTrue)
+
+	t.Run("Failing", func(t *testing.T) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		r := &Reconciler{
+			Recorder: recorder,
+			PodExec: func(
+				ctx context.Context, namespace, pod, container string,
+				stdin io.Reader, stdout, stderr io.Writer, command ...string,
+			) error {
+				_, _ = io.WriteString(stdout, `{
+					"pending": 40, "archivedCount": 10, "failedCount": 25,
+					"lastArchivedWAL": "000000010000000000000009",
+					"lastArchivedTime": "2025-01-01T10:00:00Z",
+					"lastFailedWAL": "00000001000000000000000A",
+					"lastFailedTime": "2025-01-01T11:00:00Z"
+				}`+"\n")
+				return nil
+			},
+		}
+
+		cluster := cluster.DeepCopy()
+		cluster.Name = "hippo"
+		cluster.Status.PGBackRest.Archive = nil
+		cluster.Status.Conditions = nil
+
+		// The first failure changes the condition without an event.
+		_, err := r.reconcileArchiveStatus(ctx, cluster, instances)
+		assert.NilError(t, err)
+		assert.Equal(t, len(recorder.Events), 0)
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionArchivingHealthy)
+		assert.Assert(t, condition != nil)
+		assert.Equal(t, condition.Status, metav1.ConditionFalse)
+		assert.Equal(t, condition.Reason, "ArchiveCommandFailing")
+
+		// Failures that persist beyond the threshold emit an event.
+		condition.LastTransitionTime.Time = condition.LastTransitionTime.Add(-archiveFailureThreshold)
+		cluster.Status.PGBackRest.Archive = nil
+
+		_, err = r.reconcileArchiveStatus(ctx, cluster, instances)
+		assert.NilError(t, err)
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Type, corev1.EventTypeWarning)
+		assert.Equal(t, recorder.Events[0].Reason, "ArchivingFailed")
+		assert.Equal(t, recorder.Events[0].Regarding.Name, "hippo")
+		assert.Assert(t, cmp.Contains(recorder.Events[0].Note, "failing for 15m0s"))
+	})
+
+	t.Run("Dropped", func(t *testing.T) {
+		dropped := "00000001000000000000000A"
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		r := &Reconciler{
+			Recorder: recorder,
+			PodExec: func(
+				ctx context.Context, namespace, pod, container string,
+				stdin io.Reader, stdout, stderr io.Writer, command ...string,
+			) error {
+				assert.Assert(t, cmp.Contains(command,
+					"--set=logfile=/pgdata/pgbackrest/log/db-archive-push-async.log"))
+
+				_, _ = io.WriteString(stdout, `{
+					"pending": 0, "archivedCount": 10, "failedCount": 0,
+					"lastArchivedWAL": "00000001000000000000000B",
+					"lastArchivedTime": "2025-01-01T10:00:00Z",
+					"lastDroppedWAL": `+strconv.Quote(dropped)+`
+				}`+"\n")
+				return nil
+			},
+		}
+
+		cluster := cluster.DeepCopy()
+		cluster.Name = "hippo"
+		cluster.Status.PGBackRest.Archive = nil
+		cluster.Status.Conditions = nil
+
+		// The first time a dropped WAL file is seen, it is recorded with an event.
+		_, err := r.reconcileArchiveStatus(ctx, cluster, instances)
+		assert.NilError(t, err)
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, "WALDropped")
+
+		archive := cluster.Status.PGBackRest.Archive
+		assert.Equal(t, archive.LastDroppedWAL, "00000001000000000000000A")
+		assert.Assert(t, archive.LastDroppedTime != nil)
+		first := *archive.LastDroppedTime
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionArchivingHealthy)
+		assert.Assert(t, condition != nil)
+		assert.Equal(t, condition.Status, metav1.ConditionFalse)
+		assert.Equal(t, condition.Reason, "WALDropped")
+
+		// It is remembered after it leaves the log, without another event.
+		dropped = ""
+		archive.ObservedTime.Time = archive.ObservedTime.Add(-archiveStatusInterval)
+
+		_, err = r.reconcileArchiveStatus(ctx, cluster, instances)
+		assert.NilError(t, err)
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, cluster.Status.PGBackRest.Archive.LastDroppedWAL, "00000001000000000000000A")
+		assert.Assert(t, cluster.Status.PGBackRest.Archive.LastDroppedTime.Equal(&first))
+
+		// Archiving is healthy again after a backup completes.
+		cluster.Status.PGBackRest.Archive.ObservedTime.Time =
+			cluster.Status.PGBackRest.Archive.ObservedTime.Add(-archiveStatusInterval)
+		cluster.Status.PGBackRest.Repos[0].Backups = []v1beta1.PGBackRestBackupStatus{{
+			Label: "20250101-000000F", Type: "full",
+			StopTime: metav1.NewTime(first.Add(time.Minute)),
+		}}
+
+		_, err = r.reconcileArchiveStatus(ctx, cluster, instances)
+		assert.NilError(t, err)
+
+		condition = meta.FindStatusCondition(cluster.Status.Conditions, ConditionArchivingHealthy)
+		assert.Assert(t, condition != nil)
+		assert.Equal(t, condition.Status, metav1.ConditionTrue)
+	})
+}
+
+func TestArchivingCondition(t *testing.T) {
+	at := func(s string) *metav1.Time {
+		parsed, err := time.Parse(time.RFC3339, s)
+		assert.NilError(t, err)
+		return &metav1.Time{Time: parsed}
+	}
+
+	t.Run("NothingArchived", func(t *testing.T) {
+		condition := archivingCondition(&v1beta1.PGBackRestArchiveStatus{}, time.Time{})
+		assert.Equal(t, condition.Type, "ArchivingHealthy")
+		assert.Equal(t, condition.Status, metav1.ConditionTrue)
+		assert.Equal(t, condition.Message,
+			"Last archived WAL none; last failed WAL none; 0 WAL files waiting")
+	})
+
+	t.Run("Recovered", func(t *testing.T) {
+		condition := archivingCondition(&v1beta1.PGBackRestArchiveStatus{
+			Pending:          1,
+			LastArchivedWAL:  "000000010000000000000003",
+			LastArchivedTime: at("2025-01-01T12:00:00Z"),
+			LastFailedWAL:    "000000010000000000000002",
+			LastFailedTime:   at("2025-01-01T11:00:00Z"),
+		}, time.Time{})
+		assert.Equal(t, condition.Status, metav1.ConditionTrue)
+		assert.Equal(t, condition.Reason, "Archiving")
+		assert.Equal(t, condition.Message,
+			`Last archived WAL "000000010000000000000003" at 2025-01-01T12:00:00Z; `+
+				`last failed WAL "000000010000000000000002" at 2025-01-01T11:00:00Z; `+
+				`1 WAL files waiting`)
+	})
+
+	t.Run("Failing", func(t *testing.T) {
+		condition := archivingCondition(&v1beta1.PGBackRestArchiveStatus{
+			Pending:          7,
+			LastArchivedWAL:  "000000010000000000000003",
+			LastArchivedTime: at("2025-01-01T12:00:00Z"),
+			LastFailedWAL:    "000000010000000000000004",
+			LastFailedTime:   at("2025-01-01T12:05:00Z"),
+		}, time.Time{})
+		assert.Equal(t, condition.Status, metav1.ConditionFalse)
+		assert.Equal(t, condition.Reason, "ArchiveCommandFailing")
+		assert.Equal(t, condition.Message,
+			`Last failed WAL "000000010000000000000004" at 2025-01-01T12:05:00Z; `+
+				`last archived WAL "000000010000000000000003" at 2025-01-01T12:00:00Z; `+
+				`7 WAL files waiting`)
+	})
+
+	t.Run("NeverArchived", func(t *testing.T) {
+		condition := archivingCondition(&v1beta1.PGBackRestArchiveStatus{
+			LastFailedWAL:  "000000010000000000000001",
+			LastFailedTime: at("2025-01-01T12:05:00Z"),
+		}, time.Time{})
+		assert.Equal(t, condition.Status, metav1.ConditionFalse)
+		assert.Assert(t, cmp.Contains(condition.Message, "last archived WAL none"))
+	})
+
+	t.Run("Dropped", func(t *testing.T) {
+		archive := &v1beta1.PGBackRestArchiveStatus{
+			Pending:          2,
+			LastArchivedWAL:  "000000010000000000000009",
+			LastArchivedTime: at("2025-01-01T12:10:00Z"),
+			LastDroppedWAL:   "000000010000000000000005",
+			LastDroppedTime:  at("2025-01-01T12:05:00Z"),
+		}
+
+		// The archiver reports success, but WAL is missing from the repository.
+		condition := archivingCondition(archive, at("2025-01-01T12:00:00Z").Time)
+		assert.Equal(t, condition.Status, metav1.ConditionFalse)
+		assert.Equal(t, condition.Reason, "WALDropped")
+		assert.Equal(t, condition.Message,
+			`Dropped WAL "000000010000000000000005" at 2025-01-01T12:05:00Z because `+
+				`archive-push-queue-max was exceeded and no backup has completed since; `+
+				`2 WAL files waiting`)
+
+		// Backups after the drop can be recovered.
+		condition = archivingCondition(archive, at("2025-01-01T13:00:00Z").Time)
+		assert.Equal(t, condition.Status, metav1.ConditionTrue)
+		assert.Equal(t, condition.Reason, "Archiving")
+	})
+}
+
+func TestReconcileManualExpire(t *testing.T) {
+	ctx := context.Background()
+	sa := &corev1.ServiceAccount{}
+	sa.Name = "hippo-pgbackrest"
+
+	newCluster := func(id string) *v1beta1.PostgresCluster {
+		cluster := &v1beta1.PostgresCluster{}
+		cluster.Namespace, cluster.Name, cluster.UID = "ns1", "hippo", "some-uid"
+		cluster.Annotations = map[string]string{naming.PGBackRestExpire: id}
+		cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{
+			{Name: "repo1", Volume: &v1beta1.RepoPVC{}},
+			{Name: "repo2", S3: &v1beta1.RepoS3{Bucket: "b", Endpoint: "e", Region: "r"}},
+		}
+		cluster.Spec.Backups.PGBackRest.Expire = &v1beta1.PGBackRestManualExpire{
+			RepoName: "repo1", Set: "20250102-030405F",
+		}
+		cluster.Status.Conditions = []metav1.Condition{{
+			Type: ConditionRepoHostReady, Status: metav1.ConditionTrue,
+		}}
+		cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+			Repos: []v1beta1.RepoStatus{
+				{Name: "repo1", StanzaCreated: true},
+				{Name: "repo2", StanzaCreated: true},
+			},
+		}
+		return cluster
+	}
+
+	// The fake client does not implement server-side apply, so keep the Jobs
+	// that are applied.
+	newReconciler := func(t *testing.T, applied *[]*batchv1.Job, objects ...client.Object) (*Reconciler, *events.Recorder) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		return &Reconciler{
+			Client: fake.NewClientBuilder().WithScheme(runtime.Scheme).WithObjects(objects...).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Patch: func(
+						ctx context.Context, c client.WithWatch, object client.Object,
+						patch client.Patch, options ...client.PatchOption,
+					) error {
+						if job, ok := object.(*batchv1.Job); ok && patch.Type() == types.ApplyPatchType {
+							*applied = append(*applied, job.DeepCopy())
+							return nil
+						}
+						return c.Patch(ctx, object, patch, options...)
+					},
+				}).Build(),
+			Owner:    ControllerName,
+			Recorder: recorder,
+		}, recorder
+	}
+
+	finished := func(name, id string, conditionType batchv1.JobConditionType) *batchv1.Job {
+		job := &batchv1.Job{}
+		job.Namespace, job.Name = "ns1", name
+		job.Annotations = map[string]string{naming.PGBackRestExpire: id}
+		job.Labels = naming.PGBackRestExpireJobLabels("hippo", "repo1")
+		job.Status.Conditions = []batchv1.JobCondition{{
+			Type: conditionType, Status: corev1.ConditionTrue,
+		}}
+		return job
+	}
+
+	t.Run("Set", func(t *testing.T) {
+		var applied []*batchv1.Job
+		r, _ := newReconciler(t, &applied)
+		cluster := newCluster("spill")
+
+		assert.NilError(t, r.reconcileManualExpire(ctx, cluster, nil, sa))
+		assert.Equal(t, cluster.Status.PGBackRest.Expire.ID, "spill")
+		assert.Assert(t, !cluster.Status.PGBackRest.Expire.Finished)
+
+		assert.Equal(t, len(applied), 1)
+		job := applied[0]
+		assert.Assert(t, strings.HasPrefix(job.Name, "hippo-expire-"))
+		assert.Equal(t, job.Annotations[naming.PGBackRestExpire], "spill")
+		assert.Equal(t, job.Labels[naming.LabelPGBackRestRepo], "repo1")
+		assert.Assert(t, job.Labels[naming.LabelPGBackRestBackup] == "",
+			"expire Jobs are not backups")
+		assert.Assert(t, metav1.IsControlledBy(job, cluster))
+
+		assert.Assert(t, cmp.MarshalMatches(job.Spec.Template.Spec.Containers[0].Env[:2], `
+- name: COMMAND
+  value: expire
+- name: COMMAND_OPTS
+  value: --stanza=db --repo=1 --set=20250102-030405F
+		`))
+	})
+
+	t.Run("Retention", func(t *testing.T) {
+		var applied []*batchv1.Job
+		r, _ := newReconciler(t, &applied)
+		cluster := newCluster("spill")
+		cluster.Spec.Backups.PGBackRest.Expire = &v1beta1.PGBackRestManualExpire{
+			RepoName:  "repo2",
+			Retention: &v1beta1.PGBackRestRetention{Full: initialize.Int32(1)},
+		}
+
+		assert.NilError(t, r.reconcileManualExpire(ctx, cluster, nil, sa))
+		assert.Equal(t, len(applied), 1)
+		assert.DeepEqual(t, applied[0].Spec.Template.Spec.Containers[0].Command, []string{
+			"/bin/pgbackrest", "expire", "--stanza=db", "--repo=2", "--repo2-retention-full=1",
+		})
+	})
+
+	t.Run("Finished", func(t *testing.T) {
+		var applied []*batchv1.Job
+		r, _ := newReconciler(t, &applied)
+		cluster := newCluster("spill")
+		cluster.Status.PGBackRest.Expire = &v1beta1.PGBackRestJobStatus{ID: "spill"}
+
+		jobs := []*batchv1.Job{finished("hippo-expire-abcd", "spill", batchv1.JobFailed)}
+		jobs[0].Status.Failed = 1
+
+		assert.NilError(t, r.reconcileManualExpire(ctx, cluster, jobs, sa))
+		assert.Equal(t, len(applied), 0)
+		assert.Assert(t, cluster.Status.PGBackRest.Expire.Finished)
+		assert.Equal(t, cluster.Status.PGBackRest.Expire.Failed, int32(1))
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionManualExpireSuccessful)
+		assert.Assert(t, condition != nil)
+		assert.Equal(t, condition.Status, metav1.ConditionFalse)
+		assert.Equal(t, condition.Reason, "ManualExpireFailed")
+	})
+
+	t.Run("NewID", func(t *testing.T) {
+		var applied []*batchv1.Job
+		previous := finished("hippo-expire-abcd", "earlier", batchv1.JobComplete)
+		r, _ := newReconciler(t, &applied, previous)
+		cluster := newCluster("spill")
+		cluster.Status.PGBackRest.Expire = &v1beta1.PGBackRestJobStatus{ID: "earlier", Finished: true}
+
+		// The finished Job is deleted so another can take its place.
+		assert.NilError(t, r.reconcileManualExpire(ctx, cluster, []*batchv1.Job{previous}, sa))
+		assert.Equal(t, len(applied), 0)
+
+		jobs := &batchv1.JobList{}
+		assert.NilError(t, r.Client.List(ctx, jobs))
+		assert.Equal(t, len(jobs.Items), 0)
+
+		assert.NilError(t, r.reconcileManualExpire(ctx, cluster, nil, sa))
+		assert.Equal(t, len(applied), 1)
+		assert.Equal(t, cluster.Status.PGBackRest.Expire.ID, "spill")
+		assert.Assert(t, !cluster.Status.PGBackRest.Expire.Finished)
+	})
+
+	t.Run("Standby", func(t *testing.T) {
+		var applied []*batchv1.Job
+		r, recorder := newReconciler(t, &applied)
+		cluster := newCluster("spill")
+		cluster.Spec.Standby = &v1beta1.PostgresStandbySpec{Enabled: true}
+
+		assert.NilError(t, r.reconcileManualExpire(ctx, cluster, nil, sa))
+		assert.Equal(t, len(applied), 0)
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, "InvalidManualExpire")
+	})
+
+	t.Run("NoStanza", func(t *testing.T) {
+		var applied []*batchv1.Job
+		r, recorder := newReconciler(t, &applied)
+		cluster := newCluster("spill")
+		cluster.Status.PGBackRest.Repos[0].StanzaCreated = false
+
+		assert.NilError(t, r.reconcileManualExpire(ctx, cluster, nil, sa))
+		assert.Equal(t, len(applied), 0)
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, "StanzaNotCreated")
+	})
+}
+
+func TestReconcilePGBackRestCipherSecret(t *testing.T) {
+	ctx := context.Background()
+
+	chosen := &corev1.Secret{}
+	chosen.Namespace, chosen.Name = "ns1", "chosen"
+	chosen.Data = map[string][]byte{"pass": []byte("secret-sauce")}
+
+	existing := &corev1.Secret{}
+	existing.Namespace, existing.Name = "ns1", "hippo-pgbackrest-cipher"
+	existing.Data = map[string][]byte{
+		"repo1-cipher-pass": []byte("generated"),
+		"repo4-cipher-pass": []byte("removed"),
+	}
+
+	var applied []*corev1.Secret
+	recorder := events.NewRecorder(t, runtime.Scheme)
+	r := &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(runtime.Scheme).
+			WithObjects(chosen, existing).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Patch: func(
+					ctx context.Context, c client.WithWatch, object client.Object,
+					patch client.Patch, options ...client.PatchOption,
+				) error {
+					if secret, ok := object.(*corev1.Secret); ok && patch.Type() == types.ApplyPatchType {
+						applied = append(applied, secret.DeepCopy())
+						return nil
+					}
+					return c.Patch(ctx, object, patch, options...)
+				},
+			}).Build(),
+		Recorder: recorder,
+	}
+
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.Namespace, cluster.Name = "ns1", "hippo"
+	require.UnmarshalInto(t, &cluster.Spec.Backups.PGBackRest.Repos, `[
+		{ name: repo1, encryption: {} },
+		{ name: repo2, encryption: { passphrase: { name: chosen, key: pass } } },
+		{ name: repo3 },
+	]`)
+
+	passphrases, err := r.reconcilePGBackRestCipherSecret(ctx, cluster)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, passphrases, map[string]string{
+		"repo1": "generated",
+		"repo2": "secret-sauce",
+	})
+	assert.Equal(t, len(recorder.Events), 0)
+
+	// Generated passphrases are kept, even those of removed repositories,
+	// in a Secret that the cluster does not own.
+	assert.Equal(t, len(applied), 1)
+	assert.DeepEqual(t, applied[0].Data, existing.Data)
+	assert.Equal(t, len(applied[0].OwnerReferences), 0)
+
+	t.Run("UserSecretMissing", func(t *testing.T) {
+		applied = nil
+		recorder.Events = nil
+
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Backups.PGBackRest.Repos = cluster.Spec.Backups.PGBackRest.Repos[1:]
+		cluster.Spec.Backups.PGBackRest.Repos[0].Encryption.Passphrase.Name = "nope"
+
+		passphrases, err := r.reconcilePGBackRestCipherSecret(ctx, cluster)
+		assert.NilError(t, err)
+		assert.Equal(t, len(passphrases), 0)
+		assert.Equal(t, len(applied), 0)
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, "CipherPassphraseNotFound")
+	})
+}
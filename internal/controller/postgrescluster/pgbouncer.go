@@ -18,6 +18,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/crunchydata/postgres-operator/internal/certmanager"
 	"github.com/crunchydata/postgres-operator/internal/collector"
 	"github.com/crunchydata/postgres-operator/internal/initialize"
 	"github.com/crunchydata/postgres-operator/internal/logging"
@@ -248,6 +249,17 @@ func (r *Reconciler) reconcilePGBouncerSecret(
 		err = errors.WithStack(r.apply(ctx, intent))
 	}
 
+	// When cert-manager is requested and installed, it issues the certificate
+	// that PgBouncer presents to clients.
+	if err == nil && cluster.Spec.Proxy.PGBouncer.CustomTLSSecret == nil &&
+		certmanager.Enabled(ctx, cluster) {
+		dnsNames := naming.ServiceDNSNames(ctx, service)
+
+		_, err = r.reconcileCertManagerCertificate(ctx, cluster,
+			naming.CertManagerPGBouncerCertificate(cluster), "pgbouncer-tls",
+			dnsNames[0], dnsNames, "server auth", "digital signature", "key encipherment")
+	}
+
 	return intent, err
 }
 
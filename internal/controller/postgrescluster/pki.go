@@ -6,13 +6,19 @@ package postgrescluster
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/crunchydata/postgres-operator/internal/certmanager"
 	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/internal/patroni"
+	"github.com/crunchydata/postgres-operator/internal/pgbouncer"
 	"github.com/crunchydata/postgres-operator/internal/pki"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
@@ -27,17 +33,32 @@ const (
 // +kubebuilder:rbac:groups="",resources="secrets",verbs={get}
 // +kubebuilder:rbac:groups="",resources="secrets",verbs={create,patch}
 
+// rootRotationPollInterval is how often a cluster checks whether every
+// PostgresCluster sharing its root has loaded the current phase of a rotation.
+// Clusters do not watch one another, so this bounds how long each phase lasts
+// after the last of them has caught up.
+const rootRotationPollInterval = time.Minute
+
 // reconcileRootCertificate ensures the root certificate, stored
 // in the relevant secret, has been created and is not 'bad' due
 // to being expired, formatted incorrectly, etc.
 // If it is bad for some reason, a new root certificate is
 // generated for use.
+//
+// A good root is replaced in phases when it nears expiry or when cluster is
+// annotated: first a new root is trusted alongside it, then certificates are
+// issued by the new root, and finally the old root is no longer trusted.
+// Each phase advances only after every cluster in the namespace has loaded
+// the one before it; see [Reconciler.reconcileRootCertificateLoaded].
+// The progress of this rotation is stored in the status of cluster.
 func (r *Reconciler) reconcileRootCertificate(
 	ctx context.Context, cluster *v1beta1.PostgresCluster,
 ) (
 	*pki.RootCertificateAuthority, error,
 ) {
 	const keyCertificate, keyPrivateKey = "root.crt", "root.key"
+	const keyNextCertificate, keyNextPrivateKey = "root-next.crt", "root-next.key"
+	const keyPreviousCertificate = "root-previous.crt"
 
 	existing := &corev1.Secret{}
 	existing.Namespace, existing.Name = cluster.Namespace, naming.RootCertSecret
@@ -45,6 +66,10 @@ func (r *Reconciler) reconcileRootCertificate(
 		r.Client.Get(ctx, client.ObjectKeyFromObject(existing), existing)))
 
 	root := &pki.RootCertificateAuthority{}
+	next := &pki.RootCertificateAuthority{}
+	previous := pki.Certificate{}
+	phase := ""
+	generated := false
 
 	if err == nil {
 		// Unmarshal and validate the stored root. These first errors can
@@ -56,9 +81,72 @@ func (r *Reconciler) reconcileRootCertificate(
 		if !pki.RootIsValid(root) {
 			root, err = pki.NewRootCertificateAuthority()
 			err = errors.WithStack(err)
+			generated = true
+		} else {
+			// Any rotation in progress continues from its stored phase.
+			_ = next.Certificate.UnmarshalText(existing.Data[keyNextCertificate])
+			_ = next.PrivateKey.UnmarshalText(existing.Data[keyNextPrivateKey])
+
+			if pki.RootIsValid(next) {
+				phase = v1beta1.RootCertificateRotationTrusting
+			} else if previous.UnmarshalText(existing.Data[keyPreviousCertificate]) == nil {
+				phase = v1beta1.RootCertificateRotationReissuing
+			}
+		}
+	}
+
+	now := time.Now()
+	started, parseErr := time.Parse(time.RFC3339,
+		existing.Annotations[naming.RootCertificateRotationTime])
+	if parseErr != nil {
+		started = now
+	}
+
+	status := cluster.Status.RootCertificateRotation
+	if status == nil {
+		status = &v1beta1.RootCertificateRotationStatus{}
+	}
+	trigger := cluster.Annotations[naming.RotateRootCertificate]
+	requested := trigger != "" && trigger != status.Trigger
+
+	// trust sets the authorities trusted alongside root in the current phase.
+	trust := func() {
+		switch phase {
+		case v1beta1.RootCertificateRotationTrusting:
+			root.Trusted = []pki.Certificate{next.Certificate}
+		case v1beta1.RootCertificateRotationReissuing:
+			root.Trusted = []pki.Certificate{previous}
+		default:
+			root.Trusted = nil
 		}
 	}
 
+	// A phase is done when every cluster sharing the root has loaded it.
+	var loaded bool
+	if err == nil && (phase == v1beta1.RootCertificateRotationTrusting ||
+		phase == v1beta1.RootCertificateRotationReissuing) {
+		trust()
+		loaded, err = r.rootCertificateLoaded(ctx, cluster, root)
+	}
+
+	// Advance the rotation.
+	switch {
+	case err != nil:
+	case phase == "" && (requested || pki.RootNeedsRenewal(root)):
+		next, err = pki.NewRootCertificateAuthority()
+		err = errors.WithStack(err)
+		phase, started, generated = v1beta1.RootCertificateRotationTrusting, now, true
+
+	case phase == v1beta1.RootCertificateRotationTrusting && loaded:
+		previous, root = root.Certificate, next
+		phase, started = v1beta1.RootCertificateRotationReissuing, now
+
+	case phase == v1beta1.RootCertificateRotationReissuing && loaded:
+		phase, started = v1beta1.RootCertificateRotationComplete, now
+	}
+
+	trust()
+
 	intent := &corev1.Secret{}
 	intent.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
 	intent.Namespace, intent.Name = cluster.Namespace, naming.RootCertSecret
@@ -85,19 +173,187 @@ func (r *Reconciler) reconcileRootCertificate(
 		intent.Data[keyPrivateKey], err = root.PrivateKey.MarshalText()
 		err = errors.WithStack(err)
 	}
-	if err == nil {
+	if err == nil && phase == v1beta1.RootCertificateRotationTrusting {
+		intent.Data[keyNextCertificate], err = next.Certificate.MarshalText()
+		if err == nil {
+			intent.Data[keyNextPrivateKey], err = next.PrivateKey.MarshalText()
+		}
+		err = errors.WithStack(err)
+	}
+	if err == nil && phase == v1beta1.RootCertificateRotationReissuing {
+		intent.Data[keyPreviousCertificate], err = previous.MarshalText()
+		err = errors.WithStack(err)
+	}
+	if phase == v1beta1.RootCertificateRotationTrusting ||
+		phase == v1beta1.RootCertificateRotationReissuing {
+		intent.Annotations = map[string]string{
+			naming.RootCertificateRotationTime: started.UTC().Format(time.RFC3339),
+		}
+	}
+
+	// Reconciles of clusters in the namespace may each generate an authority
+	// at the same time. Store one only when the Secret has not changed since
+	// it was read so that every cluster uses the same one. The others return
+	// an error and read that one when they reconcile again.
+	switch {
+	case err == nil && generated && existing.ResourceVersion == "":
+		err = errors.WithStack(r.Client.Create(ctx, intent, r.Owner))
+	case err == nil && generated:
+		intent.ResourceVersion = existing.ResourceVersion
+		fallthrough
+	case err == nil:
 		err = errors.WithStack(r.apply(ctx, intent))
 	}
 
+	// Another cluster in the namespace may have finished the rotation.
+	if phase == "" && (status.Phase == v1beta1.RootCertificateRotationTrusting ||
+		status.Phase == v1beta1.RootCertificateRotationReissuing) {
+		phase, started = v1beta1.RootCertificateRotationComplete, now
+	}
+	if err == nil && phase != "" && phase != status.Phase {
+		status.Phase = phase
+		status.LastTransitionTime = &metav1.Time{Time: started.Truncate(time.Second)}
+	}
+	if err == nil && requested && phase != "" {
+		status.Trigger = trigger
+	}
+	if status.Phase != "" {
+		cluster.Status.RootCertificateRotation = status
+	}
+
 	return root, err
 }
 
+// rootCertificateRotationRequeue returns how long until the rotation of the
+// root certificate authority should be checked again, or zero when it is not
+// rotating.
+func rootCertificateRotationRequeue(cluster *v1beta1.PostgresCluster) time.Duration {
+	status := cluster.Status.RootCertificateRotation
+
+	if status == nil ||
+		(status.Phase != v1beta1.RootCertificateRotationTrusting &&
+			status.Phase != v1beta1.RootCertificateRotationReissuing) {
+		return 0
+	}
+	return rootRotationPollInterval
+}
+
+// rootCertificateDigest returns a hash of the authorities trusted by root.
+// It differs in each phase of a rotation.
+func rootCertificateDigest(root *pki.RootCertificateAuthority) (string, error) {
+	return safeHash32(func(w io.Writer) error {
+		text, err := root.Trust().MarshalText()
+		if err == nil {
+			_, err = w.Write(text)
+		}
+		return err
+	})
+}
+
+// +kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="postgresclusters",verbs={list}
+
+// rootCertificateLoaded reports whether every PostgresCluster in the namespace
+// of cluster has loaded the authorities trusted by root. The status of cluster
+// may be newer than what is stored, so it is used in place of the stored one.
+func (r *Reconciler) rootCertificateLoaded(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+	root *pki.RootCertificateAuthority,
+) (bool, error) {
+	digest, err := rootCertificateDigest(root)
+
+	clusters := &v1beta1.PostgresClusterList{}
+	if err == nil {
+		err = errors.WithStack(r.Client.List(ctx, clusters,
+			client.InNamespace(cluster.Namespace)))
+	}
+	if err != nil {
+		return false, err
+	}
+
+	for i := range clusters.Items {
+		other := &clusters.Items[i]
+		if other.Name == cluster.Name {
+			other = cluster
+		}
+		if other.DeletionTimestamp != nil {
+			continue
+		}
+		if status := other.Status.RootCertificateRotation; status == nil || status.Loaded != digest {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// +kubebuilder:rbac:groups="",resources="secrets",verbs={get}
+// +kubebuilder:rbac:groups="",resources="pods/exec",verbs={create}
+
+// reconcileRootCertificateLoaded records in the status of cluster that it has
+// loaded the current phase of a root certificate rotation. It is called after
+// every certificate of cluster has been issued by root. The files of each
+// running instance are compared to its Secret; Pods that start later read the
+// Secret as it is.
+func (r *Reconciler) reconcileRootCertificateLoaded(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+	instances *observedInstances, root *pki.RootCertificateAuthority,
+) error {
+	status := cluster.Status.RootCertificateRotation
+	if status == nil {
+		return nil
+	}
+	if status.Phase != v1beta1.RootCertificateRotationTrusting &&
+		status.Phase != v1beta1.RootCertificateRotationReissuing {
+		status.Loaded = ""
+		return nil
+	}
+
+	digest, err := rootCertificateDigest(root)
+	loaded := err == nil
+
+	for _, instance := range instances.forCluster {
+		if !loaded {
+			break
+		}
+		if instance.Runner == nil {
+			continue
+		}
+		if terminating, known := instance.IsTerminating(); terminating || !known {
+			continue
+		}
+		if running, known := instance.IsRunning(naming.ContainerDatabase); !running || !known {
+			continue
+		}
+
+		secret := &corev1.Secret{ObjectMeta: naming.InstanceCertificates(instance.Runner)}
+		err = errors.WithStack(
+			r.Client.Get(ctx, client.ObjectKeyFromObject(secret), secret))
+
+		if err == nil {
+			pod := instance.Pods[0]
+			exec := func(
+				ctx context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+			) error {
+				return r.PodExec(ctx, pod.Namespace, pod.Name, naming.ContainerDatabase,
+					stdin, stdout, stderr, command...)
+			}
+			loaded, err = patroni.Executor(exec).CertificatesLoaded(ctx, secret)
+		}
+		loaded = loaded && err == nil
+	}
+
+	if err == nil && loaded {
+		status.Loaded = digest
+	}
+	return err
+}
+
 // +kubebuilder:rbac:groups="",resources="secrets",verbs={get}
 // +kubebuilder:rbac:groups="",resources="secrets",verbs={create,patch}
 
 // reconcileClusterCertificate first checks if a custom certificate
 // secret is configured. If so, that secret projection is returned.
-// Otherwise, a secret containing a generated leaf certificate, stored in
+// Next, when cert-manager is configured and installed, a projection of the
+// secret it issues is returned. Otherwise, a secret containing a generated leaf certificate, stored in
 // the relevant secret, has been created and is not 'bad' due to being
 // expired, formatted incorrectly, etc. If it is bad for any reason, a new
 // leaf certificate is generated using the current root certificate.
@@ -118,13 +374,33 @@ func (r *Reconciler) reconcileClusterCertificate(
 
 	const keyCertificate, keyPrivateKey, rootCA = "tls.crt", "tls.key", "ca.crt"
 
+	dnsNames := append(naming.ServiceDNSNames(ctx, primaryService), naming.ServiceDNSNames(ctx, replicaService)...)
+	dnsFQDN := dnsNames[0]
+
+	// When cert-manager is requested and installed, it issues the certificate
+	// and trust-manager, when installed, distributes its authority. The
+	// operator issues one until cert-manager has issued them all.
+	if certmanager.Enabled(ctx, cluster) {
+		secret, err := r.reconcileCertManagerCertificate(ctx, cluster,
+			naming.CertManagerClusterCertificate(cluster), "postgres-tls",
+			dnsFQDN, dnsNames, "server auth", "digital signature", "key encipherment")
+
+		if err == nil {
+			err = r.reconcileCertManagerBundle(ctx, cluster, secret)
+		}
+		if err != nil || certmanager.Active(ctx, cluster) {
+			return clusterCertSecretProjection(secret), err
+		}
+	} else if certmanager.Requested(cluster) {
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, "CertManagerNotFound",
+			"cert-manager is not installed; using the operator's certificate authority")
+	}
+
 	existing := &corev1.Secret{ObjectMeta: naming.PostgresTLSSecret(cluster)}
 	err := errors.WithStack(client.IgnoreNotFound(
 		r.Client.Get(ctx, client.ObjectKeyFromObject(existing), existing)))
 
 	leaf := &pki.LeafCertificate{}
-	dnsNames := append(naming.ServiceDNSNames(ctx, primaryService), naming.ServiceDNSNames(ctx, replicaService)...)
-	dnsFQDN := dnsNames[0]
 
 	if err == nil {
 		// Unmarshal and validate the stored leaf. These first errors can
@@ -163,7 +439,7 @@ func (r *Reconciler) reconcileClusterCertificate(
 		err = errors.WithStack(err)
 	}
 	if err == nil {
-		intent.Data[rootCA], err = root.Certificate.MarshalText()
+		intent.Data[rootCA], err = root.Trust().MarshalText()
 		err = errors.WithStack(err)
 	}
 
@@ -251,3 +527,144 @@ func clusterCertSecretProjection(certificate *corev1.Secret) *corev1.SecretProje
 		},
 	}
 }
+
+// certificateExpiryWarning is how long before a certificate expires that the
+// cluster warns about it. Certificates generated by the operator are renewed
+// well before this, so it mostly applies to custom certificates.
+const certificateExpiryWarning = 30 * 24 * time.Hour
+
+// projectedKey returns the key of the item in projection with path. When the
+// projection has no items, every key is projected to a path of the same name.
+func projectedKey(projection *corev1.SecretProjection, path string) string {
+	if len(projection.Items) == 0 {
+		return path
+	}
+	for _, item := range projection.Items {
+		if item.Path == path {
+			return item.Key
+		}
+	}
+	return ""
+}
+
+// +kubebuilder:rbac:groups="",resources="secrets",verbs={get}
+
+// reconcileCertificateStatus reads the certificates that cluster presents and
+// trusts then stores when they expire in its status and metrics. It emits a
+// warning event when a certificate begins to expire soon, including custom
+// certificates that the operator does not renew. Certificates that do not
+// exist yet are skipped.
+func (r *Reconciler) reconcileCertificateStatus(
+	ctx context.Context, cluster *v1beta1.PostgresCluster, instances *observedInstances,
+	primaryCertificate *corev1.SecretProjection, replicationSecret *corev1.Secret,
+) error {
+	type source struct{ name, secret, key string }
+
+	sources := []source{{"root", naming.RootCertSecret, "root.crt"}}
+
+	if primaryCertificate != nil {
+		sources = append(sources, source{"postgres-server",
+			primaryCertificate.Name, projectedKey(primaryCertificate, clusterCertFile)})
+	}
+	if replicationSecret != nil {
+		sources = append(sources, source{"replication-client",
+			replicationSecret.Name, naming.ReplicationCert})
+	}
+	if frontend := pgbouncer.FrontendCertificateProjection(ctx, cluster); frontend != nil {
+		sources = append(sources, source{"pgbouncer-frontend",
+			frontend.Name, projectedKey(frontend, clusterCertFile)})
+
+		// PgBouncer verifies PostgreSQL using the authority of its server certificate.
+		if primaryCertificate != nil {
+			sources = append(sources, source{"pgbouncer-backend",
+				primaryCertificate.Name, projectedKey(primaryCertificate, rootCertFile)})
+		}
+	}
+	sources = append(sources,
+		source{"pgbackrest-client", naming.PGBackRestSecret(cluster).Name, "pgbackrest-client.crt"},
+		source{"pgbackrest-server", naming.PGBackRestSecret(cluster).Name, "pgbackrest-repo-host.crt"})
+
+	if instances != nil {
+		for _, instance := range instances.forCluster {
+			if instance.Runner != nil {
+				sources = append(sources, source{"pgbackrest-server",
+					naming.InstanceCertificates(instance.Runner).Name, "pgbackrest-server.crt"})
+			}
+		}
+	}
+
+	var err error
+	var statuses []v1beta1.CertificateStatus
+	now := time.Now()
+	secrets := make(map[string]*corev1.Secret)
+
+	// Remember which certificates were already expiring so that each warns once.
+	type key struct{ name, secret string }
+	previous := make(map[key]v1beta1.CertificateStatus, len(cluster.Status.Certificates))
+	for _, status := range cluster.Status.Certificates {
+		previous[key{status.Name, status.Secret}] = status
+	}
+
+	for _, source := range sources {
+		secret, found := secrets[source.secret]
+		if !found {
+			secret = &corev1.Secret{}
+			secret.Namespace, secret.Name = cluster.Namespace, source.secret
+
+			if err == nil {
+				err = errors.WithStack(client.IgnoreNotFound(
+					r.Client.Get(ctx, client.ObjectKeyFromObject(secret), secret)))
+			}
+			secrets[source.secret] = secret
+		}
+
+		// Skip certificates that are missing or malformed.
+		var certificate pki.Certificate
+		if source.key == "" || certificate.UnmarshalText(secret.Data[source.key]) != nil {
+			continue
+		}
+
+		notAfter := certificate.NotAfter()
+		expiring := notAfter.Sub(now) < certificateExpiryWarning
+		statuses = append(statuses, v1beta1.CertificateStatus{
+			Name:     source.name,
+			Secret:   source.secret,
+			Issuer:   certificate.Issuer(),
+			NotAfter: metav1.NewTime(notAfter),
+			Expiring: expiring,
+		})
+
+		labels := []string{cluster.Namespace, cluster.Name, source.name, source.secret}
+		certificateExpiration.WithLabelValues(labels...).Set(float64(notAfter.Unix()))
+		certificateExpiring.WithLabelValues(labels...).Set(0)
+
+		if expiring {
+			certificateExpiring.WithLabelValues(labels...).Set(1)
+
+			if before, ok := previous[key{source.name, source.secret}]; !ok ||
+				!before.Expiring || !before.NotAfter.Time.Equal(notAfter) {
+				r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "CertificateExpiring",
+					"The %s certificate in Secret %q expires at %s",
+					source.name, source.secret, notAfter.UTC().Format(time.RFC3339))
+			}
+		}
+	}
+
+	if err == nil {
+		// Remove the metrics of certificates that are gone.
+		current := make(map[key]bool, len(statuses))
+		for _, status := range statuses {
+			current[key{status.Name, status.Secret}] = true
+		}
+		for k := range previous {
+			if !current[k] {
+				labels := []string{cluster.Namespace, cluster.Name, k.name, k.secret}
+				certificateExpiration.DeleteLabelValues(labels...)
+				certificateExpiring.DeleteLabelValues(labels...)
+			}
+		}
+
+		cluster.Status.Certificates = statuses
+	}
+	return err
+}
@@ -6,21 +6,36 @@ package postgrescluster
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"math/big"
 	"os"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"gotest.tools/v3/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
+	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
 	"github.com/crunchydata/postgres-operator/internal/naming"
 	"github.com/crunchydata/postgres-operator/internal/pki"
+	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/internal/testing/events"
 	"github.com/crunchydata/postgres-operator/internal/testing/require"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
@@ -160,6 +175,84 @@ func TestReconcileCerts(t *testing.T) {
 			assert.DeepEqual(t, *fromSecret, returnedRoot.Certificate)
 		})
 
+		t.Run("root certificate rotation", func(t *testing.T) {
+			cluster := cluster1.DeepCopy()
+			cluster.Annotations = map[string]string{naming.RotateRootCertificate: "one"}
+
+			original, err := r.reconcileRootCertificate(ctx, cluster1)
+			assert.NilError(t, err)
+			assert.Assert(t, cluster1.Status.RootCertificateRotation == nil)
+
+			// load records that clusters have loaded the authorities of root.
+			load := func(t *testing.T, root *pki.RootCertificateAuthority, clusters ...*v1beta1.PostgresCluster) {
+				t.Helper()
+				for _, c := range clusters {
+					assert.NilError(t, r.reconcileRootCertificateLoaded(ctx, c, new(observedInstances), root))
+					if c != cluster {
+						assert.NilError(t, tClient.Status().Update(ctx, c))
+					}
+				}
+			}
+
+			// The annotation starts a rotation that trusts a new root.
+			trusting, err := r.reconcileRootCertificate(ctx, cluster)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, trusting.Certificate, original.Certificate)
+			assert.Equal(t, len(trusting.Trusted), 1)
+			assert.Assert(t, !trusting.Trusted[0].Equal(original.Certificate))
+
+			assert.Equal(t, cluster.Status.RootCertificateRotation.Phase, "Trusting")
+			assert.Equal(t, cluster.Status.RootCertificateRotation.Trigger, "one")
+
+			// Other clusters in the namespace trust both roots, too.
+			other, err := r.reconcileRootCertificate(ctx, cluster2)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, other, trusting)
+
+			// The rotation waits for every cluster to load the new root.
+			load(t, trusting, cluster)
+			again, err := r.reconcileRootCertificate(ctx, cluster)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, again, trusting)
+			assert.Equal(t, cluster.Status.RootCertificateRotation.Phase, "Trusting")
+
+			// Then the new root issues certificates.
+			load(t, other, cluster2)
+			reissuing, err := r.reconcileRootCertificate(ctx, cluster)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, reissuing.Certificate, trusting.Trusted[0])
+			assert.DeepEqual(t, reissuing.Trusted, []pki.Certificate{original.Certificate})
+			assert.Equal(t, cluster.Status.RootCertificateRotation.Phase, "Reissuing")
+
+			other, err = r.reconcileRootCertificate(ctx, cluster2)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, other, reissuing)
+			load(t, reissuing, cluster, cluster2)
+
+			// After every cluster loads certificates issued by the new root,
+			// the old root is no longer trusted.
+			complete, err := r.reconcileRootCertificate(ctx, cluster)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, complete.Certificate, reissuing.Certificate)
+			assert.Assert(t, complete.Trusted == nil)
+			assert.Equal(t, cluster.Status.RootCertificateRotation.Phase, "Complete")
+
+			assert.NilError(t, tClient.Get(ctx, client.ObjectKeyFromObject(rootSecret), rootSecret))
+			assert.Assert(t, rootSecret.Data["root-next.crt"] == nil)
+			assert.Assert(t, rootSecret.Data["root-previous.crt"] == nil)
+			assert.Assert(t, rootSecret.Annotations[naming.RootCertificateRotationTime] == "")
+
+			// The same annotation does not start another rotation.
+			same, err := r.reconcileRootCertificate(ctx, cluster)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, same, complete)
+
+			// The other cluster sees that the rotation finished.
+			_, err = r.reconcileRootCertificate(ctx, cluster2)
+			assert.NilError(t, err)
+			assert.Equal(t, cluster2.Status.RootCertificateRotation.Phase, "Complete")
+		})
+
 	})
 
 	t.Run("check leaf certificate reconciliation", func(t *testing.T) {
@@ -396,3 +489,241 @@ func getCertFromSecret(
 	fromSecret := &pki.Certificate{}
 	return fromSecret, fromSecret.UnmarshalText(secretCRT)
 }
+
+func TestRootCertificateRotationRequeue(t *testing.T) {
+	cluster := new(v1beta1.PostgresCluster)
+	assert.Equal(t, rootCertificateRotationRequeue(cluster), time.Duration(0))
+
+	cluster.Status.RootCertificateRotation = &v1beta1.RootCertificateRotationStatus{Phase: "Trusting"}
+	assert.Equal(t, rootCertificateRotationRequeue(cluster), time.Minute)
+
+	cluster.Status.RootCertificateRotation.Phase = "Reissuing"
+	assert.Equal(t, rootCertificateRotationRequeue(cluster), time.Minute)
+
+	cluster.Status.RootCertificateRotation.Phase = "Complete"
+	assert.Equal(t, rootCertificateRotationRequeue(cluster), time.Duration(0))
+}
+
+func TestRootCertificateLoaded(t *testing.T) {
+	ctx := context.Background()
+
+	root, err := pki.NewRootCertificateAuthority()
+	assert.NilError(t, err)
+	digest, err := rootCertificateDigest(root)
+	assert.NilError(t, err)
+
+	one := testCluster()
+	one.Namespace, one.Name = "ns1", "one"
+	two := testCluster()
+	two.Namespace, two.Name = "ns1", "two"
+	elsewhere := testCluster()
+	elsewhere.Namespace, elsewhere.Name = "ns2", "three"
+
+	r := &Reconciler{Client: fake.NewClientBuilder().
+		WithScheme(runtime.Scheme).
+		WithObjects(one, two, elsewhere).
+		WithStatusSubresource(one, two, elsewhere).
+		Build()}
+
+	// Clusters that have not loaded the authorities hold the rotation.
+	loaded, err := r.rootCertificateLoaded(ctx, one, root)
+	assert.NilError(t, err)
+	assert.Assert(t, !loaded)
+
+	// The status of the reconciling cluster is newer than the stored one.
+	one.Status.RootCertificateRotation = &v1beta1.RootCertificateRotationStatus{
+		Phase: "Trusting", Loaded: digest,
+	}
+	loaded, err = r.rootCertificateLoaded(ctx, one, root)
+	assert.NilError(t, err)
+	assert.Assert(t, !loaded, "expected to wait for the other cluster")
+
+	two.Status.RootCertificateRotation = one.Status.RootCertificateRotation.DeepCopy()
+	assert.NilError(t, r.Client.Status().Update(ctx, two))
+
+	loaded, err = r.rootCertificateLoaded(ctx, one, root)
+	assert.NilError(t, err)
+	assert.Assert(t, loaded)
+
+	// A different set of authorities has not been loaded.
+	root.Trusted = []pki.Certificate{root.Certificate}
+	loaded, err = r.rootCertificateLoaded(ctx, one, root)
+	assert.NilError(t, err)
+	assert.Assert(t, !loaded)
+}
+
+func TestReconcileRootCertificateConflict(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := testCluster()
+	cluster.Namespace, cluster.Name = "ns1", "hippo"
+
+	t.Run("Absent", func(t *testing.T) {
+		stored := &corev1.Secret{}
+		stored.Namespace, stored.Name = "ns1", naming.RootCertSecret
+		stored.Data = map[string][]byte{"root.crt": []byte("another")}
+
+		// Another reconcile stores a root after this one finds none.
+		r := &Reconciler{Owner: ControllerName, Client: fake.NewClientBuilder().
+			WithScheme(runtime.Scheme).
+			WithObjects(stored).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(_ context.Context, _ client.WithWatch, key client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
+					return apierrors.NewNotFound(corev1.Resource("secrets"), key.Name)
+				},
+			}).
+			Build()}
+
+		_, err := r.reconcileRootCertificate(ctx, cluster)
+		assert.Assert(t, apierrors.IsAlreadyExists(err), "got %#v", err)
+	})
+
+	t.Run("Rotation", func(t *testing.T) {
+		root, err := pki.NewRootCertificateAuthority()
+		assert.NilError(t, err)
+
+		stored := &corev1.Secret{}
+		stored.Namespace, stored.Name = "ns1", naming.RootCertSecret
+		stored.Data = map[string][]byte{}
+		stored.Data["root.crt"], _ = root.Certificate.MarshalText()
+		stored.Data["root.key"], _ = root.PrivateKey.MarshalText()
+
+		cluster := cluster.DeepCopy()
+		cluster.Annotations = map[string]string{naming.RotateRootCertificate: "now"}
+
+		// The new root is stored only when the Secret has not changed.
+		var patched []byte
+		r := &Reconciler{Owner: ControllerName, Client: fake.NewClientBuilder().
+			WithScheme(runtime.Scheme).
+			WithObjects(stored).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Patch: func(_ context.Context, _ client.WithWatch, obj client.Object, patch client.Patch, _ ...client.PatchOption) error {
+					assert.Equal(t, patch.Type(), types.ApplyPatchType)
+					patched, _ = patch.Data(obj)
+					return apierrors.NewConflict(corev1.Resource("secrets"), obj.GetName(), nil)
+				},
+			}).
+			Build()}
+
+		assert.NilError(t, r.Client.Get(ctx, client.ObjectKeyFromObject(stored), stored))
+
+		_, err = r.reconcileRootCertificate(ctx, cluster)
+		assert.Assert(t, apierrors.IsConflict(err), "got %#v", err)
+		assert.Assert(t, cmp.Contains(string(patched),
+			`"resourceVersion":"`+stored.ResourceVersion+`"`))
+	})
+}
+
+func TestProjectedKey(t *testing.T) {
+	projection := &corev1.SecretProjection{}
+	assert.Equal(t, projectedKey(projection, "tls.crt"), "tls.crt")
+
+	projection.Items = []corev1.KeyToPath{{Key: "some.crt", Path: "tls.crt"}}
+	assert.Equal(t, projectedKey(projection, "tls.crt"), "some.crt")
+	assert.Equal(t, projectedKey(projection, "ca.crt"), "")
+}
+
+func TestReconcileCertificateStatus(t *testing.T) {
+	ctx := context.Background()
+
+	root, err := pki.NewRootCertificateAuthority()
+	assert.NilError(t, err)
+	leaf, err := root.GenerateLeafCertificate("some-leaf", nil)
+	assert.NilError(t, err)
+
+	rootText, _ := root.Certificate.MarshalText()
+	leafText, _ := leaf.Certificate.MarshalText()
+
+	// A custom certificate that expires tomorrow.
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "custom"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour).Truncate(time.Second),
+	}
+	customDER, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	assert.NilError(t, err)
+	customText := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: customDER})
+
+	cluster := testCluster()
+	cluster.Namespace, cluster.Name = "ns1", "hippo"
+	cluster.Spec.CustomTLSSecret = &corev1.SecretProjection{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "custom-tls"},
+		Items:                []corev1.KeyToPath{{Key: "server.crt", Path: "tls.crt"}},
+	}
+	cluster.Spec.Proxy = &v1beta1.PostgresProxySpec{
+		PGBouncer: &v1beta1.PGBouncerPodSpec{},
+	}
+
+	objects := []client.Object{
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: naming.RootCertSecret},
+			Data:       map[string][]byte{"root.crt": rootText},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "custom-tls"},
+			Data:       map[string][]byte{"server.crt": customText},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "hippo-pgbouncer"},
+			Data:       map[string][]byte{"pgbouncer-frontend.crt": leafText},
+		},
+	}
+
+	recorder := events.NewRecorder(t, runtime.Scheme)
+	reconciler := &Reconciler{
+		Client:   fake.NewClientBuilder().WithScheme(runtime.Scheme).WithObjects(objects...).Build(),
+		Recorder: recorder,
+	}
+
+	assert.NilError(t, reconciler.reconcileCertificateStatus(ctx, cluster, nil,
+		cluster.Spec.CustomTLSSecret, nil))
+
+	// Missing certificates, such as the authority of the custom secret and
+	// the pgBackRest certificates, are skipped.
+	assert.DeepEqual(t, cluster.Status.Certificates, []v1beta1.CertificateStatus{
+		{
+			Name: "root", Secret: "pgo-root-cacert", Issuer: "CN=postgres-operator-ca",
+			NotAfter: metav1.NewTime(root.Certificate.NotAfter()),
+		},
+		{
+			Name: "postgres-server", Secret: "custom-tls", Issuer: "CN=custom",
+			NotAfter: metav1.NewTime(template.NotAfter), Expiring: true,
+		},
+		{
+			Name: "pgbouncer-frontend", Secret: "hippo-pgbouncer", Issuer: "CN=postgres-operator-ca",
+			NotAfter: metav1.NewTime(leaf.Certificate.NotAfter()),
+		},
+	})
+
+	// Only the custom certificate expires soon.
+	assert.Equal(t, len(recorder.Events), 1)
+	assert.Equal(t, recorder.Events[0].Type, "Warning")
+	assert.Equal(t, recorder.Events[0].Reason, "CertificateExpiring")
+	assert.Assert(t, cmp.Contains(recorder.Events[0].Note, `The postgres-server certificate in Secret "custom-tls" expires at`))
+
+	assert.Equal(t, testutil.ToFloat64(certificateExpiring.WithLabelValues(
+		"ns1", "hippo", "postgres-server", "custom-tls")), float64(1))
+	assert.Equal(t, testutil.ToFloat64(certificateExpiring.WithLabelValues(
+		"ns1", "hippo", "root", "pgo-root-cacert")), float64(0))
+	assert.Equal(t, testutil.ToFloat64(certificateExpiration.WithLabelValues(
+		"ns1", "hippo", "postgres-server", "custom-tls")), float64(template.NotAfter.Unix()))
+
+	// The warning is not repeated while the certificate stays the same.
+	assert.NilError(t, reconciler.reconcileCertificateStatus(ctx, cluster, nil,
+		cluster.Spec.CustomTLSSecret, nil))
+	assert.Equal(t, len(recorder.Events), 1)
+
+	// Metrics of certificates that are gone are removed; the others remain.
+	cluster.Spec.Proxy = nil
+	assert.NilError(t, reconciler.reconcileCertificateStatus(ctx, cluster, nil,
+		cluster.Spec.CustomTLSSecret, nil))
+	assert.Equal(t, len(cluster.Status.Certificates), 2)
+	assert.Equal(t, testutil.CollectAndCount(certificateExpiration), 2)
+	assert.Equal(t, testutil.CollectAndCount(certificateExpiring), 2)
+
+	deleteCertificateMetrics(cluster)
+	assert.Equal(t, testutil.CollectAndCount(certificateExpiration), 0)
+}
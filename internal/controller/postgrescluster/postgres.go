@@ -13,18 +13,22 @@ import (
 	"net"
 	"net/url"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/crunchydata/postgres-operator/internal/config"
 	"github.com/crunchydata/postgres-operator/internal/feature"
 	"github.com/crunchydata/postgres-operator/internal/initialize"
 	"github.com/crunchydata/postgres-operator/internal/logging"
@@ -34,6 +38,7 @@ import (
 	"github.com/crunchydata/postgres-operator/internal/pgbackrest"
 	"github.com/crunchydata/postgres-operator/internal/pgbouncer"
 	"github.com/crunchydata/postgres-operator/internal/pgmonitor"
+	"github.com/crunchydata/postgres-operator/internal/pki"
 	"github.com/crunchydata/postgres-operator/internal/postgis"
 	"github.com/crunchydata/postgres-operator/internal/postgres"
 	pgpassword "github.com/crunchydata/postgres-operator/internal/postgres/password"
@@ -90,6 +95,16 @@ func (r *Reconciler) generatePostgresHBAs(
 	pgmonitor.PostgreSQLHBAs(ctx, cluster, &builtin)
 	pgbouncer.PostgreSQL(cluster, &builtin)
 
+	// Users with client certificates authenticate with them over TLS. These
+	// rules come before any password rules, so those users cannot use passwords.
+	// - https://www.postgresql.org/docs/current/auth-cert.html
+	for _, user := range cluster.Spec.Users {
+		if initialize.FromPointer(user.ClientCertificate) {
+			builtin.Mandatory = append(builtin.Mandatory,
+				postgres.NewHBA().TLS().Users(user.Name).Method("cert"))
+		}
+	}
+
 	// Postgres processes HBA rules in order. Start with mandatory rules
 	// so connections are matched against them first.
 	result := new(postgres.OrderedHBAs)
@@ -133,6 +148,7 @@ func (*Reconciler) generatePostgresParameters(
 	pgbackrest.PostgreSQLParameters(cluster, &builtin, backupsSpecFound)
 	pgmonitor.PostgreSQLParameters(ctx, cluster, &builtin)
 	postgres.SetHugePages(cluster, &builtin)
+	postgres.ExtensionParameters(cluster, &builtin)
 
 	// Last write wins, so start with the recommended defaults.
 	result := cmp.Or(builtin.Default.DeepCopy(), postgres.NewParameterSet())
@@ -181,10 +197,12 @@ func (*Reconciler) generatePostgresParameters(
 }
 
 // generatePostgresUserSecret returns a Secret containing a password and
-// connection details for the first database in spec. When existing is nil or
-// lacks a password or verifier, a new password and verifier are generated.
+// connection details for the first database in spec. When password is not
+// empty, it replaces any existing password. When existing is nil or lacks a
+// password or verifier, a new password and verifier are generated.
 func (r *Reconciler) generatePostgresUserSecret(
 	cluster *v1beta1.PostgresCluster, spec *v1beta1.PostgresUserSpec, existing *corev1.Secret,
+	password []byte,
 ) (*corev1.Secret, error) {
 	username := spec.Name
 	intent := &corev1.Secret{ObjectMeta: naming.PostgresUserSecret(cluster, username)}
@@ -198,9 +216,15 @@ func (r *Reconciler) generatePostgresUserSecret(
 	hostname := primary.Name + "." + primary.Namespace + ".svc"
 	port := fmt.Sprint(*cluster.Spec.Port)
 
+	// Users with rotating passwords may login through an alternate role.
+	login := username
+	if status := postgres.UserRotationStatus(cluster, username); status != nil && status.Login != "" {
+		login = status.Login
+	}
+
 	intent.Data["host"] = []byte(hostname)
 	intent.Data["port"] = []byte(port)
-	intent.Data["user"] = []byte(username)
+	intent.Data["user"] = []byte(login)
 
 	// Use the existing password and verifier.
 	if existing != nil {
@@ -208,6 +232,13 @@ func (r *Reconciler) generatePostgresUserSecret(
 		intent.Data["verifier"] = existing.Data["verifier"]
 	}
 
+	// Use the specified password. Its verifier is generated below when it
+	// differs from the existing password.
+	if len(password) > 0 && !bytes.Equal(password, intent.Data["password"]) {
+		intent.Data["password"] = password
+		intent.Data["verifier"] = nil
+	}
+
 	// When password is unset, generate a new one according to the specified policy.
 	if len(intent.Data["password"]) == 0 {
 		// NOTE: The tests around ASCII passwords are lacking. When changing
@@ -240,15 +271,23 @@ func (r *Reconciler) generatePostgresUserSecret(
 		intent.Data["verifier"] = []byte(verifier)
 	}
 
+	// Users with client certificates must present them over TLS, and the
+	// password rules that follow their "cert" rule in pg_hba.conf never apply.
+	// Leave out connection details that would authenticate with a password;
+	// that includes PgBouncer, which does not have the certificate.
+	certificate := initialize.FromPointer(spec.ClientCertificate)
+
 	// When a database has been specified, include it and a connection URI.
 	// - https://www.postgresql.org/docs/current/libpq-connect.html#LIBPQ-CONNSTRING
 	if len(spec.Databases) > 0 {
+		intent.Data["dbname"] = []byte(spec.Databases[0])
+	}
+	if len(spec.Databases) > 0 && !certificate {
 		database := spec.Databases[0]
 
-		intent.Data["dbname"] = []byte(database)
 		intent.Data["uri"] = []byte((&url.URL{
 			Scheme: "postgresql",
-			User:   url.UserPassword(username, string(intent.Data["password"])),
+			User:   url.UserPassword(login, string(intent.Data["password"])),
 			Host:   net.JoinHostPort(hostname, port),
 			Path:   database,
 		}).String())
@@ -256,7 +295,7 @@ func (r *Reconciler) generatePostgresUserSecret(
 		// The JDBC driver requires a different URI scheme and query component.
 		// - https://jdbc.postgresql.org/documentation/use/#connection-parameters
 		query := url.Values{}
-		query.Set("user", username)
+		query.Set("user", login)
 		query.Set("password", string(intent.Data["password"]))
 		intent.Data["jdbc-uri"] = []byte((&url.URL{
 			Scheme:   "jdbc:postgresql",
@@ -267,7 +306,7 @@ func (r *Reconciler) generatePostgresUserSecret(
 	}
 
 	// When PgBouncer is enabled, include values for connecting through it.
-	if cluster.Spec.Proxy != nil && cluster.Spec.Proxy.PGBouncer != nil {
+	if cluster.Spec.Proxy != nil && cluster.Spec.Proxy.PGBouncer != nil && !certificate {
 		pgBouncer := naming.ClusterPGBouncer(cluster)
 		hostname := pgBouncer.Name + "." + pgBouncer.Namespace + ".svc"
 		port := fmt.Sprint(*cluster.Spec.Proxy.PGBouncer.Port)
@@ -280,7 +319,7 @@ func (r *Reconciler) generatePostgresUserSecret(
 
 			intent.Data["pgbouncer-uri"] = []byte((&url.URL{
 				Scheme: "postgresql",
-				User:   url.UserPassword(username, string(intent.Data["password"])),
+				User:   url.UserPassword(login, string(intent.Data["password"])),
 				Host:   net.JoinHostPort(hostname, port),
 				Path:   database,
 			}).String())
@@ -291,7 +330,7 @@ func (r *Reconciler) generatePostgresUserSecret(
 			// - https://jdbc.postgresql.org/documentation/use/#connection-parameters
 			// - https://www.pgbouncer.org/faq.html#how-to-use-prepared-statements-with-transaction-pooling
 			query := url.Values{}
-			query.Set("user", username)
+			query.Set("user", login)
 			query.Set("password", string(intent.Data["password"]))
 			query.Set("prepareThreshold", "0")
 			intent.Data["pgbouncer-jdbc-uri"] = []byte((&url.URL{
@@ -303,6 +342,81 @@ func (r *Reconciler) generatePostgresUserSecret(
 		}
 	}
 
+	// Include any additional formats of the connection details. Values for
+	// connecting through PgBouncer are included when it is enabled.
+	var database string
+	if len(spec.Databases) > 0 {
+		database = spec.Databases[0]
+	}
+	var userPassword string
+	if !certificate {
+		userPassword = string(intent.Data["password"])
+	}
+	pgBouncerHost := string(intent.Data["pgbouncer-host"])
+	pgBouncerPort := string(intent.Data["pgbouncer-port"])
+
+	for _, format := range spec.ConnectionFormats {
+		switch format {
+		case v1beta1.PostgresConnectionFormatDotNet:
+			intent.Data["dotnet"] = []byte(postgres.DotNetConnectionString(
+				"Host", hostname, "Port", port, "Database", database,
+				"Username", login, "Password", userPassword))
+
+			// Npgsql should not reset sessions that PgBouncer shares.
+			// - https://www.npgsql.org/doc/compatibility.html#pgbouncer
+			if pgBouncerHost != "" {
+				intent.Data["pgbouncer-dotnet"] = []byte(postgres.DotNetConnectionString(
+					"Host", pgBouncerHost, "Port", pgBouncerPort, "Database", database,
+					"Username", login, "Password", userPassword,
+					"No Reset On Close", "true"))
+			}
+
+		case v1beta1.PostgresConnectionFormatKeywordValue:
+			intent.Data["dsn"] = []byte(postgres.KeywordValueString(
+				"host", hostname, "port", port, "dbname", database,
+				"user", login, "password", userPassword))
+
+			if pgBouncerHost != "" {
+				intent.Data["pgbouncer-dsn"] = []byte(postgres.KeywordValueString(
+					"host", pgBouncerHost, "port", pgBouncerPort, "dbname", database,
+					"user", login, "password", userPassword))
+			}
+
+		case v1beta1.PostgresConnectionFormatPGPass:
+			if !certificate {
+				pgpass := postgres.PasswordFileLine(hostname, port, database, login, userPassword)
+
+				if pgBouncerHost != "" {
+					pgpass += postgres.PasswordFileLine(
+						pgBouncerHost, pgBouncerPort, database, login, userPassword)
+				}
+				intent.Data["pgpass"] = []byte(pgpass)
+			}
+
+		case v1beta1.PostgresConnectionFormatPGService:
+			service := postgres.ServiceFileSection(cluster.Name,
+				"host", hostname, "port", port, "dbname", database,
+				"user", login, "password", userPassword)
+
+			if pgBouncerHost != "" {
+				service += postgres.ServiceFileSection(cluster.Name+"-pgbouncer",
+					"host", pgBouncerHost, "port", pgBouncerPort, "dbname", database,
+					"user", login, "password", userPassword)
+			}
+			intent.Data["pg_service.conf"] = []byte(service)
+
+		case v1beta1.PostgresConnectionFormatServiceBinding:
+			// - https://servicebinding.io/spec/core/1.1.0/#well-known-secret-entries
+			intent.Data["type"] = []byte("postgresql")
+			intent.Data["provider"] = []byte("crunchydata")
+			intent.Data["username"] = []byte(login)
+
+			if database != "" {
+				intent.Data["database"] = []byte(database)
+			}
+		}
+	}
+
 	intent.Annotations = cluster.Spec.Metadata.GetAnnotationsOrNil()
 	intent.Labels = naming.Merge(
 		cluster.Spec.Metadata.GetLabelsOrNil(),
@@ -426,15 +540,18 @@ func (r *Reconciler) reconcilePostgresDatabases(
 }
 
 // reconcilePostgresUsers writes the objects necessary to manage users and their
-// passwords in PostgreSQL.
+// passwords in PostgreSQL. It returns how long until it should be called
+// again, or zero.
 func (r *Reconciler) reconcilePostgresUsers(
 	ctx context.Context, cluster *v1beta1.PostgresCluster, instances *observedInstances,
-) error {
+	root *pki.RootCertificateAuthority,
+) (time.Duration, error) {
 	r.validatePostgresUsers(cluster)
 
-	users, secrets, err := r.reconcilePostgresUserSecrets(ctx, cluster)
+	var requeue time.Duration
+	users, secrets, err := r.reconcilePostgresUserSecrets(ctx, cluster, root)
 	if err == nil {
-		err = r.reconcilePostgresUsersInPostgreSQL(ctx, cluster, instances, users, secrets)
+		requeue, err = r.reconcilePostgresUsersInPostgreSQL(ctx, cluster, instances, users, secrets)
 	}
 	if err == nil {
 		// Copy PostgreSQL users and passwords into pgAdmin. This is here because
@@ -443,7 +560,7 @@ func (r *Reconciler) reconcilePostgresUsers(
 		// are available here, too.
 		err = r.reconcilePGAdminUsers(ctx, cluster, users, secrets)
 	}
-	return err
+	return requeue, err
 }
 
 // validatePostgresUsers emits warnings when cluster.Spec.Users contains values
@@ -488,7 +605,7 @@ func (r *Reconciler) validatePostgresUsers(cluster *v1beta1.PostgresCluster) {
 // It returns the user specifications it acted on (because defaults) and the
 // Secrets it wrote.
 func (r *Reconciler) reconcilePostgresUserSecrets(
-	ctx context.Context, cluster *v1beta1.PostgresCluster,
+	ctx context.Context, cluster *v1beta1.PostgresCluster, root *pki.RootCertificateAuthority,
 ) (
 	[]v1beta1.PostgresUserSpec, map[string]*corev1.Secret, error,
 ) {
@@ -610,23 +727,275 @@ func (r *Reconciler) reconcilePostgresUserSecrets(
 			secret = defaultSecret
 		}
 
+		var password []byte
+		if err == nil {
+			password, err = r.getPostgresUserPassword(ctx, cluster, user)
+		}
+		existing := secret
+		if err == nil && rotatePostgresUserPassword(cluster, user, secret, time.Now()) {
+			// Generate a new password rather than use the existing one.
+			secret = nil
+		}
 		if err == nil {
-			userSecrets[userName], err = r.generatePostgresUserSecret(cluster, user, secret)
+			userSecrets[userName], err = r.generatePostgresUserSecret(cluster, user, secret, password)
+		}
+		if err == nil && initialize.FromPointer(user.ClientCertificate) {
+			err = r.postgresUserCertificate(root, user, existing, userSecrets[userName])
 		}
 		if err == nil {
 			err = errors.WithStack(r.apply(ctx, userSecrets[userName]))
 		}
 	}
 
+	// Refer to the Secret of the first user in the Service Binding format.
+	if err == nil {
+		cluster.Status.Binding = nil
+
+		for i := range specUsers {
+			if secret := userSecrets[specUsers[i].Name]; secret != nil && slices.Contains(
+				specUsers[i].ConnectionFormats, v1beta1.PostgresConnectionFormatServiceBinding,
+			) {
+				cluster.Status.Binding = &corev1.LocalObjectReference{Name: secret.Name}
+				break
+			}
+		}
+	}
+
+	// Forget about users that are no longer specified.
+	cluster.Status.Users = slices.DeleteFunc(cluster.Status.Users,
+		func(status v1beta1.PostgresUserStatus) bool { return userSpecs[status.Name] == nil })
+
 	return specUsers, userSecrets, err
 }
 
+// postgresUserRotation returns the rotation interval and grace period of spec.
+// The interval is zero when the password rotates only on request.
+func postgresUserRotation(spec *v1beta1.PostgresUserSpec) (interval, grace time.Duration) {
+	grace = time.Hour
+
+	if spec != nil && spec.Password != nil && spec.Password.Rotation != nil {
+		if rotation := spec.Password.Rotation; rotation.Interval != nil {
+			interval = rotation.Interval.AsDuration().Duration
+		}
+		if rotation := spec.Password.Rotation; rotation.GracePeriod != nil {
+			grace = rotation.GracePeriod.AsDuration().Duration
+		}
+	}
+	return
+}
+
+// rotatePostgresUserPassword updates the rotation status of spec in cluster and
+// reports whether its password should be replaced now. A new password belongs
+// to whichever login role is not current, so the password in existing remains
+// valid until the grace period ends.
+func rotatePostgresUserPassword(
+	cluster *v1beta1.PostgresCluster, spec *v1beta1.PostgresUserSpec,
+	existing *corev1.Secret, now time.Time,
+) bool {
+	enabled := spec.Password != nil && spec.Password.Rotation != nil
+	interval, grace := postgresUserRotation(spec)
+
+	var trigger string
+	if existing != nil {
+		trigger = existing.Annotations[naming.PostgresUserRotatePassword]
+	}
+
+	status := postgres.UserRotationStatus(cluster, spec.Name)
+	if status == nil {
+		if !enabled {
+			return false
+		}
+
+		// Start tracking the user. Its current password is as new as the
+		// rotation policy, and any annotation has already been seen.
+		cluster.Status.Users = append(cluster.Status.Users, v1beta1.PostgresUserStatus{
+			Name:             spec.Name,
+			Login:            spec.Name,
+			LastRotationTime: initialize.Pointer(metav1.NewTime(now)),
+			RotationTrigger:  trigger,
+		})
+		return false
+	}
+
+	// The previous password is revoked when its grace period ends.
+	revoked := status.PreviousLogin == ""
+	if status.PreviousLogin != "" &&
+		(status.LastRotationTime == nil || !now.Before(status.LastRotationTime.Add(grace))) {
+		status.PreviousLogin = ""
+	}
+
+	if !enabled {
+		// Stop tracking the user once it is back to its own login role and
+		// the alternate has had its password removed.
+		if status.PreviousLogin == "" && cmp.Or(status.Login, spec.Name) == spec.Name {
+			if !revoked {
+				return false
+			}
+			cluster.Status.Users = slices.DeleteFunc(cluster.Status.Users,
+				func(status v1beta1.PostgresUserStatus) bool { return status.Name == spec.Name })
+			return false
+		}
+
+		// Move the user back to its own login role with a new password. The
+		// alternate keeps its password until the grace period ends.
+		if status.Login == spec.Name || status.PreviousLogin != "" {
+			return false
+		}
+		if existing != nil && len(existing.Data["password"]) > 0 {
+			status.PreviousLogin = status.Login
+		}
+		status.Login = spec.Name
+		status.LastRotationTime = initialize.Pointer(metav1.NewTime(now))
+		status.RotationTrigger = trigger
+		return true
+	}
+
+	due := trigger != "" && trigger != status.RotationTrigger
+	if interval > 0 && status.LastRotationTime != nil {
+		due = due || !now.Before(status.LastRotationTime.Add(interval))
+	}
+
+	// Wait for the grace period of the previous rotation to end.
+	if !due || status.PreviousLogin != "" {
+		return false
+	}
+
+	current := cmp.Or(status.Login, spec.Name)
+	if existing != nil && len(existing.Data["password"]) > 0 {
+		status.PreviousLogin = current
+	}
+	if current == spec.Name {
+		status.Login = postgres.AlternateUserName(spec.Name)
+	} else {
+		status.Login = spec.Name
+	}
+	status.LastRotationTime = initialize.Pointer(metav1.NewTime(now))
+	status.RotationTrigger = trigger
+
+	return true
+}
+
+// postgresUserRotationRequeue returns how long until a password in cluster is
+// due to rotate or leave its grace period. It returns zero when there is none.
+func postgresUserRotationRequeue(cluster *v1beta1.PostgresCluster, now time.Time) time.Duration {
+	var next time.Duration
+
+	consider := func(at time.Time) {
+		if d := at.Sub(now); d > 0 && (next == 0 || d < next) {
+			next = d
+		}
+	}
+
+	for _, status := range cluster.Status.Users {
+		if status.LastRotationTime == nil {
+			continue
+		}
+
+		var spec *v1beta1.PostgresUserSpec
+		for i := range cluster.Spec.Users {
+			if cluster.Spec.Users[i].Name == status.Name {
+				spec = &cluster.Spec.Users[i]
+			}
+		}
+
+		interval, grace := postgresUserRotation(spec)
+		if status.PreviousLogin != "" {
+			consider(status.LastRotationTime.Add(grace))
+		}
+		if interval > 0 {
+			consider(status.LastRotationTime.Add(interval))
+		}
+	}
+
+	return next
+}
+
+// postgresUserCertificate populates intent with a client certificate for the
+// user in spec, its private key, and the certificate authority. The certificate
+// in existing is kept until it is no longer valid or nears expiration.
+func (*Reconciler) postgresUserCertificate(
+	root *pki.RootCertificateAuthority, spec *v1beta1.PostgresUserSpec,
+	existing, intent *corev1.Secret,
+) error {
+	const keyCertificate, keyPrivateKey, rootCA = "tls.crt", "tls.key", "ca.crt"
+
+	leaf := &pki.LeafCertificate{}
+
+	// Unmarshal and validate the stored leaf. These first errors can be
+	// ignored because they result in an invalid leaf which is then correctly
+	// regenerated. PostgreSQL compares the common name to the user name.
+	if existing != nil {
+		_ = leaf.Certificate.UnmarshalText(existing.Data[keyCertificate])
+		_ = leaf.PrivateKey.UnmarshalText(existing.Data[keyPrivateKey])
+	}
+
+	leaf, err := root.RegenerateLeafWhenNecessary(leaf, spec.Name, nil)
+	err = errors.WithStack(err)
+
+	if err == nil {
+		intent.Data[keyCertificate], err = leaf.Certificate.MarshalText()
+		err = errors.WithStack(err)
+	}
+	if err == nil {
+		intent.Data[keyPrivateKey], err = leaf.PrivateKey.MarshalText()
+		err = errors.WithStack(err)
+	}
+	if err == nil {
+		intent.Data[rootCA], err = root.Trust().MarshalText()
+		err = errors.WithStack(err)
+	}
+
+	return err
+}
+
+// +kubebuilder:rbac:groups="",resources="secrets",verbs={get}
+
+// getPostgresUserPassword returns the password in the Secret key referenced by
+// spec. It returns nil when there is no reference or the key has no value.
+func (r *Reconciler) getPostgresUserPassword(
+	ctx context.Context, cluster *v1beta1.PostgresCluster, spec *v1beta1.PostgresUserSpec,
+) ([]byte, error) {
+	if spec.Password == nil || spec.Password.SecretKeyRef == nil {
+		return nil, nil
+	}
+
+	ref := spec.Password.SecretKeyRef
+	secret := &corev1.Secret{}
+	err := errors.WithStack(client.IgnoreNotFound(
+		r.Client.Get(ctx, client.ObjectKey{
+			Namespace: cluster.Namespace, Name: ref.Name,
+		}, secret)))
+
+	password := secret.Data[ref.Key]
+
+	// Warn when a required password is missing. The user keeps their existing
+	// password, if any, until one is available.
+	if err == nil && len(password) == 0 && !initialize.FromPointer(ref.Optional) {
+		r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "UserPasswordNotFound",
+			"Unable to find password for user %q in key %q of Secret %q",
+			spec.Name, ref.Key, ref.Name)
+	}
+
+	return password, err
+}
+
+const (
+	// ConditionUsersReady is the type used in a condition to indicate whether or
+	// not the users in cluster.spec.users are written with all their privileges.
+	ConditionUsersReady = "PostgresUsersReady"
+
+	// usersRetryInterval is how long to wait before trying again to grant
+	// privileges on schemas that did not exist.
+	usersRetryInterval = time.Minute
+)
+
 // reconcilePostgresUsersInPostgreSQL creates users inside of PostgreSQL and
-// sets their options and database access as specified.
+// sets their options and database access as specified. It returns how long
+// until it should be called again, or zero.
 func (r *Reconciler) reconcilePostgresUsersInPostgreSQL(
 	ctx context.Context, cluster *v1beta1.PostgresCluster, instances *observedInstances,
 	specUsers []v1beta1.PostgresUserSpec, userSecrets map[string]*corev1.Secret,
-) error {
+) (time.Duration, error) {
 	const container = naming.ContainerDatabase
 	var podExecutor postgres.Executor
 
@@ -654,7 +1023,7 @@ func (r *Reconciler) reconcilePostgresUsersInPostgreSQL(
 		}
 	}
 	if podExecutor == nil {
-		return nil
+		return 0, nil
 	}
 
 	// Calculate a hash of the SQL that should be executed in PostgreSQL.
@@ -686,7 +1055,7 @@ func (r *Reconciler) reconcilePostgresUsersInPostgreSQL(
 
 		// TODO(cbandy): Give the user a way to trigger execution regardless.
 		// The value of an annotation could influence the hash, for example.
-		return nil
+		return 0, nil
 	}
 
 	// Apply the necessary SQL and record its hash in cluster.Status. Include
@@ -696,11 +1065,158 @@ func (r *Reconciler) reconcilePostgresUsersInPostgreSQL(
 		log := logging.FromContext(ctx).WithValues("revision", revision)
 		err = errors.WithStack(write(logging.NewContext(ctx, log), podExecutor))
 	}
+
+	// Grants on schemas that do not exist yet should not hold up the rest of
+	// the cluster. Leave the revision unchanged so they are tried again shortly.
+	// Report the schemas when they differ from the last attempt.
+	var missing *postgres.MissingSchemasError
+	if errors.As(err, &missing) {
+		schemas := slices.Clone(missing.Schemas)
+		slices.Sort(schemas)
+		message := "Unable to grant privileges on schemas that do not exist: " +
+			strings.Join(schemas, ", ")
+
+		if previous := meta.FindStatusCondition(cluster.Status.Conditions,
+			ConditionUsersReady); previous == nil || previous.Message != message {
+			r.Recorder.Event(cluster, corev1.EventTypeWarning, "SchemasNotFound", message)
+		}
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			ObservedGeneration: cluster.GetGeneration(),
+			Type:               ConditionUsersReady,
+			Status:             metav1.ConditionFalse,
+			Reason:             "SchemasNotFound",
+			Message:            message,
+		})
+		return usersRetryInterval, nil
+	}
 	if err == nil {
 		cluster.Status.UsersRevision = revision
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			ObservedGeneration: cluster.GetGeneration(),
+			Type:               ConditionUsersReady,
+			Status:             metav1.ConditionTrue,
+			Reason:             "UsersWritten",
+			Message:            "PostgreSQL users are written with their privileges",
+		})
 	}
 
-	return err
+	return 0, err
+}
+
+const (
+	// ConditionExtensionsReady is the type used in a condition to indicate whether or not
+	// the extensions in cluster.spec.extensions are installed and up to date
+	ConditionExtensionsReady = "PostgresExtensionsReady"
+
+	// extensionsRetryInterval is how long to wait before trying again to
+	// install or update extensions that could not be written.
+	extensionsRetryInterval = time.Minute
+)
+
+// reconcilePostgresExtensions installs and updates the extensions in
+// cluster.spec.extensions then records their versions in cluster.Status. It
+// returns how long until it should be called again, or zero.
+func (r *Reconciler) reconcilePostgresExtensions(
+	ctx context.Context, cluster *v1beta1.PostgresCluster, instances *observedInstances,
+) (time.Duration, error) {
+	const container = naming.ContainerDatabase
+	var podExecutor postgres.Executor
+
+	// Forget about extensions when none are specified. Removing an extension
+	// from the spec does NOT drop it from PostgreSQL.
+	if len(cluster.Spec.Extensions) == 0 {
+		cluster.Status.Extensions = nil
+		cluster.Status.ExtensionsRevision = ""
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, ConditionExtensionsReady)
+		return 0, nil
+	}
+
+	// Find the PostgreSQL instance that can execute SQL that writes system
+	// catalogs. When there is none, return early.
+	pod, _ := instances.writablePod(container)
+	if pod == nil {
+		return 0, nil
+	}
+
+	ctx = logging.NewContext(ctx, logging.FromContext(ctx).WithValues("pod", pod.Name))
+	podExecutor = func(
+		ctx context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+	) error {
+		return r.PodExec(ctx, pod.Namespace, pod.Name, container, stdin, stdout, stderr, command...)
+	}
+
+	write := func(ctx context.Context, exec postgres.Executor) error {
+		return postgres.WriteExtensionsInPostgreSQL(ctx, exec, cluster.Spec.Extensions)
+	}
+
+	// Calculate a hash of the SQL that should be executed in PostgreSQL. The
+	// versions available depend on the image, so include that, too.
+	revision, err := safeHash32(func(hasher io.Writer) error {
+		_, err := fmt.Fprint(hasher, config.PostgresContainerImage(cluster))
+		if err != nil {
+			return err
+		}
+
+		// Discard log messages about executing SQL.
+		return write(logging.NewContext(ctx, logging.Discard()), func(
+			_ context.Context, stdin io.Reader, _, _ io.Writer, command ...string,
+		) error {
+			_, err := fmt.Fprint(hasher, command)
+			if err == nil && stdin != nil {
+				_, err = io.Copy(hasher, stdin)
+			}
+			return err
+		})
+	})
+
+	if err == nil && revision == cluster.Status.ExtensionsRevision {
+		// The necessary SQL has already been applied; there's nothing more to do.
+		return 0, nil
+	}
+
+	// Apply the necessary SQL and record its hash in cluster.Status. Include
+	// the hash in any log messages.
+
+	if err == nil {
+		log := logging.FromContext(ctx).WithValues("revision", revision)
+		ctx = logging.NewContext(ctx, log)
+
+		// Some extensions can only be installed after their shared library is
+		// loaded, which requires PostgreSQL to restart. Report the failure and
+		// try again shortly rather than hold up the rest.
+		if err := write(ctx, podExecutor); err != nil {
+			log.Error(err, "unable to install or update PostgreSQL extensions")
+			r.Recorder.Event(cluster, corev1.EventTypeWarning, "ExtensionsNotWritten",
+				"Unable to install or update PostgreSQL extensions")
+			meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+				ObservedGeneration: cluster.GetGeneration(),
+				Type:               ConditionExtensionsReady,
+				Status:             metav1.ConditionFalse,
+				Reason:             "ExtensionsNotWritten",
+				Message: "Unable to install or update PostgreSQL extensions; " +
+					"check operator logs for details",
+			})
+			return extensionsRetryInterval, nil
+		}
+
+		var status []v1beta1.PostgresExtensionStatus
+		status, err = postgres.ExtensionsInPostgreSQL(ctx, podExecutor, cluster.Spec.Extensions)
+		err = errors.WithStack(err)
+
+		if err == nil {
+			cluster.Status.Extensions = status
+			cluster.Status.ExtensionsRevision = revision
+			meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+				ObservedGeneration: cluster.GetGeneration(),
+				Type:               ConditionExtensionsReady,
+				Status:             metav1.ConditionTrue,
+				Reason:             "ExtensionsWritten",
+				Message:            "PostgreSQL extensions are installed and up to date",
+			})
+		}
+	}
+
+	return 0, err
 }
 
 // +kubebuilder:rbac:groups="",resources="persistentvolumeclaims",verbs={create,patch}
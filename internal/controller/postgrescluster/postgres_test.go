@@ -12,6 +12,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp/cmpopts"
 	volumesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
@@ -19,13 +20,16 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
 	"github.com/crunchydata/postgres-operator/internal/feature"
 	"github.com/crunchydata/postgres-operator/internal/initialize"
 	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/internal/pki"
 	"github.com/crunchydata/postgres-operator/internal/postgres"
 	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
 	"github.com/crunchydata/postgres-operator/internal/testing/events"
@@ -159,6 +163,23 @@ func TestGeneratePostgresHBAs(t *testing.T) {
 			`another`,                              // Patroni
 		})
 	})
+
+	t.Run("ClientCertificate", func(t *testing.T) {
+		cluster := v1beta1.NewPostgresCluster()
+		require.UnmarshalInto(t, &cluster.Spec.Users, `[
+			{ name: alice, clientCertificate: true },
+			{ name: bob, clientCertificate: false },
+			{ name: carol },
+		]`)
+
+		result := reconciler.generatePostgresHBAs(ctx, cluster).AsStrings()
+		assert.Assert(t, cmp.Len(result, len(required)+1+len(defaults)),
+			"expected one more mandatory rule")
+
+		assert.DeepEqual(t, result[:len(required)], required)
+		assert.Equal(t, result[len(required)], `hostssl all "alice" all "cert"`)
+		assert.DeepEqual(t, result[len(required)+1:], defaults)
+	})
 }
 
 func TestGeneratePostgresParameters(t *testing.T) {
@@ -275,6 +296,23 @@ func TestGeneratePostgresParameters(t *testing.T) {
 			assert.Equal(t, result.Value("shared_preload_libraries"), "citus,pgaudit,given, citus,other",
 				"expected citus in front")
 		})
+
+		t.Run("Extensions", func(t *testing.T) {
+			cluster := v1beta1.NewPostgresCluster()
+			require.UnmarshalInto(t, &cluster.Spec, `{
+				config: { parameters: { shared_preload_libraries: given } },
+				extensions: [
+					{ name: pg_cron },
+					{ name: pgaudit },
+					{ name: pg_stat_kcache, sharedPreloadLibraries: [pg_cron, other] },
+				],
+			}`)
+
+			result := reconciler.generatePostgresParameters(ctx, cluster, false)
+			assert.Equal(t, result.Value("shared_preload_libraries"),
+				"pgaudit,pg_cron,pg_stat_statements,pg_stat_kcache,other,given",
+				"expected extension libraries once, ahead of specified")
+		})
 	})
 }
 
@@ -292,7 +330,7 @@ func TestGeneratePostgresUserSecret(t *testing.T) {
 	spec := &v1beta1.PostgresUserSpec{Name: "some-user-name"}
 
 	t.Run("ObjectMeta", func(t *testing.T) {
-		secret, err := reconciler.generatePostgresUserSecret(cluster, spec, nil)
+		secret, err := reconciler.generatePostgresUserSecret(cluster, spec, nil, nil)
 		assert.NilError(t, err)
 
 		if assert.Check(t, secret != nil) {
@@ -307,7 +345,7 @@ func TestGeneratePostgresUserSecret(t *testing.T) {
 	})
 
 	t.Run("Primary", func(t *testing.T) {
-		secret, err := reconciler.generatePostgresUserSecret(cluster, spec, nil)
+		secret, err := reconciler.generatePostgresUserSecret(cluster, spec, nil, nil)
 		assert.NilError(t, err)
 
 		if assert.Check(t, secret != nil) {
@@ -319,7 +357,7 @@ func TestGeneratePostgresUserSecret(t *testing.T) {
 
 	t.Run("Password", func(t *testing.T) {
 		// Generated when no existing Secret.
-		secret, err := reconciler.generatePostgresUserSecret(cluster, spec, nil)
+		secret, err := reconciler.generatePostgresUserSecret(cluster, spec, nil, nil)
 		assert.NilError(t, err)
 
 		if assert.Check(t, secret != nil) {
@@ -328,7 +366,7 @@ func TestGeneratePostgresUserSecret(t *testing.T) {
 		}
 
 		// Generated when existing Secret is lacking.
-		secret, err = reconciler.generatePostgresUserSecret(cluster, spec, new(corev1.Secret))
+		secret, err = reconciler.generatePostgresUserSecret(cluster, spec, new(corev1.Secret), nil)
 		assert.NilError(t, err)
 
 		if assert.Check(t, secret != nil) {
@@ -341,7 +379,7 @@ func TestGeneratePostgresUserSecret(t *testing.T) {
 
 			// ASCII when unspecified.
 			spec.Password = nil
-			secret, err = reconciler.generatePostgresUserSecret(cluster, spec, new(corev1.Secret))
+			secret, err = reconciler.generatePostgresUserSecret(cluster, spec, new(corev1.Secret), nil)
 			assert.NilError(t, err)
 
 			if assert.Check(t, secret != nil) {
@@ -356,7 +394,7 @@ func TestGeneratePostgresUserSecret(t *testing.T) {
 				Type: v1beta1.PostgresPasswordTypeAlphaNumeric,
 			}
 
-			secret, err = reconciler.generatePostgresUserSecret(cluster, spec, new(corev1.Secret))
+			secret, err = reconciler.generatePostgresUserSecret(cluster, spec, new(corev1.Secret), nil)
 			assert.NilError(t, err)
 
 			if assert.Check(t, secret != nil) {
@@ -369,7 +407,7 @@ func TestGeneratePostgresUserSecret(t *testing.T) {
 			Data: map[string][]byte{
 				"password": []byte(`asdf`),
 			},
-		})
+		}, nil)
 		assert.NilError(t, err)
 
 		if assert.Check(t, secret != nil) {
@@ -377,13 +415,41 @@ func TestGeneratePostgresUserSecret(t *testing.T) {
 			assert.Assert(t, len(secret.Data["verifier"]) > 90, "got %v", len(secret.Data["verifier"]))
 		}
 
+		// Replaced by the specified password.
+		secret, err = reconciler.generatePostgresUserSecret(cluster, spec, &corev1.Secret{
+			Data: map[string][]byte{
+				"password": []byte(`asdf`),
+				"verifier": []byte(`some$thing`),
+			},
+		}, []byte(`specified`))
+		assert.NilError(t, err)
+
+		if assert.Check(t, secret != nil) {
+			assert.Equal(t, string(secret.Data["password"]), "specified")
+			assert.Assert(t, len(secret.Data["verifier"]) > 90, "got %v", len(secret.Data["verifier"]))
+		}
+
+		// Verifier is kept when the specified password is unchanged.
+		secret, err = reconciler.generatePostgresUserSecret(cluster, spec, &corev1.Secret{
+			Data: map[string][]byte{
+				"password": []byte(`asdf`),
+				"verifier": []byte(`some$thing`),
+			},
+		}, []byte(`asdf`))
+		assert.NilError(t, err)
+
+		if assert.Check(t, secret != nil) {
+			assert.Equal(t, string(secret.Data["password"]), "asdf")
+			assert.Equal(t, string(secret.Data["verifier"]), "some$thing")
+		}
+
 		// Copied when existing Secret is full.
 		secret, err = reconciler.generatePostgresUserSecret(cluster, spec, &corev1.Secret{
 			Data: map[string][]byte{
 				"password": []byte(`asdf`),
 				"verifier": []byte(`some$thing`),
 			},
-		})
+		}, nil)
 		assert.NilError(t, err)
 
 		if assert.Check(t, secret != nil) {
@@ -396,7 +462,7 @@ func TestGeneratePostgresUserSecret(t *testing.T) {
 		spec := *spec
 
 		// Missing when none specified.
-		secret, err := reconciler.generatePostgresUserSecret(cluster, &spec, nil)
+		secret, err := reconciler.generatePostgresUserSecret(cluster, &spec, nil, nil)
 		assert.NilError(t, err)
 
 		if assert.Check(t, secret != nil) {
@@ -408,7 +474,7 @@ func TestGeneratePostgresUserSecret(t *testing.T) {
 		// Present when specified.
 		spec.Databases = []string{"db1"}
 
-		secret, err = reconciler.generatePostgresUserSecret(cluster, &spec, nil)
+		secret, err = reconciler.generatePostgresUserSecret(cluster, &spec, nil, nil)
 		assert.NilError(t, err)
 
 		if assert.Check(t, secret != nil) {
@@ -425,7 +491,7 @@ func TestGeneratePostgresUserSecret(t *testing.T) {
 		// Only the first in the list.
 		spec.Databases = []string{"first", "asdf"}
 
-		secret, err = reconciler.generatePostgresUserSecret(cluster, &spec, nil)
+		secret, err = reconciler.generatePostgresUserSecret(cluster, &spec, nil, nil)
 		assert.NilError(t, err)
 
 		if assert.Check(t, secret != nil) {
@@ -445,7 +511,7 @@ func TestGeneratePostgresUserSecret(t *testing.T) {
 			proxy: { pgBouncer: { port: 10220 } },
 		}`)
 
-		secret, err := reconciler.generatePostgresUserSecret(cluster, spec, nil)
+		secret, err := reconciler.generatePostgresUserSecret(cluster, spec, nil, nil)
 		assert.NilError(t, err)
 
 		if assert.Check(t, secret != nil) {
@@ -459,7 +525,7 @@ func TestGeneratePostgresUserSecret(t *testing.T) {
 		spec := *spec
 		spec.Databases = []string{"yes", "no"}
 
-		secret, err = reconciler.generatePostgresUserSecret(cluster, &spec, nil)
+		secret, err = reconciler.generatePostgresUserSecret(cluster, &spec, nil, nil)
 		assert.NilError(t, err)
 
 		if assert.Check(t, secret != nil) {
@@ -472,6 +538,126 @@ func TestGeneratePostgresUserSecret(t *testing.T) {
 				string(secret.Data["pgbouncer-jdbc-uri"])))
 		}
 	})
+
+	t.Run("ConnectionFormats", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Proxy = nil
+
+		spec := *spec
+		spec.Databases = []string{"db1"}
+		spec.ConnectionFormats = []string{
+			"DotNet", "KeywordValue", "PGPass", "PGService", "ServiceBinding",
+		}
+
+		existing := &corev1.Secret{Data: map[string][]byte{
+			"password": []byte(`a'b:c;d`),
+			"verifier": []byte("some$verifier"),
+		}}
+
+		secret, err := reconciler.generatePostgresUserSecret(cluster, &spec, existing, nil)
+		assert.NilError(t, err)
+
+		if assert.Check(t, secret != nil) {
+			assert.Equal(t, string(secret.Data["dotnet"]),
+				`Host=hippo2-primary.ns1.svc;Port=9999;Database=db1;Username=some-user-name;Password="a'b:c;d";`)
+			assert.Equal(t, string(secret.Data["dsn"]),
+				`host=hippo2-primary.ns1.svc port=9999 dbname=db1 user=some-user-name password='a\'b:c;d'`)
+			assert.Equal(t, string(secret.Data["pgpass"]),
+				`hippo2-primary.ns1.svc:9999:db1:some-user-name:a'b\:c;d`+"\n")
+			assert.Equal(t, string(secret.Data["pg_service.conf"]), strings.Join([]string{
+				`[hippo2]`,
+				`host=hippo2-primary.ns1.svc`,
+				`port=9999`,
+				`dbname=db1`,
+				`user=some-user-name`,
+				`password=a'b:c;d`,
+				``,
+			}, "\n"))
+
+			assert.Equal(t, string(secret.Data["type"]), "postgresql")
+			assert.Equal(t, string(secret.Data["provider"]), "crunchydata")
+			assert.Equal(t, string(secret.Data["username"]), "some-user-name")
+			assert.Equal(t, string(secret.Data["database"]), "db1")
+		}
+
+		// PgBouncer values are included when it is enabled.
+		require.UnmarshalInto(t, &cluster.Spec, `{
+			proxy: { pgBouncer: { port: 10220 } },
+		}`)
+
+		secret, err = reconciler.generatePostgresUserSecret(cluster, &spec, existing, nil)
+		assert.NilError(t, err)
+
+		if assert.Check(t, secret != nil) {
+			assert.Assert(t, cmp.Contains(string(secret.Data["pgbouncer-dotnet"]),
+				`Host=hippo2-pgbouncer.ns1.svc;Port=10220;`))
+			assert.Assert(t, cmp.Contains(string(secret.Data["pgbouncer-dotnet"]),
+				`No Reset On Close=true;`))
+			assert.Assert(t, cmp.Contains(string(secret.Data["pgbouncer-dsn"]),
+				`host=hippo2-pgbouncer.ns1.svc port=10220 `))
+			assert.Assert(t, cmp.Contains(string(secret.Data["pgpass"]),
+				"\nhippo2-pgbouncer.ns1.svc:10220:db1:"))
+			assert.Assert(t, cmp.Contains(string(secret.Data["pg_service.conf"]),
+				"\n[hippo2-pgbouncer]\nhost=hippo2-pgbouncer.ns1.svc\nport=10220\n"))
+		}
+	})
+
+	t.Run("ClientCertificate", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		require.UnmarshalInto(t, &cluster.Spec, `{
+			proxy: { pgBouncer: { port: 10220 } },
+		}`)
+
+		spec := *spec
+		spec.ClientCertificate = initialize.Bool(true)
+		spec.Databases = []string{"db1"}
+		spec.ConnectionFormats = []string{"KeywordValue", "PGPass", "PGService"}
+
+		secret, err := reconciler.generatePostgresUserSecret(cluster, &spec, nil, nil)
+		assert.NilError(t, err)
+
+		if assert.Check(t, secret != nil) {
+			assert.Equal(t, string(secret.Data["dbname"]), "db1")
+			assert.Assert(t, len(secret.Data["password"]) > 0)
+			assert.Assert(t, len(secret.Data["verifier"]) > 0)
+
+			// Connection details that authenticate with a password are omitted.
+			for _, key := range []string{
+				"uri", "jdbc-uri", "pgpass",
+				"pgbouncer-host", "pgbouncer-port", "pgbouncer-uri", "pgbouncer-jdbc-uri",
+				"pgbouncer-dsn",
+			} {
+				assert.Assert(t, secret.Data[key] == nil, "key %q", key)
+			}
+
+			assert.Equal(t, string(secret.Data["dsn"]),
+				`host=hippo2-primary.ns1.svc port=9999 dbname=db1 user=some-user-name`)
+			assert.Assert(t, !strings.Contains(string(secret.Data["pg_service.conf"]), "password"))
+		}
+	})
+
+	t.Run("Rotation", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Status.Users = []v1beta1.PostgresUserStatus{{
+			Name: "some-user-name", Login: "some-user-name-alt",
+		}}
+
+		spec := *spec
+		spec.Databases = []string{"db1"}
+
+		secret, err := reconciler.generatePostgresUserSecret(cluster, &spec, nil, nil)
+		assert.NilError(t, err)
+
+		if assert.Check(t, secret != nil) {
+			assert.Equal(t, secret.Name, "hippo2-pguser-some-user-name")
+			assert.Equal(t, string(secret.Data["user"]), "some-user-name-alt")
+			assert.Assert(t, cmp.Regexp(
+				`^postgresql://some-user-name-alt:[^@]+@hippo2-primary.ns1.svc:9999/db1$`,
+				string(secret.Data["uri"])))
+			assert.Assert(t, cmp.Regexp(
+				`[?&]user=some-user-name-alt$`, string(secret.Data["jdbc-uri"])))
+		}
+	})
 }
 
 func TestReconcilePostgresVolumes(t *testing.T) {
@@ -848,6 +1034,494 @@ volumeMode: Filesystem
 	})
 }
 
+func TestGetPostgresUserPassword(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := v1beta1.NewPostgresCluster()
+	cluster.Namespace, cluster.Name = "ns1", "hippo"
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "external"},
+		Data:       map[string][]byte{"pw": []byte("from-secret")},
+	}
+
+	recorder := events.NewRecorder(t, runtime.Scheme)
+	reconciler := &Reconciler{
+		Client:   fake.NewClientBuilder().WithScheme(runtime.Scheme).WithObjects(secret).Build(),
+		Recorder: recorder,
+	}
+
+	t.Run("Unspecified", func(t *testing.T) {
+		password, err := reconciler.getPostgresUserPassword(ctx, cluster,
+			&v1beta1.PostgresUserSpec{Name: "u1"})
+		assert.NilError(t, err)
+		assert.Assert(t, password == nil)
+
+		password, err = reconciler.getPostgresUserPassword(ctx, cluster,
+			&v1beta1.PostgresUserSpec{Name: "u1", Password: &v1beta1.PostgresPasswordSpec{}})
+		assert.NilError(t, err)
+		assert.Assert(t, password == nil)
+	})
+
+	t.Run("Found", func(t *testing.T) {
+		recorder.Events = nil
+
+		var spec v1beta1.PostgresUserSpec
+		require.UnmarshalInto(t, &spec, `{
+			name: u1, password: { secretKeyRef: { name: external, key: pw } },
+		}`)
+
+		password, err := reconciler.getPostgresUserPassword(ctx, cluster, &spec)
+		assert.NilError(t, err)
+		assert.Equal(t, string(password), "from-secret")
+		assert.Equal(t, len(recorder.Events), 0)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		for _, tt := range []struct{ name, ref string }{
+			{name: "Secret", ref: `{ name: missing, key: pw }`},
+			{name: "Key", ref: `{ name: external, key: missing }`},
+		} {
+			t.Run(tt.name, func(t *testing.T) {
+				recorder.Events = nil
+
+				var spec v1beta1.PostgresUserSpec
+				require.UnmarshalInto(t, &spec, `{ name: u1, password: { secretKeyRef: `+tt.ref+` } }`)
+
+				password, err := reconciler.getPostgresUserPassword(ctx, cluster, &spec)
+				assert.NilError(t, err)
+				assert.Assert(t, password == nil)
+
+				assert.Equal(t, len(recorder.Events), 1)
+				assert.Equal(t, recorder.Events[0].Reason, "UserPasswordNotFound")
+				assert.Assert(t, cmp.Contains(recorder.Events[0].Note, `"u1"`))
+			})
+		}
+
+		t.Run("Optional", func(t *testing.T) {
+			recorder.Events = nil
+
+			var spec v1beta1.PostgresUserSpec
+			require.UnmarshalInto(t, &spec, `{
+				name: u1, password: { secretKeyRef: { name: missing, key: pw, optional: true } },
+			}`)
+
+			password, err := reconciler.getPostgresUserPassword(ctx, cluster, &spec)
+			assert.NilError(t, err)
+			assert.Assert(t, password == nil)
+			assert.Equal(t, len(recorder.Events), 0)
+		})
+	})
+}
+
+func TestPostgresUserCertificate(t *testing.T) {
+	reconciler := &Reconciler{}
+	root, err := pki.NewRootCertificateAuthority()
+	assert.NilError(t, err)
+
+	spec := &v1beta1.PostgresUserSpec{Name: "some-user"}
+	intent := &corev1.Secret{Data: map[string][]byte{}}
+
+	assert.NilError(t, reconciler.postgresUserCertificate(root, spec, nil, intent))
+
+	var certificate pki.Certificate
+	assert.NilError(t, certificate.UnmarshalText(intent.Data["tls.crt"]))
+	assert.Equal(t, certificate.CommonName(), "some-user")
+	assert.Assert(t, len(intent.Data["tls.key"]) > 0)
+
+	authority, _ := root.Certificate.MarshalText()
+	assert.DeepEqual(t, intent.Data["ca.crt"], authority)
+
+	t.Run("Existing", func(t *testing.T) {
+		existing := intent.DeepCopy()
+		next := &corev1.Secret{Data: map[string][]byte{}}
+
+		// A valid certificate is kept.
+		assert.NilError(t, reconciler.postgresUserCertificate(root, spec, existing, next))
+		assert.DeepEqual(t, next.Data, existing.Data)
+
+		// A certificate for another user is replaced.
+		other := &v1beta1.PostgresUserSpec{Name: "other-user"}
+		assert.NilError(t, reconciler.postgresUserCertificate(root, other, existing, next))
+		assert.NilError(t, certificate.UnmarshalText(next.Data["tls.crt"]))
+		assert.Equal(t, certificate.CommonName(), "other-user")
+	})
+}
+
+func TestRotatePostgresUserPassword(t *testing.T) {
+	now := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	existing := &corev1.Secret{Data: map[string][]byte{"password": []byte("old")}}
+
+	var spec v1beta1.PostgresUserSpec
+	require.UnmarshalInto(t, &spec, `{
+		name: app, password: { type: ASCII, rotation: { interval: 90d, gracePeriod: 2h } },
+	}`)
+
+	t.Run("Disabled", func(t *testing.T) {
+		cluster := v1beta1.NewPostgresCluster()
+
+		assert.Assert(t, !rotatePostgresUserPassword(cluster,
+			&v1beta1.PostgresUserSpec{Name: "app"}, existing, now))
+		assert.Assert(t, cluster.Status.Users == nil)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cluster := v1beta1.NewPostgresCluster()
+
+		// The current password is not replaced right away.
+		assert.Assert(t, !rotatePostgresUserPassword(cluster, &spec, existing, now))
+		assert.DeepEqual(t, cluster.Status.Users, []v1beta1.PostgresUserStatus{{
+			Name: "app", Login: "app",
+			LastRotationTime: initialize.Pointer(metav1.NewTime(now)),
+		}})
+
+		// Nothing happens before the interval.
+		later := now.Add(89 * 24 * time.Hour)
+		assert.Assert(t, !rotatePostgresUserPassword(cluster, &spec, existing, later))
+		assert.Equal(t, cluster.Status.Users[0].Login, "app")
+
+		// The password rotates to the alternate role after the interval.
+		later = now.Add(90 * 24 * time.Hour)
+		assert.Assert(t, rotatePostgresUserPassword(cluster, &spec, existing, later))
+		assert.DeepEqual(t, cluster.Status.Users, []v1beta1.PostgresUserStatus{{
+			Name: "app", Login: "app-alt", PreviousLogin: "app",
+			LastRotationTime: initialize.Pointer(metav1.NewTime(later)),
+		}})
+
+		// The previous password is revoked after the grace period.
+		assert.Assert(t, !rotatePostgresUserPassword(cluster, &spec, existing, later.Add(time.Hour)))
+		assert.Equal(t, cluster.Status.Users[0].PreviousLogin, "app")
+		assert.Assert(t, !rotatePostgresUserPassword(cluster, &spec, existing, later.Add(2*time.Hour)))
+		assert.Equal(t, cluster.Status.Users[0].PreviousLogin, "")
+		assert.Equal(t, cluster.Status.Users[0].Login, "app-alt")
+	})
+
+	t.Run("Annotation", func(t *testing.T) {
+		cluster := v1beta1.NewPostgresCluster()
+		existing := existing.DeepCopy()
+		existing.Annotations = map[string]string{naming.PostgresUserRotatePassword: "one"}
+
+		// An annotation present when tracking starts does not rotate.
+		assert.Assert(t, !rotatePostgresUserPassword(cluster, &spec, existing, now))
+		assert.Equal(t, cluster.Status.Users[0].RotationTrigger, "one")
+
+		// A new value rotates once.
+		existing.Annotations[naming.PostgresUserRotatePassword] = "two"
+		assert.Assert(t, rotatePostgresUserPassword(cluster, &spec, existing, now))
+		assert.Equal(t, cluster.Status.Users[0].RotationTrigger, "two")
+		assert.Equal(t, cluster.Status.Users[0].Login, "app-alt")
+		assert.Assert(t, !rotatePostgresUserPassword(cluster, &spec, existing, now))
+
+		// Another value waits for the grace period to end.
+		existing.Annotations[naming.PostgresUserRotatePassword] = "three"
+		assert.Assert(t, !rotatePostgresUserPassword(cluster, &spec, existing, now.Add(time.Hour)))
+		assert.Assert(t, rotatePostgresUserPassword(cluster, &spec, existing, now.Add(2*time.Hour)))
+		assert.DeepEqual(t, cluster.Status.Users, []v1beta1.PostgresUserStatus{{
+			Name: "app", Login: "app", PreviousLogin: "app-alt", RotationTrigger: "three",
+			LastRotationTime: initialize.Pointer(metav1.NewTime(now.Add(2 * time.Hour))),
+		}})
+	})
+
+	t.Run("NoSecret", func(t *testing.T) {
+		cluster := v1beta1.NewPostgresCluster()
+		cluster.Status.Users = []v1beta1.PostgresUserStatus{{
+			Name: "app", Login: "app", LastRotationTime: initialize.Pointer(metav1.NewTime(now)),
+		}}
+
+		// There is no previous password to keep.
+		assert.Assert(t, rotatePostgresUserPassword(cluster, &spec, nil, now.Add(90*24*time.Hour)))
+		assert.Equal(t, cluster.Status.Users[0].Login, "app-alt")
+		assert.Equal(t, cluster.Status.Users[0].PreviousLogin, "")
+	})
+
+	t.Run("Removed", func(t *testing.T) {
+		cluster := v1beta1.NewPostgresCluster()
+		cluster.Status.Users = []v1beta1.PostgresUserStatus{{
+			Name: "app", Login: "app-alt", LastRotationTime: initialize.Pointer(metav1.NewTime(now)),
+		}}
+
+		// The user moves back to its own login role with a new password.
+		unspecified := &v1beta1.PostgresUserSpec{Name: "app"}
+		later := now.Add(time.Minute)
+		assert.Assert(t, rotatePostgresUserPassword(cluster, unspecified, existing, later))
+		assert.DeepEqual(t, cluster.Status.Users, []v1beta1.PostgresUserStatus{{
+			Name: "app", Login: "app", PreviousLogin: "app-alt",
+			LastRotationTime: initialize.Pointer(metav1.NewTime(later)),
+		}})
+
+		// The alternate keeps its password for the default grace period.
+		assert.Assert(t, !rotatePostgresUserPassword(cluster, unspecified, existing, later.Add(30*time.Minute)))
+		assert.Equal(t, cluster.Status.Users[0].PreviousLogin, "app-alt")
+
+		// The user is tracked until the alternate password is removed.
+		assert.Assert(t, !rotatePostgresUserPassword(cluster, unspecified, existing, later.Add(time.Hour)))
+		assert.Equal(t, len(cluster.Status.Users), 1)
+		assert.Equal(t, cluster.Status.Users[0].PreviousLogin, "")
+
+		assert.Assert(t, !rotatePostgresUserPassword(cluster, unspecified, existing, later.Add(time.Hour)))
+		assert.Equal(t, len(cluster.Status.Users), 0)
+	})
+}
+
+func TestPostgresUserRotationRequeue(t *testing.T) {
+	now := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	cluster := v1beta1.NewPostgresCluster()
+	assert.Equal(t, postgresUserRotationRequeue(cluster, now), time.Duration(0))
+
+	require.UnmarshalInto(t, &cluster.Spec.Users, `[
+		{ name: a, password: { type: ASCII, rotation: { interval: 10h } } },
+		{ name: b, password: { type: ASCII, rotation: { gracePeriod: 30m } } },
+	]`)
+	cluster.Status.Users = []v1beta1.PostgresUserStatus{
+		{Name: "a", LastRotationTime: initialize.Pointer(metav1.NewTime(now.Add(-5 * time.Hour)))},
+		{Name: "b", LastRotationTime: initialize.Pointer(metav1.NewTime(now))},
+	}
+	assert.Equal(t, postgresUserRotationRequeue(cluster, now), 5*time.Hour)
+
+	// The end of a grace period is sooner.
+	cluster.Status.Users[1].PreviousLogin = "b-alt"
+	assert.Equal(t, postgresUserRotationRequeue(cluster, now), 30*time.Minute)
+}
+
+func TestReconcilePostgresUsersInPostgreSQL(t *testing.T) {
+	ctx := context.Background()
+
+	var calls int
+	var stdout string
+
+	recorder := events.NewRecorder(t, runtime.Scheme)
+	r := &Reconciler{
+		Recorder: recorder,
+		PodExec: func(
+			ctx context.Context, namespace, pod, container string,
+			stdin io.Reader, out, stderr io.Writer, command ...string,
+		) error {
+			calls++
+			_, _ = io.WriteString(out, stdout)
+			return nil
+		},
+	}
+
+	observed := &observedInstances{forCluster: []*Instance{{
+		Pods: []*corev1.Pod{{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns1",
+				Name:      "pod",
+				Annotations: map[string]string{
+					"status": `{"role":"primary"}`,
+				},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: naming.ContainerDatabase,
+					State: corev1.ContainerState{
+						Running: new(corev1.ContainerStateRunning),
+					},
+				}},
+			},
+		}},
+		Runner: &appsv1.StatefulSet{},
+	}}}
+
+	t.Run("MissingSchemas", func(t *testing.T) {
+		cluster := testCluster()
+		require.UnmarshalInto(t, &cluster.Spec.Users, `[
+			{ name: reader, databases: [db1, db2], privileges: {
+				schemas: [
+					{ database: db1, schema: app, access: ReadOnly },
+					{ database: db2, schema: other, access: ReadOnly },
+				],
+			} },
+		]`)
+		secrets := map[string]*corev1.Secret{}
+
+		// Schemas that do not exist are reported and tried again shortly.
+		calls, stdout = 0, "db1.app\n"
+		requeue, err := r.reconcilePostgresUsersInPostgreSQL(ctx, cluster, observed, cluster.Spec.Users, secrets)
+		assert.NilError(t, err)
+		assert.Equal(t, requeue, time.Minute)
+		assert.Assert(t, calls > 0)
+		assert.Equal(t, cluster.Status.UsersRevision, "")
+
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, "SchemasNotFound")
+		assert.Assert(t, cmp.Contains(recorder.Events[0].Note, "db1.app"))
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionUsersReady)
+		if assert.Check(t, condition != nil) {
+			assert.Equal(t, condition.Status, metav1.ConditionFalse)
+			assert.Equal(t, condition.Reason, "SchemasNotFound")
+		}
+
+		// The same schemas are not reported again.
+		requeue, err = r.reconcilePostgresUsersInPostgreSQL(ctx, cluster, observed, cluster.Spec.Users, secrets)
+		assert.NilError(t, err)
+		assert.Equal(t, requeue, time.Minute)
+		assert.Equal(t, len(recorder.Events), 1)
+
+		// Different schemas are.
+		stdout = "db2.other\ndb1.app\n"
+		requeue, err = r.reconcilePostgresUsersInPostgreSQL(ctx, cluster, observed, cluster.Spec.Users, secrets)
+		assert.NilError(t, err)
+		assert.Equal(t, requeue, time.Minute)
+		assert.Equal(t, len(recorder.Events), 2)
+		assert.Assert(t, cmp.Contains(recorder.Events[1].Note, "db1.app, db2.other"))
+
+		// The users are done when every schema exists.
+		stdout = ""
+		requeue, err = r.reconcilePostgresUsersInPostgreSQL(ctx, cluster, observed, cluster.Spec.Users, secrets)
+		assert.NilError(t, err)
+		assert.Equal(t, requeue, time.Duration(0))
+		assert.Assert(t, cluster.Status.UsersRevision != "")
+		assert.Equal(t, len(recorder.Events), 2)
+
+		condition = meta.FindStatusCondition(cluster.Status.Conditions, ConditionUsersReady)
+		if assert.Check(t, condition != nil) {
+			assert.Equal(t, condition.Status, metav1.ConditionTrue)
+		}
+	})
+}
+
+func TestReconcilePostgresExtensions(t *testing.T) {
+	ctx := context.Background()
+
+	var calls [][]string
+	var stdouts []string
+	var failure error
+
+	recorder := events.NewRecorder(t, runtime.Scheme)
+	r := &Reconciler{
+		Recorder: recorder,
+		PodExec: func(
+			ctx context.Context, namespace, pod, container string,
+			stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			assert.Equal(t, namespace, "ns1")
+			assert.Equal(t, pod, "pod")
+			assert.Equal(t, container, naming.ContainerDatabase)
+
+			calls = append(calls, command)
+			if len(stdouts) > 0 {
+				_, _ = io.WriteString(stdout, stdouts[0])
+				stdouts = stdouts[1:]
+			}
+			return failure
+		},
+	}
+
+	observed := &observedInstances{forCluster: []*Instance{{
+		Pods: []*corev1.Pod{{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns1",
+				Name:      "pod",
+				Annotations: map[string]string{
+					"status": `{"role":"primary"}`,
+				},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: naming.ContainerDatabase,
+					State: corev1.ContainerState{
+						Running: new(corev1.ContainerStateRunning),
+					},
+				}},
+			},
+		}},
+		Runner: &appsv1.StatefulSet{},
+	}}}
+
+	t.Run("Unspecified", func(t *testing.T) {
+		calls = nil
+		cluster := testCluster()
+		cluster.Status.Extensions = []v1beta1.PostgresExtensionStatus{{Name: "any"}}
+		cluster.Status.ExtensionsRevision = "anything"
+
+		cluster.Status.Conditions = []metav1.Condition{{Type: ConditionExtensionsReady}}
+
+		requeue, err := r.reconcilePostgresExtensions(ctx, cluster, observed)
+		assert.NilError(t, err)
+		assert.Equal(t, requeue, time.Duration(0))
+		assert.Equal(t, len(calls), 0, "expected no exec")
+		assert.Assert(t, cluster.Status.Extensions == nil)
+		assert.Equal(t, cluster.Status.ExtensionsRevision, "")
+		assert.Assert(t, meta.FindStatusCondition(cluster.Status.Conditions, ConditionExtensionsReady) == nil)
+	})
+
+	t.Run("NoWritablePod", func(t *testing.T) {
+		calls = nil
+		cluster := testCluster()
+		cluster.Spec.Extensions = []v1beta1.PostgresExtensionSpec{{Name: "pg_cron"}}
+
+		_, err := r.reconcilePostgresExtensions(ctx, cluster, nil)
+		assert.NilError(t, err)
+		assert.Equal(t, len(calls), 0, "expected no exec")
+		assert.Equal(t, cluster.Status.ExtensionsRevision, "")
+	})
+
+	t.Run("Specified", func(t *testing.T) {
+		calls = nil
+		stdouts = []string{"", `{"database":"postgres","available":{"pg_cron":"1.6"},"installed":{"pg_cron":"1.6"}}`}
+
+		cluster := testCluster()
+		cluster.Spec.Extensions = []v1beta1.PostgresExtensionSpec{{Name: "pg_cron"}}
+
+		_, err := r.reconcilePostgresExtensions(ctx, cluster, observed)
+		assert.NilError(t, err)
+		assert.Equal(t, len(calls), 2, "expected write then read")
+		assert.Assert(t, cluster.Status.ExtensionsRevision != "")
+		assert.Assert(t, meta.IsStatusConditionTrue(cluster.Status.Conditions, ConditionExtensionsReady))
+		assert.DeepEqual(t, cluster.Status.Extensions, []v1beta1.PostgresExtensionStatus{{
+			Name:              "pg_cron",
+			AvailableVersion:  "1.6",
+			InstalledVersions: map[string]string{"postgres": "1.6"},
+		}})
+
+		t.Run("Unchanged", func(t *testing.T) {
+			calls = nil
+			_, err := r.reconcilePostgresExtensions(ctx, cluster, observed)
+			assert.NilError(t, err)
+			assert.Equal(t, len(calls), 0, "expected no exec")
+		})
+
+		t.Run("ImageChanged", func(t *testing.T) {
+			calls = nil
+			stdouts = []string{"", "{}"}
+			before := cluster.Status.ExtensionsRevision
+
+			cluster.Spec.Image = "different"
+			_, err := r.reconcilePostgresExtensions(ctx, cluster, observed)
+			assert.NilError(t, err)
+			assert.Equal(t, len(calls), 2, "expected write then read")
+			assert.Assert(t, cluster.Status.ExtensionsRevision != before)
+		})
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		calls = nil
+		recorder.Events = nil
+		failure = errors.New("boom")
+		t.Cleanup(func() { failure = nil })
+
+		cluster := testCluster()
+		cluster.Spec.Extensions = []v1beta1.PostgresExtensionSpec{{Name: "timescaledb"}}
+
+		requeue, err := r.reconcilePostgresExtensions(ctx, cluster, observed)
+		assert.NilError(t, err, "expected other reconcilers to continue")
+		assert.Equal(t, requeue, extensionsRetryInterval, "expected to try again")
+		assert.Equal(t, len(calls), 1, "expected only write")
+		assert.Equal(t, cluster.Status.ExtensionsRevision, "")
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionExtensionsReady)
+		assert.Assert(t, condition != nil)
+		assert.Equal(t, condition.Status, metav1.ConditionFalse)
+		assert.Equal(t, condition.Reason, "ExtensionsNotWritten")
+
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, "ExtensionsNotWritten")
+	})
+}
+
 func TestReconcileDatabaseInitSQL(t *testing.T) {
 	ctx := context.Background()
 	var called bool
@@ -6,6 +6,7 @@ package postgrescluster
 
 import (
 	"context"
+	"slices"
 
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -13,8 +14,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/crunchydata/postgres-operator/internal/certmanager"
 	"github.com/crunchydata/postgres-operator/internal/naming"
 	"github.com/crunchydata/postgres-operator/internal/patroni"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
 // watchPods returns a handler.EventHandler for Pods.
@@ -74,3 +77,55 @@ func (*Reconciler) watchPods() handler.Funcs {
 		},
 	}
 }
+
+// +kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="postgresclusters",verbs={list}
+
+// findPostgresClustersForSecret returns PostgresClusters that have users or
+// repositories whose passwords are in secret or that asked cert-manager to
+// issue secret. Every PostgresCluster in the namespace shares the root
+// certificate authority, so all of them are returned when secret is its.
+func (r *Reconciler) findPostgresClustersForSecret(
+	ctx context.Context, secret client.ObjectKey,
+) []*v1beta1.PostgresCluster {
+	var matching []*v1beta1.PostgresCluster
+	var clusters v1beta1.PostgresClusterList
+
+	// NOTE: If this becomes slow due to a large number of clusters in a single
+	// namespace, we can configure the [manager.Manager] field indexer and pass a
+	// [fields.Selector] here.
+	// - https://book.kubebuilder.io/reference/watching-resources/externally-managed.html
+	if r.Client.List(ctx, &clusters, &client.ListOptions{
+		Namespace: secret.Namespace,
+	}) == nil {
+		for i := range clusters.Items {
+			if secret.Name == naming.RootCertSecret {
+				matching = append(matching, &clusters.Items[i])
+				continue
+			}
+			if certmanager.Requested(&clusters.Items[i]) && slices.Contains([]string{
+				naming.CertManagerClusterCertificate(&clusters.Items[i]).Name,
+				naming.CertManagerReplicationCertificate(&clusters.Items[i]).Name,
+				naming.CertManagerPGBouncerCertificate(&clusters.Items[i]).Name,
+			}, secret.Name) {
+				matching = append(matching, &clusters.Items[i])
+				continue
+			}
+			if slices.ContainsFunc(clusters.Items[i].Spec.Backups.PGBackRest.Repos,
+				func(repo v1beta1.PGBackRestRepo) bool {
+					return repo.Encryption != nil && repo.Encryption.Passphrase != nil &&
+						repo.Encryption.Passphrase.Name == secret.Name
+				}) {
+				matching = append(matching, &clusters.Items[i])
+				continue
+			}
+			for _, user := range clusters.Items[i].Spec.Users {
+				if user.Password != nil && user.Password.SecretKeyRef != nil &&
+					user.Password.SecretKeyRef.Name == secret.Name {
+					matching = append(matching, &clusters.Items[i])
+					break
+				}
+			}
+		}
+	}
+	return matching
+}
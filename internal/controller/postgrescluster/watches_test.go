@@ -12,9 +12,16 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/internal/testing/require"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
 func TestWatchPodsUpdate(t *testing.T) {
@@ -182,3 +189,67 @@ func TestWatchPodsUpdate(t *testing.T) {
 	}, queue)
 	assert.Equal(t, queue.Len(), 1)
 }
+
+func TestFindPostgresClustersForSecret(t *testing.T) {
+	ctx := context.Background()
+
+	one := v1beta1.NewPostgresCluster()
+	one.Namespace, one.Name = "ns1", "one"
+	require.UnmarshalInto(t, &one.Spec.Users, `[
+		{ name: a },
+		{ name: b, password: { secretKeyRef: { name: shared, key: b } } },
+	]`)
+
+	two := v1beta1.NewPostgresCluster()
+	two.Namespace, two.Name = "ns1", "two"
+	require.UnmarshalInto(t, &two.Spec.Users, `[
+		{ name: c, password: { secretKeyRef: { name: other, key: c } } },
+		{ name: d, password: { secretKeyRef: { name: shared, key: d } } },
+	]`)
+	require.UnmarshalInto(t, &two.Spec.Backups.PGBackRest.Repos, `[
+		{ name: repo1, encryption: { passphrase: { name: cipher, key: pass } } },
+	]`)
+
+	elsewhere := v1beta1.NewPostgresCluster()
+	elsewhere.Namespace, elsewhere.Name = "ns2", "three"
+	elsewhere.Spec.Users = two.Spec.Users
+	elsewhere.Spec.CertManager = &v1beta1.CertManagerSpec{
+		IssuerRef: v1beta1.CertManagerIssuerReference{Name: "ca"},
+	}
+
+	reconciler := &Reconciler{
+		Client: fake.NewClientBuilder().
+			WithScheme(runtime.Scheme).
+			WithObjects(one, two, elsewhere).
+			Build(),
+	}
+
+	names := func(clusters []*v1beta1.PostgresCluster) []string {
+		var result []string
+		for _, cluster := range clusters {
+			result = append(result, cluster.Name)
+		}
+		return result
+	}
+
+	assert.DeepEqual(t, names(reconciler.findPostgresClustersForSecret(ctx,
+		client.ObjectKey{Namespace: "ns1", Name: "shared"})), []string{"one", "two"})
+	assert.DeepEqual(t, names(reconciler.findPostgresClustersForSecret(ctx,
+		client.ObjectKey{Namespace: "ns1", Name: "other"})), []string{"two"})
+	assert.DeepEqual(t, names(reconciler.findPostgresClustersForSecret(ctx,
+		client.ObjectKey{Namespace: "ns1", Name: "cipher"})), []string{"two"})
+	assert.Assert(t, reconciler.findPostgresClustersForSecret(ctx,
+		client.ObjectKey{Namespace: "ns1", Name: "nope"}) == nil)
+
+	// Certificates issued by cert-manager belong to clusters that asked for them.
+	assert.DeepEqual(t, names(reconciler.findPostgresClustersForSecret(ctx,
+		client.ObjectKey{Namespace: "ns2", Name: "three-cluster-tls"})), []string{"three"})
+	assert.DeepEqual(t, names(reconciler.findPostgresClustersForSecret(ctx,
+		client.ObjectKey{Namespace: "ns2", Name: "three-pgbouncer-tls"})), []string{"three"})
+	assert.Assert(t, reconciler.findPostgresClustersForSecret(ctx,
+		client.ObjectKey{Namespace: "ns1", Name: "one-cluster-tls"}) == nil)
+
+	// The root certificate authority belongs to every cluster in the namespace.
+	assert.DeepEqual(t, names(reconciler.findPostgresClustersForSecret(ctx,
+		client.ObjectKey{Namespace: "ns1", Name: naming.RootCertSecret})), []string{"one", "two"})
+}
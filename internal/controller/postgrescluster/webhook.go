@@ -0,0 +1,269 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgrescluster
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+// Webhook defaults and validates PostgresCluster objects as they are written
+// to the Kubernetes API. It checks relationships between fields that cannot
+// be expressed with OpenAPI or CEL validation rules.
+type Webhook struct{}
+
+var (
+	_ admission.CustomDefaulter = (*Webhook)(nil)
+	_ admission.CustomValidator = (*Webhook)(nil)
+)
+
+// The paths below are generated by controller-runtime based on the GroupVersionKind.
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime/pkg/builder#WebhookBuilder
+//
+// +kubebuilder:webhook:path=/mutate-postgres-operator-crunchydata-com-v1beta1-postgrescluster,mutating=true,failurePolicy=fail,sideEffects=None,groups=postgres-operator.crunchydata.com,resources=postgresclusters,verbs=create;update,versions=v1beta1,name=mpostgrescluster.postgres-operator.crunchydata.com,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-postgres-operator-crunchydata-com-v1beta1-postgrescluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=postgres-operator.crunchydata.com,resources=postgresclusters,verbs=create;update,versions=v1beta1,name=vpostgrescluster.postgres-operator.crunchydata.com,admissionReviewVersions=v1
+
+// SetupWithManager registers the PostgresCluster webhooks with the webhook
+// server of mgr.
+func (w *Webhook) SetupWithManager(mgr manager.Manager) error {
+	return builder.WebhookManagedBy(mgr).
+		For(&v1beta1.PostgresCluster{}).
+		WithDefaulter(w).
+		WithValidator(w).
+		Complete()
+}
+
+// Default implements [admission.CustomDefaulter] by calling the same Default
+// method that the controller calls before every reconcile.
+func (*Webhook) Default(ctx context.Context, object runtime.Object) error {
+	cluster, ok := object.(*v1beta1.PostgresCluster)
+	if !ok {
+		return fmt.Errorf("expected a PostgresCluster but got %T", object)
+	}
+
+	cluster.Default()
+	return nil
+}
+
+// ValidateCreate implements [admission.CustomValidator].
+func (w *Webhook) ValidateCreate(
+	ctx context.Context, object runtime.Object,
+) (admission.Warnings, error) {
+	return w.validate(nil, object)
+}
+
+// ValidateUpdate implements [admission.CustomValidator]. Updates that leave the
+// spec unchanged, such as removing a finalizer, are always allowed.
+func (w *Webhook) ValidateUpdate(
+	ctx context.Context, oldObject, object runtime.Object,
+) (admission.Warnings, error) {
+	cluster, ok := object.(*v1beta1.PostgresCluster)
+	if !ok {
+		return nil, fmt.Errorf("expected a PostgresCluster but got %T", object)
+	}
+	previous, ok := oldObject.(*v1beta1.PostgresCluster)
+	if !ok {
+		return nil, fmt.Errorf("expected a PostgresCluster but got %T", oldObject)
+	}
+
+	if cluster.DeletionTimestamp != nil || equality.Semantic.DeepEqual(previous.Spec, cluster.Spec) {
+		return nil, nil
+	}
+	return w.validate(previous, cluster)
+}
+
+// ValidateDelete implements [admission.CustomValidator]. Deletion is always allowed.
+func (*Webhook) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate checks object and returns any problems as an error. Problems that
+// previous already had are returned as warnings instead so that clusters
+// written before a rule existed can still be changed.
+func (*Webhook) validate(previous *v1beta1.PostgresCluster, object runtime.Object) (admission.Warnings, error) {
+	cluster, ok := object.(*v1beta1.PostgresCluster)
+	if !ok {
+		return nil, fmt.Errorf("expected a PostgresCluster but got %T", object)
+	}
+
+	// Validate a defaulted copy so that generated values, like instance set
+	// names, are checked the same way the controller will see them.
+	defaulted := cluster.DeepCopy()
+	defaulted.Default()
+	errs := validatePostgresCluster(defaulted)
+
+	var warnings admission.Warnings
+	if previous != nil && len(errs) > 0 {
+		defaulted = previous.DeepCopy()
+		defaulted.Default()
+
+		existing := sets.New[string]()
+		for _, err := range validatePostgresCluster(defaulted) {
+			existing.Insert(err.Error())
+		}
+
+		errs = slices.DeleteFunc(errs, func(err *field.Error) bool {
+			if existing.Has(err.Error()) {
+				warnings = append(warnings, err.Error())
+				return true
+			}
+			return false
+		})
+	}
+
+	if len(errs) > 0 {
+		return warnings, apierrors.NewInvalid(
+			v1beta1.GroupVersion.WithKind("PostgresCluster").GroupKind(),
+			cluster.Name, errs)
+	}
+	return warnings, nil
+}
+
+// validatePostgresCluster returns any problems with the relationships between
+// fields of cluster. It expects cluster to have its defaults set.
+func validatePostgresCluster(cluster *v1beta1.PostgresCluster) field.ErrorList {
+	var errs field.ErrorList
+	spec := field.NewPath("spec")
+
+	// Each instance set needs a distinct name, including those assigned by
+	// [v1beta1.PostgresInstanceSetSpec.Default].
+	instanceSets := sets.New[string]()
+	for i := range cluster.Spec.InstanceSets {
+		name := cluster.Spec.InstanceSets[i].Name
+		if instanceSets.Has(name) {
+			errs = append(errs, field.Duplicate(
+				spec.Child("instances").Index(i).Child("name"), name))
+		}
+		instanceSets.Insert(name)
+	}
+
+	// Collect the names of the pgBackRest repositories that can be referenced.
+	repos := sets.New[string]()
+	for _, repo := range cluster.Spec.Backups.PGBackRest.Repos {
+		repos.Insert(repo.Name)
+	}
+
+	if standby := cluster.Spec.Standby; standby != nil && standby.Enabled {
+		path := spec.Child("standby")
+
+		if standby.Host == "" && standby.RepoName == "" {
+			errs = append(errs, field.Required(path,
+				"standby requires a host or repoName to be enabled"))
+		}
+		if standby.RepoName != "" && !repos.Has(standby.RepoName) {
+			errs = append(errs, field.NotFound(
+				path.Child("repoName"), standby.RepoName))
+		}
+	}
+
+	if manual := cluster.Spec.Backups.PGBackRest.Manual; manual != nil {
+		if !repos.Has(manual.RepoName) {
+			errs = append(errs, field.NotFound(
+				spec.Child("backups", "pgbackrest", "manual", "repoName"), manual.RepoName))
+		}
+	}
+
+	// An in-place restore that does not name another cluster reads from the
+	// repositories of this cluster.
+	if restore := cluster.Spec.Backups.PGBackRest.Restore; restore != nil &&
+		restore.Enabled != nil && *restore.Enabled &&
+		restore.PostgresClusterDataSource != nil &&
+		(restore.ClusterName == "" || restore.ClusterName == cluster.Name) &&
+		(restore.ClusterNamespace == "" || restore.ClusterNamespace == cluster.Namespace) {
+		if !repos.Has(restore.RepoName) {
+			errs = append(errs, field.NotFound(
+				spec.Child("backups", "pgbackrest", "restore", "repoName"), restore.RepoName))
+		}
+	}
+
+	// Client certificates come from the operator's certificate authority,
+	// which PostgreSQL does not trust when cert-manager issues its certificates.
+	if cluster.Spec.CertManager != nil {
+		for i := range cluster.Spec.Users {
+			if initialize.FromPointer(cluster.Spec.Users[i].ClientCertificate) {
+				errs = append(errs, field.Forbidden(
+					spec.Child("users").Index(i).Child("clientCertificate"),
+					"client certificates cannot be issued when certManager is set"))
+			}
+		}
+	}
+
+	// Kubernetes rejects CronJobs with time zones that are not in the IANA
+	// database, or that depend on its own time zone.
+	// - https://docs.k8s.io/concepts/workloads/controllers/cron-jobs/#time-zones
+	if zone := backupScheduleTimeZone(cluster); zone != nil {
+		if _, err := time.LoadLocation(*zone); err != nil || strings.EqualFold(*zone, "Local") {
+			errs = append(errs, field.Invalid(
+				spec.Child("backups", "pgbackrest", "scheduling", "timeZone"), *zone,
+				"must be a time zone in the IANA database"))
+		}
+	}
+
+	if major, ok := imageMajorVersion(cluster.Spec.Image); ok &&
+		major != cluster.Spec.PostgresVersion {
+		errs = append(errs, field.Invalid(spec.Child("image"), cluster.Spec.Image,
+			fmt.Sprintf("image appears to contain PostgreSQL %d but postgresVersion is %d",
+				major, cluster.Spec.PostgresVersion)))
+	}
+
+	return errs
+}
+
+// regexImageVersion matches a part of an image tag that looks like a version,
+// capturing its first number.
+var regexImageVersion = regexp.MustCompile(`^([0-9]+)(?:[.][0-9]+)*$`)
+
+// imageMajorVersion returns the PostgreSQL major version found in the tag of
+// image, if any. It understands tags like "16", "16.4-alpine", and the
+// "ubi9-16.9-2520" tags of Crunchy Data images. It returns false when the tag
+// is absent or does not appear to contain a PostgreSQL version.
+func imageMajorVersion(image string) (int, bool) {
+	// Ignore any digest; it says nothing about the version.
+	image, _, _ = strings.Cut(image, "@")
+
+	// The tag follows the last colon, but only when that colon is after the
+	// last slash. Otherwise, the colon is part of a registry host and port.
+	i := strings.LastIndex(image, ":")
+	if i < 0 || i < strings.LastIndex(image, "/") {
+		return 0, false
+	}
+
+	// The first dash-separated part of the tag that looks like a version
+	// determines the major version; e.g. "ubi9" and "2520" do not qualify
+	// in "ubi9-16.9-2520" because only the second part contains a dot.
+	parts := strings.Split(image[i+1:], "-")
+	for j, part := range parts {
+		if m := regexImageVersion.FindStringSubmatch(part); m != nil &&
+			(j == 0 || strings.Contains(part, ".")) {
+			major, err := strconv.Atoi(m[1])
+
+			// PostgreSQL 10 and later have a single number major version.
+			// Anything else is probably not a PostgreSQL version.
+			if err != nil || major < 10 || major > 99 {
+				return 0, false
+			}
+			return major, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,351 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgrescluster
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/internal/testing/require"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+func TestWebhookDefault(t *testing.T) {
+	ctx := context.Background()
+	webhook := &Webhook{}
+
+	t.Run("WrongType", func(t *testing.T) {
+		assert.ErrorContains(t, webhook.Default(ctx, &corev1.ConfigMap{}), "PostgresCluster")
+	})
+
+	cluster := new(v1beta1.PostgresCluster)
+	require.UnmarshalInto(t, &cluster.Spec, `{
+		postgresVersion: 16,
+		instances: [{ dataVolumeClaimSpec: {} }, { name: two, dataVolumeClaimSpec: {} }],
+	}`)
+
+	assert.NilError(t, webhook.Default(ctx, cluster))
+	assert.Equal(t, cluster.Kind, "PostgresCluster")
+	assert.Equal(t, cluster.Spec.InstanceSets[0].Name, "00")
+	assert.Equal(t, cluster.Spec.InstanceSets[1].Name, "two")
+	assert.Equal(t, *cluster.Spec.InstanceSets[0].Replicas, int32(1))
+	assert.Equal(t, *cluster.Spec.Port, int32(5432))
+}
+
+func TestWebhookValidate(t *testing.T) {
+	ctx := context.Background()
+	webhook := &Webhook{}
+
+	t.Run("WrongType", func(t *testing.T) {
+		_, err := webhook.ValidateCreate(ctx, &corev1.ConfigMap{})
+		assert.ErrorContains(t, err, "PostgresCluster")
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cluster := new(v1beta1.PostgresCluster)
+		cluster.Name = "hippo"
+		require.UnmarshalInto(t, &cluster.Spec, `{
+			postgresVersion: 16,
+			instances: [{ dataVolumeClaimSpec: {} }],
+			backups: { pgbackrest: { repos: [{ name: repo1 }] } },
+		}`)
+
+		warnings, err := webhook.ValidateCreate(ctx, cluster)
+		assert.NilError(t, err)
+		assert.Assert(t, warnings == nil)
+
+		warnings, err = webhook.ValidateUpdate(ctx, cluster, cluster)
+		assert.NilError(t, err)
+		assert.Assert(t, warnings == nil)
+
+		// The object is not mutated during validation.
+		assert.Equal(t, cluster.Spec.InstanceSets[0].Name, "")
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		cluster := new(v1beta1.PostgresCluster)
+		cluster.Name = "hippo"
+		require.UnmarshalInto(t, &cluster.Spec, `{
+			postgresVersion: 16,
+			instances: [{ dataVolumeClaimSpec: {} }, { name: "00", dataVolumeClaimSpec: {} }],
+		}`)
+
+		_, err := webhook.ValidateCreate(ctx, cluster)
+		assert.Assert(t, apierrors.IsInvalid(err), "got %#v", err)
+		assert.ErrorContains(t, err, "spec.instances[1].name")
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		previous := new(v1beta1.PostgresCluster)
+		previous.Name = "hippo"
+		require.UnmarshalInto(t, &previous.Spec, `{
+			postgresVersion: 16,
+			image: example.com/postgres:15.8,
+			instances: [{ dataVolumeClaimSpec: {} }],
+			backups: { pgbackrest: { repos: [{ name: repo1 }] } },
+		}`)
+
+		// Changes are allowed despite problems the cluster already had.
+		cluster := previous.DeepCopy()
+		cluster.Spec.Port = initialize.Int32(5433)
+
+		warnings, err := webhook.ValidateUpdate(ctx, previous, cluster)
+		assert.NilError(t, err)
+		assert.Equal(t, len(warnings), 1)
+		assert.Assert(t, cmp.Contains(warnings[0], "spec.image"))
+
+		// New problems are rejected.
+		cluster.Spec.Backups.PGBackRest.Manual = &v1beta1.PGBackRestManualBackup{RepoName: "repo2"}
+
+		_, err = webhook.ValidateUpdate(ctx, previous, cluster)
+		assert.Assert(t, apierrors.IsInvalid(err), "got %#v", err)
+		assert.ErrorContains(t, err, "spec.backups.pgbackrest.manual.repoName")
+		assert.Assert(t, !strings.Contains(err.Error(), "spec.image"))
+
+		// Changes to metadata and deletions are not validated.
+		cluster = previous.DeepCopy()
+		cluster.Finalizers = []string{"some-finalizer"}
+
+		warnings, err = webhook.ValidateUpdate(ctx, previous, cluster)
+		assert.NilError(t, err)
+		assert.Assert(t, warnings == nil)
+
+		cluster = previous.DeepCopy()
+		cluster.Spec.Backups.PGBackRest.Manual = &v1beta1.PGBackRestManualBackup{RepoName: "repo2"}
+		cluster.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+		_, err = webhook.ValidateUpdate(ctx, previous, cluster)
+		assert.NilError(t, err)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		warnings, err := webhook.ValidateDelete(ctx, new(v1beta1.PostgresCluster))
+		assert.NilError(t, err)
+		assert.Assert(t, warnings == nil)
+	})
+}
+
+func TestValidatePostgresCluster(t *testing.T) {
+	for _, tt := range []struct {
+		name, spec string
+		expected   []string
+	}{
+		{
+			name: "Minimal",
+			spec: `{
+				postgresVersion: 16,
+				instances: [{ name: "00" }],
+			}`,
+		},
+		{
+			name: "InstanceSetNames",
+			spec: `{
+				postgresVersion: 16,
+				instances: [{ name: "00" }, { name: one }, { name: "00" }, { name: one }],
+			}`,
+			expected: []string{
+				`spec.instances[2].name: Duplicate value: "00"`,
+				`spec.instances[3].name: Duplicate value: "one"`,
+			},
+		},
+		{
+			name: "StandbyRequiresSource",
+			spec: `{
+				postgresVersion: 16,
+				instances: [{ name: "00" }],
+				standby: { enabled: true },
+			}`,
+			expected: []string{
+				`spec.standby: Required value: standby requires a host or repoName to be enabled`,
+			},
+		},
+		{
+			name: "StandbyRepoName",
+			spec: `{
+				postgresVersion: 16,
+				instances: [{ name: "00" }],
+				backups: { pgbackrest: { repos: [{ name: repo1 }] } },
+				standby: { enabled: true, repoName: repo2 },
+			}`,
+			expected: []string{
+				`spec.standby.repoName: Not found: "repo2"`,
+			},
+		},
+		{
+			name: "StandbyDisabled",
+			spec: `{
+				postgresVersion: 16,
+				instances: [{ name: "00" }],
+				standby: { enabled: false, repoName: repo2 },
+			}`,
+		},
+		{
+			name: "ManualRepoName",
+			spec: `{
+				postgresVersion: 16,
+				instances: [{ name: "00" }],
+				backups: { pgbackrest: {
+					repos: [{ name: repo1 }, { name: repo2 }],
+					manual: { repoName: repo4 },
+				} },
+			}`,
+			expected: []string{
+				`spec.backups.pgbackrest.manual.repoName: Not found: "repo4"`,
+			},
+		},
+		{
+			name: "RestoreRepoName",
+			spec: `{
+				postgresVersion: 16,
+				instances: [{ name: "00" }],
+				backups: { pgbackrest: {
+					repos: [{ name: repo1 }],
+					restore: { enabled: true, repoName: repo3 },
+				} },
+			}`,
+			expected: []string{
+				`spec.backups.pgbackrest.restore.repoName: Not found: "repo3"`,
+			},
+		},
+		{
+			name: "RestoreDisabled",
+			spec: `{
+				postgresVersion: 16,
+				instances: [{ name: "00" }],
+				backups: { pgbackrest: {
+					repos: [{ name: repo1 }],
+					restore: { enabled: false, repoName: repo3 },
+				} },
+			}`,
+		},
+		{
+			name: "RestoreFromAnotherCluster",
+			spec: `{
+				postgresVersion: 16,
+				instances: [{ name: "00" }],
+				backups: { pgbackrest: {
+					repos: [{ name: repo1 }],
+					restore: { enabled: true, repoName: repo3, clusterName: other },
+				} },
+			}`,
+		},
+		{
+			name: "TimeZone",
+			spec: `{
+				postgresVersion: 16,
+				instances: [{ name: "00" }],
+				backups: { pgbackrest: {
+					repos: [{ name: repo1 }],
+					scheduling: { timeZone: America/New_York },
+				} },
+			}`,
+		},
+		{
+			name: "TimeZoneUnknown",
+			spec: `{
+				postgresVersion: 16,
+				instances: [{ name: "00" }],
+				backups: { pgbackrest: {
+					repos: [{ name: repo1 }],
+					scheduling: { timeZone: Mars/Olympus_Mons },
+				} },
+			}`,
+			expected: []string{
+				`spec.backups.pgbackrest.scheduling.timeZone: Invalid value: "Mars/Olympus_Mons": must be a time zone in the IANA database`,
+			},
+		},
+		{
+			name: "TimeZoneLocal",
+			spec: `{
+				postgresVersion: 16,
+				instances: [{ name: "00" }],
+				backups: { pgbackrest: {
+					repos: [{ name: repo1 }],
+					scheduling: { timeZone: Local },
+				} },
+			}`,
+			expected: []string{
+				`spec.backups.pgbackrest.scheduling.timeZone: Invalid value: "Local": must be a time zone in the IANA database`,
+			},
+		},
+		{
+			name: "ClientCertificateWithCertManager",
+			spec: `{
+				postgresVersion: 16,
+				instances: [{ name: "00" }],
+				backups: { pgbackrest: { repos: [{ name: repo1 }] } },
+				certManager: { issuerRef: { name: ca } },
+				users: [
+					{ name: app },
+					{ name: reader, clientCertificate: true },
+				],
+			}`,
+			expected: []string{
+				`spec.users[1].clientCertificate: Forbidden: client certificates cannot be issued when certManager is set`,
+			},
+		},
+		{
+			name: "ImageVersion",
+			spec: `{
+				postgresVersion: 16,
+				image: "example.com:5000/crunchy-postgres:ubi9-17.5-2520",
+				instances: [{ name: "00" }],
+			}`,
+			expected: []string{
+				`spec.image: Invalid value: "example.com:5000/crunchy-postgres:ubi9-17.5-2520": ` +
+					`image appears to contain PostgreSQL 17 but postgresVersion is 16`,
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := new(v1beta1.PostgresCluster)
+			cluster.Name = "hippo"
+			require.UnmarshalInto(t, &cluster.Spec, tt.spec)
+
+			errs := validatePostgresCluster(cluster)
+
+			var actual []string
+			for _, err := range errs {
+				actual = append(actual, err.Error())
+			}
+			assert.DeepEqual(t, actual, tt.expected)
+		})
+	}
+}
+
+func TestImageMajorVersion(t *testing.T) {
+	for _, tt := range []struct {
+		image    string
+		expected int
+		found    bool
+	}{
+		{image: ""},
+		{image: "postgres"},
+		{image: "postgres:latest"},
+		{image: "localhost:5000/postgres"},
+		{image: "postgres:16", expected: 16, found: true},
+		{image: "postgres:16.4-alpine", expected: 16, found: true},
+		{image: "docker.io/library/postgres:17@sha256:abc", expected: 17, found: true},
+		{image: "localhost:5000/postgres:15.8", expected: 15, found: true},
+		{image: "registry.developers.crunchydata.com/crunchydata/crunchy-postgres:ubi9-17.5-2520", expected: 17, found: true},
+		{image: "registry.developers.crunchydata.com/crunchydata/crunchy-postgres-gis:ubi9-16.9-3.4-2520", expected: 16, found: true},
+		{image: "example.com/postgres:2520"},
+		{image: "example.com/postgres:9.6"},
+		{image: "example.com/postgres:ubi9-2520"},
+	} {
+		major, found := imageMajorVersion(tt.image)
+		assert.Equal(t, found, tt.found, "image %q", tt.image)
+		assert.Equal(t, major, tt.expected, "image %q", tt.image)
+	}
+}
@@ -0,0 +1,283 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgresdatabase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/internal/logging"
+	"github.com/crunchydata/postgres-operator/internal/postgres"
+	"github.com/crunchydata/postgres-operator/internal/tracing"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+const (
+	// RefreshInterval is how often the status of a database is read from
+	// PostgreSQL when nothing else changes.
+	RefreshInterval = 5 * time.Minute
+
+	// retryInterval is how soon to try again when PostgreSQL is unavailable.
+	retryInterval = 10 * time.Second
+)
+
+// Reconciler reconciles PostgresDatabase objects
+type Reconciler struct {
+	Client  client.Client
+	Owner   client.FieldOwner
+	PodExec func(
+		ctx context.Context, namespace, pod, container string,
+		stdin io.Reader, stdout, stderr io.Writer, command ...string,
+	) error
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="postgresdatabases",verbs={list,watch}
+//+kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="postgresclusters",verbs={list,watch}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.PodExec == nil {
+		var err error
+		r.PodExec, err = runtime.NewPodExecutor(mgr.GetConfig())
+		if err != nil {
+			return err
+		}
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.PostgresDatabase{}).
+		Watches(
+			v1beta1.NewPostgresCluster(),
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, cluster client.Object) []ctrl.Request {
+				return runtime.Requests(r.findDatabasesForPostgresCluster(ctx, client.ObjectKeyFromObject(cluster))...)
+			}),
+		).
+		Complete(r)
+}
+
+//+kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="postgresdatabases",verbs={list}
+
+// findDatabasesForPostgresCluster returns PostgresDatabases that target cluster.
+func (r *Reconciler) findDatabasesForPostgresCluster(
+	ctx context.Context, cluster client.ObjectKey,
+) []*v1beta1.PostgresDatabase {
+	var matching []*v1beta1.PostgresDatabase
+	var databases v1beta1.PostgresDatabaseList
+
+	// NOTE: If this becomes slow due to a large number of databases in a single
+	// namespace, we can configure the [ctrl.Manager] field indexer and pass a
+	// [fields.Selector] here.
+	// - https://book.kubebuilder.io/reference/watching-resources/externally-managed.html
+	if r.Client.List(ctx, &databases, &client.ListOptions{
+		Namespace: cluster.Namespace,
+	}) == nil {
+		for i := range databases.Items {
+			if databases.Items[i].Spec.ClusterName == cluster.Name {
+				matching = append(matching, &databases.Items[i])
+			}
+		}
+	}
+	return matching
+}
+
+//+kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="postgresdatabases",verbs={list}
+
+// findOlderDatabase returns the oldest PostgresDatabase that was created before
+// database and targets the same database in the same cluster, if any.
+func (r *Reconciler) findOlderDatabase(
+	ctx context.Context, database *v1beta1.PostgresDatabase,
+) (*v1beta1.PostgresDatabase, error) {
+	var oldest *v1beta1.PostgresDatabase
+
+	older := func(a, b *v1beta1.PostgresDatabase) bool {
+		if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+			return a.CreationTimestamp.Before(&b.CreationTimestamp)
+		}
+		return a.Name < b.Name
+	}
+
+	var databases v1beta1.PostgresDatabaseList
+	err := r.Client.List(ctx, &databases, &client.ListOptions{
+		Namespace: database.Namespace,
+	})
+
+	for i := range databases.Items {
+		other := &databases.Items[i]
+		other.Default()
+
+		if other.Name != database.Name &&
+			other.Spec.ClusterName == database.Spec.ClusterName &&
+			other.Spec.Name == database.Spec.Name &&
+			older(other, database) && (oldest == nil || older(other, oldest)) {
+			oldest = other
+		}
+	}
+	return oldest, errors.WithStack(err)
+}
+
+//+kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="postgresdatabases",verbs={get}
+//+kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="postgresdatabases/status",verbs={patch}
+//+kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="postgresclusters",verbs={get}
+
+// Reconcile does the work to move the current state of the world toward the
+// desired state described in a [v1beta1.PostgresDatabase] identified by req.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "reconcile-postgresdatabase")
+	log := logging.FromContext(ctx)
+	defer span.End()
+	defer func(s tracing.Span) { _ = tracing.Escape(s, err) }(span)
+
+	// Retrieve the database from the client cache, if it exists. A deferred
+	// function below will send any changes to its Status field.
+	//
+	// NOTE: No DeepCopy is necessary here because controller-runtime makes a
+	// copy before returning from its cache.
+	// - https://github.com/kubernetes-sigs/controller-runtime/issues/1235
+	database := &v1beta1.PostgresDatabase{}
+	err = r.Client.Get(ctx, req.NamespacedName, database)
+
+	if err == nil {
+		// Write any changes to the database status on the way out.
+		before := database.DeepCopy()
+		defer func() {
+			if !equality.Semantic.DeepEqual(before.Status, database.Status) {
+				status := r.Client.Status().Patch(ctx, database, client.MergeFrom(before), r.Owner)
+
+				if err == nil && status != nil {
+					err = status
+				} else if status != nil {
+					log.Error(status, "Patching PostgresDatabase status")
+				}
+			}
+		}()
+	} else {
+		// NotFound cannot be fixed by requeuing so ignore it.
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Set defaults if unset.
+	database.Default()
+
+	// Find the cluster that hosts the database. NotFound is handled below.
+	cluster := v1beta1.NewPostgresCluster()
+	err = client.IgnoreNotFound(r.Client.Get(ctx, client.ObjectKey{
+		Namespace: database.Namespace, Name: database.Spec.ClusterName,
+	}, cluster))
+	if err != nil {
+		return runtime.ErrorWithBackoff(err)
+	}
+	if cluster.UID == "" {
+		cluster = nil
+	}
+
+	var exec postgres.Executor
+	if cluster != nil {
+		exec, err = r.primaryExecutor(ctx, cluster)
+		if err != nil {
+			return runtime.ErrorWithBackoff(err)
+		}
+	}
+
+	if result, err := r.handleDelete(ctx, database, cluster, exec); err != nil || result != nil {
+		if err != nil {
+			return runtime.ErrorWithBackoff(err)
+		}
+		return *result, nil
+	}
+
+	// Only the oldest PostgresDatabase of a database in PostgreSQL manages it.
+	// We will reconcile again when the other is deleted or shortly after.
+	if other, err := r.findOlderDatabase(ctx, database); err != nil {
+		return runtime.ErrorWithBackoff(err)
+	} else if other != nil {
+		if condition := meta.FindStatusCondition(database.Status.Conditions,
+			v1beta1.PostgresDatabaseReady); condition == nil || condition.Reason != "DuplicateDatabase" {
+			r.Recorder.Eventf(database, corev1.EventTypeWarning, "DuplicateDatabase",
+				"PostgresDatabase %q already manages database %q in PostgresCluster %q",
+				other.Name, database.Spec.Name, database.Spec.ClusterName)
+		}
+		setReadyCondition(database, metav1.ConditionFalse, "DuplicateDatabase",
+			fmt.Sprintf("PostgresDatabase %q already manages database %q", other.Name, database.Spec.Name))
+		return runtime.RequeueWithoutBackoff(RefreshInterval), nil
+	}
+
+	// Report when the cluster is not ready. We will reconcile again when the
+	// cluster changes or shortly after.
+	switch {
+	case cluster == nil:
+		setReadyCondition(database, metav1.ConditionFalse, "ClusterNotFound",
+			fmt.Sprintf("PostgresCluster %q not found", database.Spec.ClusterName))
+		return ctrl.Result{}, nil
+
+	case exec == nil:
+		setReadyCondition(database, metav1.ConditionFalse, "PrimaryNotReady",
+			fmt.Sprintf("PostgresCluster %q has no writable instance", cluster.Name))
+		return runtime.RequeueWithoutBackoff(retryInterval), nil
+	}
+
+	ctx = logging.NewContext(ctx, log.WithValues("database", database.Spec.Name))
+
+	// Remember when the database is created so that it can be dropped later.
+	created, err := postgres.WriteDatabaseInPostgreSQL(ctx, exec, &database.Spec)
+	if created {
+		database.Status.Created = true
+	}
+	if err != nil {
+		r.Recorder.Event(database, corev1.EventTypeWarning, "DatabaseNotWritten",
+			"Unable to write database in PostgreSQL")
+		setReadyCondition(database, metav1.ConditionFalse, "DatabaseNotWritten",
+			"Unable to write database in PostgreSQL; check operator logs for details")
+		return runtime.ErrorWithBackoff(err)
+	}
+
+	exists, size, err := postgres.DatabaseSizeInPostgreSQL(ctx, exec, database.Spec.Name)
+	if err != nil {
+		return runtime.ErrorWithBackoff(err)
+	}
+
+	database.Status.Exists = initialize.Bool(exists)
+	database.Status.Size = resource.NewQuantity(size, resource.BinarySI)
+	database.Status.ObservedGeneration = database.Generation
+
+	if exists {
+		setReadyCondition(database, metav1.ConditionTrue, "DatabaseReady",
+			fmt.Sprintf("Database %q exists in PostgreSQL", database.Spec.Name))
+	} else {
+		setReadyCondition(database, metav1.ConditionFalse, "DatabaseNotFound",
+			fmt.Sprintf("Database %q not found in PostgreSQL", database.Spec.Name))
+	}
+
+	// Read the size of the database again later.
+	return runtime.RequeueWithoutBackoff(RefreshInterval), nil
+}
+
+// setReadyCondition sets the Ready condition of database.
+func setReadyCondition(
+	database *v1beta1.PostgresDatabase, status metav1.ConditionStatus, reason, message string,
+) {
+	meta.SetStatusCondition(&database.Status.Conditions, metav1.Condition{
+		ObservedGeneration: database.Generation,
+		Type:               v1beta1.PostgresDatabaseReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+	})
+}
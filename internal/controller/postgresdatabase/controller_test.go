@@ -0,0 +1,463 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgresdatabase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/internal/testing/events"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+// primaryPod returns a running PostgreSQL Pod that is the leader of cluster.
+func primaryPod(cluster *v1beta1.PostgresCluster) *corev1.Pod {
+	pod := new(corev1.Pod)
+	pod.Namespace = cluster.Namespace
+	pod.Name = cluster.Name + "-00-abcd-0"
+	pod.Labels = map[string]string{
+		naming.LabelCluster:  cluster.Name,
+		naming.LabelInstance: cluster.Name + "-00-abcd",
+		naming.LabelRole:     naming.RolePatroniLeader,
+	}
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:  naming.ContainerDatabase,
+		State: corev1.ContainerState{Running: new(corev1.ContainerStateRunning)},
+	}}
+	return pod
+}
+
+func newReconciler(t testing.TB, objects ...client.Object) *Reconciler {
+	return &Reconciler{
+		Client: fake.NewClientBuilder().
+			WithScheme(runtime.Scheme).
+			WithObjects(objects...).
+			WithStatusSubresource(&v1beta1.PostgresDatabase{}).
+			Build(),
+		Owner:    naming.ControllerPostgresDatabase,
+		Recorder: events.NewRecorder(t, runtime.Scheme),
+	}
+}
+
+func TestFindDatabasesForPostgresCluster(t *testing.T) {
+	ctx := context.Background()
+
+	one := v1beta1.NewPostgresDatabase()
+	one.Namespace, one.Name = "ns1", "one"
+	one.Spec.ClusterName = "hippo"
+
+	two := v1beta1.NewPostgresDatabase()
+	two.Namespace, two.Name = "ns1", "two"
+	two.Spec.ClusterName = "rhino"
+
+	three := v1beta1.NewPostgresDatabase()
+	three.Namespace, three.Name = "ns2", "three"
+	three.Spec.ClusterName = "hippo"
+
+	r := newReconciler(t, one, two, three)
+
+	found := r.findDatabasesForPostgresCluster(ctx, client.ObjectKey{Namespace: "ns1", Name: "hippo"})
+	assert.Equal(t, len(found), 1)
+	assert.Equal(t, found[0].Name, "one")
+
+	found = r.findDatabasesForPostgresCluster(ctx, client.ObjectKey{Namespace: "ns1", Name: "other"})
+	assert.Equal(t, len(found), 0)
+}
+
+func TestPrimaryExecutor(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := v1beta1.NewPostgresCluster()
+	cluster.Namespace, cluster.Name = "ns1", "hippo"
+
+	t.Run("NoPods", func(t *testing.T) {
+		exec, err := newReconciler(t).primaryExecutor(ctx, cluster)
+		assert.NilError(t, err)
+		assert.Assert(t, exec == nil)
+	})
+
+	t.Run("NotRunning", func(t *testing.T) {
+		pod := primaryPod(cluster)
+		pod.Status.ContainerStatuses = nil
+
+		exec, err := newReconciler(t, pod).primaryExecutor(ctx, cluster)
+		assert.NilError(t, err)
+		assert.Assert(t, exec == nil)
+	})
+
+	t.Run("Replica", func(t *testing.T) {
+		pod := primaryPod(cluster)
+		pod.Labels[naming.LabelRole] = naming.RolePatroniReplica
+
+		exec, err := newReconciler(t, pod).primaryExecutor(ctx, cluster)
+		assert.NilError(t, err)
+		assert.Assert(t, exec == nil)
+	})
+
+	t.Run("Standby", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Standby = &v1beta1.PostgresStandbySpec{Enabled: true}
+
+		exec, err := newReconciler(t, primaryPod(cluster)).primaryExecutor(ctx, cluster)
+		assert.NilError(t, err)
+		assert.Assert(t, exec == nil)
+	})
+
+	t.Run("Running", func(t *testing.T) {
+		r := newReconciler(t, primaryPod(cluster))
+
+		calls := 0
+		r.PodExec = func(
+			ctx context.Context, namespace, pod, container string,
+			stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			calls++
+			assert.Equal(t, namespace, "ns1")
+			assert.Equal(t, pod, "hippo-00-abcd-0")
+			assert.Equal(t, container, "database")
+			return nil
+		}
+
+		exec, err := r.primaryExecutor(ctx, cluster)
+		assert.NilError(t, err)
+		assert.Assert(t, exec != nil)
+		assert.NilError(t, exec(ctx, nil, nil, nil))
+		assert.Equal(t, calls, 1)
+	})
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := v1beta1.NewPostgresCluster()
+	cluster.Namespace, cluster.Name = "ns1", "hippo"
+	cluster.UID = "some-uid"
+
+	database := v1beta1.NewPostgresDatabase()
+	database.Namespace, database.Name = "ns1", "app"
+	database.Generation = 3
+	database.Spec.ClusterName = "hippo"
+
+	request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(database)}
+
+	t.Run("NotFound", func(t *testing.T) {
+		result, err := newReconciler(t).Reconcile(ctx, request)
+		assert.NilError(t, err)
+		assert.Assert(t, result.IsZero())
+	})
+
+	t.Run("ClusterNotFound", func(t *testing.T) {
+		r := newReconciler(t, database.DeepCopy())
+
+		result, err := r.Reconcile(ctx, request)
+		assert.NilError(t, err)
+		assert.Assert(t, result.IsZero())
+
+		var actual v1beta1.PostgresDatabase
+		assert.NilError(t, r.Client.Get(ctx, request.NamespacedName, &actual))
+
+		condition := meta.FindStatusCondition(actual.Status.Conditions, v1beta1.PostgresDatabaseReady)
+		assert.Assert(t, condition != nil)
+		assert.Equal(t, condition.Status, metav1.ConditionFalse)
+		assert.Equal(t, condition.Reason, "ClusterNotFound")
+		assert.Assert(t, actual.Status.Exists == nil)
+	})
+
+	t.Run("PrimaryNotReady", func(t *testing.T) {
+		r := newReconciler(t, database.DeepCopy(), cluster.DeepCopy())
+
+		result, err := r.Reconcile(ctx, request)
+		assert.NilError(t, err)
+		assert.Equal(t, result.RequeueAfter, 10*time.Second)
+
+		var actual v1beta1.PostgresDatabase
+		assert.NilError(t, r.Client.Get(ctx, request.NamespacedName, &actual))
+
+		condition := meta.FindStatusCondition(actual.Status.Conditions, v1beta1.PostgresDatabaseReady)
+		assert.Assert(t, condition != nil)
+		assert.Equal(t, condition.Status, metav1.ConditionFalse)
+		assert.Equal(t, condition.Reason, "PrimaryNotReady")
+	})
+
+	t.Run("Ready", func(t *testing.T) {
+		r := newReconciler(t, database.DeepCopy(), cluster.DeepCopy(), primaryPod(cluster))
+
+		var scripts []string
+		r.PodExec = func(
+			ctx context.Context, namespace, pod, container string,
+			stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			b, _ := io.ReadAll(stdin)
+			scripts = append(scripts, string(b))
+
+			assert.Assert(t, strings.Contains(strings.Join(command, " "), "--set=database=app"),
+				"expected the database name to default to the object name")
+
+			if strings.Contains(string(b), "CREATE DATABASE") {
+				_, _ = stdout.Write([]byte("t\n"))
+			}
+			if strings.Contains(string(b), "pg_database_size") {
+				_, _ = stdout.Write([]byte("8192\n"))
+			}
+			return nil
+		}
+
+		result, err := r.Reconcile(ctx, request)
+		assert.NilError(t, err)
+		assert.Equal(t, result.RequeueAfter, RefreshInterval)
+		assert.Equal(t, len(scripts), 2, "expected write then size")
+
+		var actual v1beta1.PostgresDatabase
+		assert.NilError(t, r.Client.Get(ctx, request.NamespacedName, &actual))
+		assert.Assert(t, actual.Status.Created, "expected the database to be created")
+		assert.Assert(t, actual.Status.Exists != nil && *actual.Status.Exists)
+		assert.Equal(t, actual.Status.Size.String(), "8Ki")
+		assert.Equal(t, actual.Status.ObservedGeneration, actual.Generation)
+
+		condition := meta.FindStatusCondition(actual.Status.Conditions, v1beta1.PostgresDatabaseReady)
+		assert.Assert(t, condition != nil)
+		assert.Equal(t, condition.Status, metav1.ConditionTrue)
+
+		// No finalizer when the database should be retained.
+		assert.Assert(t, len(actual.Finalizers) == 0)
+	})
+
+	t.Run("WriteFailed", func(t *testing.T) {
+		r := newReconciler(t, database.DeepCopy(), cluster.DeepCopy(), primaryPod(cluster))
+		r.PodExec = func(
+			context.Context, string, string, string,
+			io.Reader, io.Writer, io.Writer, ...string,
+		) error {
+			return errors.New("boom")
+		}
+
+		_, err := r.Reconcile(ctx, request)
+		assert.ErrorContains(t, err, "boom")
+
+		recorder := r.Recorder.(*events.Recorder)
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, "DatabaseNotWritten")
+
+		var actual v1beta1.PostgresDatabase
+		assert.NilError(t, r.Client.Get(ctx, request.NamespacedName, &actual))
+
+		condition := meta.FindStatusCondition(actual.Status.Conditions, v1beta1.PostgresDatabaseReady)
+		assert.Assert(t, condition != nil)
+		assert.Equal(t, condition.Reason, "DatabaseNotWritten")
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		now := time.Now()
+
+		older := v1beta1.NewPostgresDatabase()
+		older.Namespace, older.Name = "ns1", "first"
+		older.CreationTimestamp = metav1.NewTime(now.Add(-time.Hour))
+		older.Spec.ClusterName = "hippo"
+		older.Spec.Name = "app"
+
+		duplicate := database.DeepCopy()
+		duplicate.CreationTimestamp = metav1.NewTime(now)
+
+		r := newReconciler(t, duplicate, older, cluster.DeepCopy(), primaryPod(cluster))
+		r.PodExec = func(
+			context.Context, string, string, string,
+			io.Reader, io.Writer, io.Writer, ...string,
+		) error {
+			t.Fatal("expected no exec")
+			return nil
+		}
+
+		for range 2 {
+			result, err := r.Reconcile(ctx, request)
+			assert.NilError(t, err)
+			assert.Equal(t, result.RequeueAfter, RefreshInterval)
+		}
+
+		recorder := r.Recorder.(*events.Recorder)
+		assert.Equal(t, len(recorder.Events), 1, "expected one event")
+		assert.Equal(t, recorder.Events[0].Reason, "DuplicateDatabase")
+		assert.Assert(t, strings.Contains(recorder.Events[0].Note, `"first"`))
+
+		var actual v1beta1.PostgresDatabase
+		assert.NilError(t, r.Client.Get(ctx, request.NamespacedName, &actual))
+		assert.Assert(t, !actual.Status.Created)
+
+		condition := meta.FindStatusCondition(actual.Status.Conditions, v1beta1.PostgresDatabaseReady)
+		assert.Assert(t, condition != nil)
+		assert.Equal(t, condition.Status, metav1.ConditionFalse)
+		assert.Equal(t, condition.Reason, "DuplicateDatabase")
+
+		// The older PostgresDatabase is not a duplicate.
+		other, err := r.findOlderDatabase(ctx, older)
+		assert.NilError(t, err)
+		assert.Assert(t, other == nil)
+	})
+}
+
+func TestReconcileDelete(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := v1beta1.NewPostgresCluster()
+	cluster.Namespace, cluster.Name = "ns1", "hippo"
+	cluster.UID = "some-uid"
+
+	database := v1beta1.NewPostgresDatabase()
+	database.Namespace, database.Name = "ns1", "app"
+	database.Spec.ClusterName = "hippo"
+	database.Spec.DeletionPolicy = v1beta1.PostgresDatabaseDeletionPolicyDelete
+
+	request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(database)}
+
+	t.Run("AddsFinalizer", func(t *testing.T) {
+		r := newReconciler(t, database.DeepCopy())
+
+		_, err := r.Reconcile(ctx, request)
+		assert.NilError(t, err)
+
+		var actual v1beta1.PostgresDatabase
+		assert.NilError(t, r.Client.Get(ctx, request.NamespacedName, &actual))
+		assert.DeepEqual(t, actual.Finalizers, []string{naming.Finalizer})
+	})
+
+	t.Run("RemovesFinalizer", func(t *testing.T) {
+		retain := database.DeepCopy()
+		retain.Finalizers = []string{"other", naming.Finalizer}
+		retain.Spec.DeletionPolicy = v1beta1.PostgresDatabaseDeletionPolicyRetain
+		r := newReconciler(t, retain)
+
+		_, err := r.Reconcile(ctx, request)
+		assert.NilError(t, err)
+
+		var actual v1beta1.PostgresDatabase
+		assert.NilError(t, r.Client.Get(ctx, request.NamespacedName, &actual))
+		assert.DeepEqual(t, actual.Finalizers, []string{"other"})
+	})
+
+	t.Run("Drops", func(t *testing.T) {
+		deleting := database.DeepCopy()
+		deleting.Finalizers = []string{naming.Finalizer}
+		deleting.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		deleting.Status.Created = true
+		r := newReconciler(t, deleting, cluster.DeepCopy(), primaryPod(cluster))
+
+		var scripts []string
+		r.PodExec = func(
+			ctx context.Context, namespace, pod, container string,
+			stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			b, _ := io.ReadAll(stdin)
+			scripts = append(scripts, string(b))
+			return nil
+		}
+
+		result, err := r.Reconcile(ctx, request)
+		assert.NilError(t, err)
+		assert.Assert(t, result.IsZero())
+		assert.Equal(t, len(scripts), 1)
+		assert.Assert(t, strings.Contains(scripts[0], "DROP DATABASE"))
+
+		// The fake client deletes the object once its finalizers are gone.
+		var actual v1beta1.PostgresDatabase
+		err = r.Client.Get(ctx, request.NamespacedName, &actual)
+		assert.Assert(t, client.IgnoreNotFound(err) == nil && err != nil, "got %v", err)
+	})
+
+	t.Run("NotCreated", func(t *testing.T) {
+		deleting := database.DeepCopy()
+		deleting.Finalizers = []string{naming.Finalizer}
+		deleting.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		r := newReconciler(t, deleting, cluster.DeepCopy(), primaryPod(cluster))
+		r.PodExec = func(
+			context.Context, string, string, string,
+			io.Reader, io.Writer, io.Writer, ...string,
+		) error {
+			t.Fatal("expected no exec")
+			return nil
+		}
+
+		// The database existed before this PostgresDatabase; it is retained.
+		result, err := r.Reconcile(ctx, request)
+		assert.NilError(t, err)
+		assert.Assert(t, result.IsZero())
+
+		recorder := r.Recorder.(*events.Recorder)
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, "DatabaseNotDropped")
+		assert.Equal(t, recorder.Events[0].Type, corev1.EventTypeNormal)
+
+		var actual v1beta1.PostgresDatabase
+		err = r.Client.Get(ctx, request.NamespacedName, &actual)
+		assert.Assert(t, client.IgnoreNotFound(err) == nil && err != nil, "got %v", err)
+	})
+
+	t.Run("WaitsForPrimary", func(t *testing.T) {
+		deleting := database.DeepCopy()
+		deleting.Finalizers = []string{naming.Finalizer}
+		deleting.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		deleting.Status.Created = true
+		r := newReconciler(t, deleting, cluster.DeepCopy())
+
+		result, err := r.Reconcile(ctx, request)
+		assert.NilError(t, err)
+		assert.Equal(t, result.RequeueAfter, 10*time.Second)
+
+		var actual v1beta1.PostgresDatabase
+		assert.NilError(t, r.Client.Get(ctx, request.NamespacedName, &actual))
+		assert.DeepEqual(t, actual.Finalizers, []string{naming.Finalizer})
+	})
+
+	t.Run("Standby", func(t *testing.T) {
+		deleting := database.DeepCopy()
+		deleting.Finalizers = []string{naming.Finalizer}
+		deleting.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		deleting.Status.Created = true
+
+		standby := cluster.DeepCopy()
+		standby.Spec.Standby = &v1beta1.PostgresStandbySpec{Enabled: true}
+		r := newReconciler(t, deleting, standby)
+
+		// There is no primary to exec into, and the database is not dropped.
+		result, err := r.Reconcile(ctx, request)
+		assert.NilError(t, err)
+		assert.Assert(t, result.IsZero())
+
+		recorder := r.Recorder.(*events.Recorder)
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, "DatabaseNotDropped")
+
+		var actual v1beta1.PostgresDatabase
+		err = r.Client.Get(ctx, request.NamespacedName, &actual)
+		assert.Assert(t, client.IgnoreNotFound(err) == nil && err != nil, "got %v", err)
+	})
+
+	t.Run("ClusterGone", func(t *testing.T) {
+		deleting := database.DeepCopy()
+		deleting.Finalizers = []string{naming.Finalizer}
+		deleting.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		r := newReconciler(t, deleting)
+
+		result, err := r.Reconcile(ctx, request)
+		assert.NilError(t, err)
+		assert.Assert(t, result.IsZero())
+
+		var actual v1beta1.PostgresDatabase
+		err = r.Client.Get(ctx, request.NamespacedName, &actual)
+		assert.Assert(t, client.IgnoreNotFound(err) == nil && err != nil, "got %v", err)
+	})
+}
@@ -0,0 +1,114 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgresdatabase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/internal/postgres"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+// +kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="postgresdatabases",verbs={patch}
+
+// handleDelete sets a finalizer on database when its deletion policy is Delete
+// and drops the database from PostgreSQL when it is being deleted. Only a
+// database that database created in PostgreSQL is dropped. It returns
+// (nil, nil) when database is not being deleted. The caller is responsible for
+// returning other values to controller-runtime.
+func (r *Reconciler) handleDelete(
+	ctx context.Context, database *v1beta1.PostgresDatabase,
+	cluster *v1beta1.PostgresCluster, exec postgres.Executor,
+) (*reconcile.Result, error) {
+	finalizers := sets.New(database.Finalizers...)
+	drop := database.Spec.DeletionPolicy == v1beta1.PostgresDatabaseDeletionPolicyDelete
+
+	// The finalizer is necessary only when the database should be dropped.
+	// - https://docs.k8s.io/concepts/overview/working-with-objects/finalizers/
+
+	if database.DeletionTimestamp.IsZero() {
+		switch {
+		case drop && !finalizers.Has(naming.Finalizer):
+			return nil, r.patchFinalizers(ctx, database, finalizers.Insert(naming.Finalizer))
+		case !drop && finalizers.Has(naming.Finalizer):
+			return nil, r.patchFinalizers(ctx, database, finalizers.Delete(naming.Finalizer))
+		}
+
+		// The database is not being deleted. The caller can do what they like.
+		return nil, nil
+	}
+
+	if !finalizers.Has(naming.Finalizer) {
+		// The database is being deleted and there is no finalizer.
+		// The caller should listen for another event.
+		return &reconcile.Result{}, nil
+	}
+
+	// The database is being deleted and our finalizer is still set. Drop the
+	// database unless its cluster is gone or going away.
+	if drop && cluster != nil && cluster.DeletionTimestamp.IsZero() {
+		switch {
+		case !database.Status.Created:
+			// Only a database created by this PostgresDatabase is dropped. One
+			// that existed beforehand may belong to something else.
+			r.Recorder.Eventf(database, corev1.EventTypeNormal, "DatabaseNotDropped",
+				"Database %q was not created by this PostgresDatabase; it is retained",
+				database.Spec.Name)
+
+		case cluster.Spec.Standby != nil && cluster.Spec.Standby.Enabled:
+			// A standby cluster is read-only and replicates its databases from
+			// another cluster. The database must be dropped there.
+			r.Recorder.Eventf(database, corev1.EventTypeWarning, "DatabaseNotDropped",
+				"PostgresCluster %q is a standby; drop database %q in its primary cluster",
+				cluster.Name, database.Spec.Name)
+
+		case exec == nil:
+			setReadyCondition(database, metav1.ConditionFalse, "PrimaryNotReady",
+				fmt.Sprintf("PostgresCluster %q has no writable instance", cluster.Name))
+			return initialize.Pointer(runtime.RequeueWithoutBackoff(retryInterval)), nil
+
+		default:
+			if err := postgres.DropDatabaseInPostgreSQL(ctx, exec, database.Spec.Name); err != nil {
+				r.Recorder.Event(database, corev1.EventTypeWarning, "DatabaseNotDropped",
+					"Unable to drop database in PostgreSQL")
+				return nil, err
+			}
+		}
+	}
+
+	// Our finalizer logic is finished; remove our finalizer.
+	err := r.patchFinalizers(ctx, database, finalizers.Delete(naming.Finalizer))
+
+	// The caller should wait for further events or requeue upon error.
+	return &reconcile.Result{}, err
+}
+
+// patchFinalizers replaces the finalizers of database with finalizers.
+func (r *Reconciler) patchFinalizers(
+	ctx context.Context, database *v1beta1.PostgresDatabase, finalizers sets.Set[string],
+) error {
+	// The Finalizers field is shared by multiple controllers. Build a
+	// merge-patch that includes the full list of Finalizers plus
+	// ResourceVersion to detect conflicts with other potential writers.
+	// - https://issue.k8s.io/99730
+	before := database.DeepCopy()
+	// Make another copy so that Patch doesn't write back to database.
+	intent := before.DeepCopy()
+	intent.Finalizers = sets.List(finalizers)
+
+	return errors.WithStack(r.Client.Patch(ctx, intent,
+		client.MergeFromWithOptions(before, client.MergeFromWithOptimisticLock{}), r.Owner))
+}
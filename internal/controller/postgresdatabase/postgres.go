@@ -0,0 +1,71 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgresdatabase
+
+import (
+	"context"
+	"io"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crunchydata/postgres-operator/internal/logging"
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/internal/postgres"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+//+kubebuilder:rbac:groups="",resources="pods",verbs={list,watch}
+//+kubebuilder:rbac:groups="",resources="pods/exec",verbs={create}
+
+// primaryExecutor returns an Executor that runs "psql" in the PostgreSQL
+// instance of cluster that can write system catalogs. It returns nil when
+// there is no such instance.
+func (r *Reconciler) primaryExecutor(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+) (postgres.Executor, error) {
+	const container = naming.ContainerDatabase
+
+	// The leader of a standby cluster is read-only.
+	if cluster.Spec.Standby != nil && cluster.Spec.Standby.Enabled {
+		return nil, nil
+	}
+
+	selector, err := naming.AsSelector(naming.ClusterPrimary(cluster.Name))
+
+	var pods corev1.PodList
+	if err == nil {
+		err = errors.WithStack(r.Client.List(ctx, &pods,
+			client.InNamespace(cluster.Namespace),
+			client.MatchingLabelsSelector{Selector: selector},
+		))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+
+		var running bool
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name == container {
+				running = status.State.Running != nil
+			}
+		}
+
+		if terminating := pod.DeletionTimestamp != nil; running && !terminating {
+			return func(
+				ctx context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+			) error {
+				ctx = logging.NewContext(ctx, logging.FromContext(ctx).WithValues("pod", pod.Name))
+				return r.PodExec(ctx, pod.Namespace, pod.Name, container, stdin, stdout, stderr, command...)
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
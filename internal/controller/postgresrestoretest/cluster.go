@@ -0,0 +1,155 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgresrestoretest
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+const (
+	// conditionPostgresDataInitialized is the condition a PostgresCluster uses to
+	// report the outcome of restoring its data source.
+	conditionPostgresDataInitialized = "PostgresDataInitialized"
+
+	// conditionRestoreProgressing is the condition a PostgresCluster uses to
+	// report a restore that cannot start.
+	conditionRestoreProgressing = "PGBackRestoreProgressing"
+)
+
+// generateCluster returns the PostgresCluster that restores the backups of
+// source for the active run of test. It has one instance that resembles the
+// first instance set of source and no backups of its own.
+func generateCluster(
+	test *v1beta1.PostgresRestoreTest, source *v1beta1.PostgresCluster,
+) *v1beta1.PostgresCluster {
+	cluster := v1beta1.NewPostgresCluster()
+	cluster.Namespace = test.Namespace
+	cluster.Name = test.Status.Active.ClusterName
+	cluster.Annotations = test.Spec.Metadata.GetAnnotationsOrNil()
+	cluster.Labels = naming.Merge(
+		test.Spec.Metadata.GetLabelsOrNil(),
+		map[string]string{
+			naming.LabelRestoreTest: test.Name,
+		})
+
+	cluster.Spec.Metadata = test.Spec.Metadata.DeepCopy()
+	cluster.Spec.DataSource = &v1beta1.DataSource{
+		PostgresCluster: &v1beta1.PostgresClusterDataSource{
+			ClusterName: source.Name,
+			RepoName:    test.Spec.RepoName,
+			Options:     test.Spec.Options,
+		},
+	}
+
+	cluster.Spec.PostgresVersion = source.Spec.PostgresVersion
+	cluster.Spec.PostGISVersion = source.Spec.PostGISVersion
+	cluster.Spec.Image = source.Spec.Image
+	cluster.Spec.ImagePullPolicy = source.Spec.ImagePullPolicy
+	cluster.Spec.ImagePullSecrets = source.Spec.ImagePullSecrets
+	cluster.Spec.Config = source.Spec.Config.DeepCopy()
+	cluster.Spec.Patroni = source.Spec.Patroni.DeepCopy()
+	cluster.Spec.SupplementalGroups = source.Spec.SupplementalGroups
+
+	// Restore into a single instance with the same storage as the source.
+	instance := v1beta1.PostgresInstanceSetSpec{
+		Name:     "00",
+		Replicas: initialize.Int32(1),
+	}
+	if len(source.Spec.InstanceSets) > 0 {
+		set := source.Spec.InstanceSets[0].DeepCopy()
+		instance.DataVolumeClaimSpec = set.DataVolumeClaimSpec
+		instance.WALVolumeClaimSpec = set.WALVolumeClaimSpec
+		instance.TablespaceVolumes = set.TablespaceVolumes
+		instance.Resources = set.Resources
+		instance.Tolerations = set.Tolerations
+	}
+	cluster.Spec.InstanceSets = []v1beta1.PostgresInstanceSetSpec{instance}
+
+	return cluster
+}
+
+//+kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="postgresclusters",verbs={get,create}
+
+// reconcileCluster creates the PostgresCluster for the active run of test when
+// it does not exist. It returns the cluster as it is now.
+func (r *Reconciler) reconcileCluster(
+	ctx context.Context, test *v1beta1.PostgresRestoreTest, source *v1beta1.PostgresCluster,
+) (*v1beta1.PostgresCluster, error) {
+	cluster := v1beta1.NewPostgresCluster()
+	err := r.Client.Get(ctx, client.ObjectKey{
+		Namespace: test.Namespace, Name: test.Status.Active.ClusterName,
+	}, cluster)
+
+	if apierrors.IsNotFound(err) {
+		cluster = generateCluster(test, source)
+		err = controllerutil.SetControllerReference(test, cluster, r.Client.Scheme())
+
+		if err == nil {
+			err = r.Client.Create(ctx, cluster, r.Owner)
+		}
+	}
+
+	return cluster, errors.WithStack(err)
+}
+
+//+kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="postgresclusters",verbs={delete}
+
+// deleteCluster deletes the PostgresCluster named name when test controls it.
+func (r *Reconciler) deleteCluster(
+	ctx context.Context, test *v1beta1.PostgresRestoreTest, name string,
+) error {
+	cluster := v1beta1.NewPostgresCluster()
+	err := r.Client.Get(ctx, client.ObjectKey{Namespace: test.Namespace, Name: name}, cluster)
+
+	if err == nil && metav1.IsControlledBy(cluster, test) {
+		uid := cluster.GetUID()
+		version := cluster.GetResourceVersion()
+		exactly := client.Preconditions{UID: &uid, ResourceVersion: &version}
+
+		err = r.Client.Delete(ctx, cluster, exactly,
+			client.PropagationPolicy(metav1.DeletePropagationBackground))
+	}
+
+	return errors.WithStack(client.IgnoreNotFound(err))
+}
+
+// restoreComplete returns whether or not cluster has restored its data source
+// and has a ready instance.
+func restoreComplete(cluster *v1beta1.PostgresCluster) bool {
+	var ready int32
+	for _, set := range cluster.Status.InstanceSets {
+		ready += set.ReadyReplicas
+	}
+	return ready > 0 && meta.IsStatusConditionTrue(
+		cluster.Status.Conditions, conditionPostgresDataInitialized)
+}
+
+// restoreFailure returns why cluster was unable to restore its data source,
+// if it was.
+func restoreFailure(cluster *v1beta1.PostgresCluster) error {
+	initialized := meta.FindStatusCondition(cluster.Status.Conditions, conditionPostgresDataInitialized)
+	progressing := meta.FindStatusCondition(cluster.Status.Conditions, conditionRestoreProgressing)
+
+	switch {
+	case initialized != nil && initialized.Status == metav1.ConditionFalse &&
+		initialized.Reason == "PGBackRestRestoreFailed":
+		return errors.New(initialized.Message)
+	case progressing != nil && progressing.Status == metav1.ConditionFalse &&
+		progressing.Reason == "InvalidRecoveryTarget":
+		return errors.New(progressing.Message)
+	}
+	return nil
+}
@@ -0,0 +1,278 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgresrestoretest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
+	"github.com/crunchydata/postgres-operator/internal/logging"
+	"github.com/crunchydata/postgres-operator/internal/tracing"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+const (
+	// pollInterval is how often a running test checks on its cluster when
+	// nothing else changes.
+	pollInterval = 30 * time.Second
+)
+
+// Reconciler reconciles PostgresRestoreTest objects
+type Reconciler struct {
+	Client  client.Client
+	Owner   client.FieldOwner
+	PodExec func(
+		ctx context.Context, namespace, pod, container string,
+		stdin io.Reader, stdout, stderr io.Writer, command ...string,
+	) error
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="postgresrestoretests",verbs={list,watch}
+//+kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="postgresclusters",verbs={list,watch}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.PodExec == nil {
+		var err error
+		r.PodExec, err = runtime.NewPodExecutor(mgr.GetConfig())
+		if err != nil {
+			return err
+		}
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.PostgresRestoreTest{}).
+		Owns(v1beta1.NewPostgresCluster()).
+		Complete(r)
+}
+
+//+kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="postgresrestoretests",verbs={get}
+//+kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="postgresrestoretests/status",verbs={patch}
+
+// Reconcile does the work to move the current state of the world toward the
+// desired state described in a [v1beta1.PostgresRestoreTest] identified by req.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "reconcile-postgresrestoretest")
+	log := logging.FromContext(ctx)
+	defer span.End()
+	defer func(s tracing.Span) { _ = tracing.Escape(s, err) }(span)
+
+	// Retrieve the test from the client cache, if it exists. A deferred
+	// function below will send any changes to its Status field.
+	//
+	// NOTE: No DeepCopy is necessary here because controller-runtime makes a
+	// copy before returning from its cache.
+	// - https://github.com/kubernetes-sigs/controller-runtime/issues/1235
+	test := &v1beta1.PostgresRestoreTest{}
+	err = r.Client.Get(ctx, req.NamespacedName, test)
+
+	if err == nil {
+		// Write any changes to the test status on the way out.
+		before := test.DeepCopy()
+		defer func() {
+			if !equality.Semantic.DeepEqual(before.Status, test.Status) {
+				status := r.Client.Status().Patch(ctx, test, client.MergeFrom(before), r.Owner)
+
+				if err == nil && status != nil {
+					err = status
+				} else if status != nil {
+					log.Error(status, "Patching PostgresRestoreTest status")
+				}
+			}
+		}()
+	} else {
+		// NotFound cannot be fixed by requeuing so ignore it.
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Set defaults if unset.
+	test.Default()
+
+	// Tests that are being deleted do not start. Kubernetes deletes any
+	// running cluster through its owner reference.
+	if !test.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	schedule, err := cron.ParseStandard(test.Spec.Schedule)
+	if err != nil {
+		// An invalid schedule cannot be fixed by requeuing.
+		setSucceededCondition(test, metav1.ConditionFalse, "InvalidSchedule", err.Error())
+		test.Status.NextScheduleTime = nil
+		return ctrl.Result{}, nil
+	}
+
+	now := time.Now()
+	test.Status.ObservedGeneration = test.Generation
+
+	if test.Status.Active == nil {
+		last := test.CreationTimestamp.Time
+		if test.Status.LastRun != nil {
+			last = test.Status.LastRun.StartTime.Time
+
+			// Delete the cluster of the last test now that its outcome is
+			// stored. It is gone already when nothing has changed.
+			if err := r.deleteCluster(ctx, test, test.Status.LastRun.ClusterName); err != nil {
+				return runtime.ErrorWithBackoff(err)
+			}
+		}
+		next := schedule.Next(last)
+
+		if test.Spec.Suspend != nil && *test.Spec.Suspend {
+			test.Status.NextScheduleTime = nil
+			return ctrl.Result{}, nil
+		}
+
+		test.Status.NextScheduleTime = &metav1.Time{Time: next}
+		if now.Before(next) {
+			return runtime.RequeueWithoutBackoff(next.Sub(now)), nil
+		}
+
+		// Start a test now. The cluster name is unique to this run.
+		test.Status.Active = &v1beta1.PostgresRestoreTestRun{
+			ClusterName:    test.Name + "-" + strconv.FormatInt(now.Unix(), 36),
+			StartTime:      metav1.NewTime(now),
+			RecoveryTarget: recoveryTarget(test.Spec.Options),
+		}
+		test.Status.NextScheduleTime = &metav1.Time{Time: schedule.Next(now)}
+		setSucceededCondition(test, metav1.ConditionUnknown, "Running",
+			fmt.Sprintf("Restoring into PostgresCluster %q", test.Status.Active.ClusterName))
+	}
+
+	ctx = logging.NewContext(ctx, log.WithValues("restore", test.Status.Active.ClusterName))
+
+	// Find the cluster whose backups are being tested. NotFound is handled below.
+	source := v1beta1.NewPostgresCluster()
+	err = client.IgnoreNotFound(r.Client.Get(ctx, client.ObjectKey{
+		Namespace: test.Namespace, Name: test.Spec.ClusterName,
+	}, source))
+	if err != nil {
+		return runtime.ErrorWithBackoff(err)
+	}
+	if source.UID == "" {
+		return r.finish(ctx, test, schedule, now,
+			fmt.Errorf("PostgresCluster %q not found", test.Spec.ClusterName))
+	}
+
+	restored, err := r.reconcileCluster(ctx, test, source)
+	if err != nil {
+		return runtime.ErrorWithBackoff(err)
+	}
+
+	timeout := test.Spec.Timeout.AsDuration().Duration
+	if now.Sub(test.Status.Active.StartTime.Time) > timeout {
+		return r.finish(ctx, test, schedule, now,
+			fmt.Errorf("timed out after %v", timeout))
+	}
+
+	if failure := restoreFailure(restored); failure != nil {
+		return r.finish(ctx, test, schedule, now, failure)
+	}
+
+	// Wait for the restored cluster to be ready. We will reconcile again
+	// when the cluster changes or shortly after.
+	exec, err := r.primaryExecutor(ctx, restored)
+	if err != nil {
+		return runtime.ErrorWithBackoff(err)
+	}
+	if !restoreComplete(restored) || exec == nil {
+		return runtime.RequeueWithoutBackoff(pollInterval), nil
+	}
+
+	return r.finish(ctx, test, schedule, time.Now(), r.validate(ctx, test, exec, now))
+}
+
+// finish records the outcome of the active test in the status of test. The
+// cluster created for it is deleted after that status is stored; see
+// [Reconciler.Reconcile]. Any error from the test is reported as a failure.
+func (r *Reconciler) finish(
+	ctx context.Context, test *v1beta1.PostgresRestoreTest,
+	schedule cron.Schedule, now time.Time, failure error,
+) (ctrl.Result, error) {
+	run := test.Status.Active
+	run.CompletionTime = &metav1.Time{Time: now}
+	run.Duration = &metav1.Duration{Duration: now.Sub(run.StartTime.Time).Round(time.Second)}
+	run.Succeeded = failure == nil
+
+	if failure == nil {
+		test.Status.LastSuccessfulTime = run.CompletionTime
+		setSucceededCondition(test, metav1.ConditionTrue, "Restored",
+			fmt.Sprintf("Restored and validated in %v", run.Duration.Duration))
+		r.Recorder.Event(test, corev1.EventTypeNormal, "RestoreTestSucceeded",
+			fmt.Sprintf("Restored and validated PostgresCluster %q in %v",
+				test.Spec.ClusterName, run.Duration.Duration))
+	} else {
+		run.Message = failure.Error()
+		setSucceededCondition(test, metav1.ConditionFalse, "RestoreFailed", run.Message)
+		r.Recorder.Event(test, corev1.EventTypeWarning, "RestoreTestFailed",
+			fmt.Sprintf("Unable to restore PostgresCluster %q: %v",
+				test.Spec.ClusterName, run.Message))
+	}
+
+	test.Status.LastRun = run
+	test.Status.Active = nil
+
+	// Start the next test on schedule. The change to status is an event that
+	// reconciles again, sooner, to delete the cluster.
+	next := schedule.Next(run.StartTime.Time)
+	test.Status.NextScheduleTime = &metav1.Time{Time: next}
+	return runtime.RequeueWithoutBackoff(max(next.Sub(now), time.Second)), nil
+}
+
+// recoveryTarget returns the point to which options restore, as described by
+// their "--type" and "--target" options.
+// - https://pgbackrest.org/command.html#command-restore
+func recoveryTarget(options []string) string {
+	var kind, target string
+	for i := 0; i < len(options); i++ {
+		name, value, found := strings.Cut(options[i], "=")
+		if !found && i+1 < len(options) {
+			i, value = i+1, options[i+1]
+		}
+		switch name {
+		case "--type":
+			kind = value
+		case "--target":
+			target = value
+		}
+	}
+
+	switch {
+	case kind == "" || kind == "default":
+		return "latest"
+	case target == "":
+		return kind
+	default:
+		return kind + " " + target
+	}
+}
+
+// setSucceededCondition sets the Succeeded condition of test.
+func setSucceededCondition(
+	test *v1beta1.PostgresRestoreTest, status metav1.ConditionStatus, reason, message string,
+) {
+	meta.SetStatusCondition(&test.Status.Conditions, metav1.Condition{
+		ObservedGeneration: test.Generation,
+		Type:               v1beta1.PostgresRestoreTestSucceeded,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+	})
+}
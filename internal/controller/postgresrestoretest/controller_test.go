@@ -0,0 +1,381 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgresrestoretest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/internal/testing/events"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+// primaryPod returns a running PostgreSQL Pod that is the leader of cluster.
+func primaryPod(cluster *v1beta1.PostgresCluster) *corev1.Pod {
+	pod := new(corev1.Pod)
+	pod.Namespace = cluster.Namespace
+	pod.Name = cluster.Name + "-00-abcd-0"
+	pod.Labels = map[string]string{
+		naming.LabelCluster:  cluster.Name,
+		naming.LabelInstance: cluster.Name + "-00-abcd",
+		naming.LabelRole:     naming.RolePatroniLeader,
+	}
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:  naming.ContainerDatabase,
+		State: corev1.ContainerState{Running: new(corev1.ContainerStateRunning)},
+	}}
+	return pod
+}
+
+func newReconciler(t testing.TB, objects ...client.Object) *Reconciler {
+	return &Reconciler{
+		Client: fake.NewClientBuilder().
+			WithScheme(runtime.Scheme).
+			WithObjects(objects...).
+			WithStatusSubresource(&v1beta1.PostgresRestoreTest{}, &v1beta1.PostgresCluster{}).
+			Build(),
+		Owner:    naming.ControllerPostgresRestoreTest,
+		Recorder: events.NewRecorder(t, runtime.Scheme),
+	}
+}
+
+func TestRecoveryTarget(t *testing.T) {
+	for _, tt := range []struct {
+		options  []string
+		expected string
+	}{
+		{options: nil, expected: "latest"},
+		{options: []string{"--delta"}, expected: "latest"},
+		{options: []string{"--type=default"}, expected: "latest"},
+		{options: []string{"--type=immediate"}, expected: "immediate"},
+		{
+			options:  []string{"--type=time", `--target="2025-01-01 00:00:00+00"`},
+			expected: `time "2025-01-01 00:00:00+00"`,
+		},
+		{
+			options:  []string{"--type", "name", "--target", "before-upgrade"},
+			expected: "name before-upgrade",
+		},
+	} {
+		assert.Equal(t, recoveryTarget(tt.options), tt.expected, "options: %q", tt.options)
+	}
+}
+
+func TestGenerateCluster(t *testing.T) {
+	source := v1beta1.NewPostgresCluster()
+	source.Namespace, source.Name = "ns1", "hippo"
+	source.Spec.PostgresVersion = 16
+	source.Spec.Image = "postgres:16"
+	source.Spec.InstanceSets = []v1beta1.PostgresInstanceSetSpec{{
+		Name:     "primary",
+		Replicas: initialize.Int32(3),
+		DataVolumeClaimSpec: v1beta1.VolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{"ReadWriteOnce"},
+		},
+	}}
+	source.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{{Name: "repo1"}}
+
+	test := &v1beta1.PostgresRestoreTest{}
+	test.Namespace, test.Name = "ns1", "nightly"
+	test.Spec.RepoName = "repo2"
+	test.Spec.Options = []string{"--type=immediate"}
+	test.Spec.Metadata = &v1beta1.Metadata{Labels: map[string]string{"team": "dba"}}
+	test.Status.Active = &v1beta1.PostgresRestoreTestRun{ClusterName: "nightly-abc"}
+
+	cluster := generateCluster(test, source)
+
+	assert.Assert(t, cmp.MarshalMatches(cluster, `
+apiVersion: postgres-operator.crunchydata.com/v1beta1
+kind: PostgresCluster
+metadata:
+  creationTimestamp: null
+  labels:
+    postgres-operator.crunchydata.com/restore-test: nightly
+    team: dba
+  name: nightly-abc
+  namespace: ns1
+spec:
+  dataSource:
+    postgresCluster:
+      clusterName: hippo
+      options:
+      - --type=immediate
+      repoName: repo2
+  image: postgres:16
+  instances:
+  - dataVolumeClaimSpec:
+      accessModes:
+      - ReadWriteOnce
+      resources: {}
+    name: "00"
+    replicas: 1
+  metadata:
+    labels:
+      team: dba
+  postgresVersion: 16
+	`))
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	request := func(test *v1beta1.PostgresRestoreTest) reconcile.Request {
+		return reconcile.Request{NamespacedName: client.ObjectKeyFromObject(test)}
+	}
+
+	source := v1beta1.NewPostgresCluster()
+	source.Namespace, source.Name, source.UID = "ns1", "hippo", "source-uid"
+	source.Spec.PostgresVersion = 16
+
+	newTest := func() *v1beta1.PostgresRestoreTest {
+		test := v1beta1.NewPostgresRestoreTest()
+		test.Namespace, test.Name = "ns1", "nightly"
+		test.CreationTimestamp = metav1.NewTime(time.Now().Add(-25 * time.Hour))
+		test.Spec.ClusterName = "hippo"
+		test.Spec.RepoName = "repo1"
+		test.Spec.Schedule = "0 0 * * *"
+		return test
+	}
+
+	t.Run("InvalidSchedule", func(t *testing.T) {
+		test := newTest()
+		test.Spec.Schedule = "not a schedule"
+		r := newReconciler(t, test)
+
+		result, err := r.Reconcile(ctx, request(test))
+		assert.NilError(t, err)
+		assert.Assert(t, result.IsZero())
+
+		assert.NilError(t, r.Client.Get(ctx, client.ObjectKeyFromObject(test), test))
+		condition := meta.FindStatusCondition(test.Status.Conditions, v1beta1.PostgresRestoreTestSucceeded)
+		assert.Assert(t, condition != nil)
+		assert.Equal(t, condition.Reason, "InvalidSchedule")
+		assert.Assert(t, test.Status.Active == nil)
+	})
+
+	t.Run("NotYet", func(t *testing.T) {
+		test := newTest()
+		test.Status.LastRun = &v1beta1.PostgresRestoreTestRun{
+			ClusterName: "nightly-old", StartTime: metav1.Now(),
+		}
+		r := newReconciler(t, test)
+
+		result, err := r.Reconcile(ctx, request(test))
+		assert.NilError(t, err)
+		assert.Assert(t, result.RequeueAfter > 0 && result.RequeueAfter <= 24*time.Hour)
+
+		assert.NilError(t, r.Client.Get(ctx, client.ObjectKeyFromObject(test), test))
+		assert.Assert(t, test.Status.Active == nil)
+		assert.Assert(t, test.Status.NextScheduleTime != nil)
+	})
+
+	t.Run("Suspended", func(t *testing.T) {
+		test := newTest()
+		test.Spec.Suspend = initialize.Bool(true)
+		r := newReconciler(t, test, source)
+
+		result, err := r.Reconcile(ctx, request(test))
+		assert.NilError(t, err)
+		assert.Assert(t, result.IsZero())
+
+		assert.NilError(t, r.Client.Get(ctx, client.ObjectKeyFromObject(test), test))
+		assert.Assert(t, test.Status.Active == nil)
+		assert.Assert(t, test.Status.NextScheduleTime == nil)
+	})
+
+	t.Run("Start", func(t *testing.T) {
+		test := newTest()
+		r := newReconciler(t, test, source)
+
+		result, err := r.Reconcile(ctx, request(test))
+		assert.NilError(t, err)
+		assert.Equal(t, result.RequeueAfter, pollInterval)
+
+		assert.NilError(t, r.Client.Get(ctx, client.ObjectKeyFromObject(test), test))
+		assert.Assert(t, test.Status.Active != nil)
+		assert.Equal(t, test.Status.Active.RecoveryTarget, "latest")
+		assert.Assert(t, strings.HasPrefix(test.Status.Active.ClusterName, "nightly-"))
+
+		cluster := v1beta1.NewPostgresCluster()
+		assert.NilError(t, r.Client.Get(ctx, client.ObjectKey{
+			Namespace: "ns1", Name: test.Status.Active.ClusterName,
+		}, cluster))
+		assert.Assert(t, metav1.IsControlledBy(cluster, test))
+		assert.Equal(t, cluster.Spec.DataSource.PostgresCluster.ClusterName, "hippo")
+	})
+
+	t.Run("SourceNotFound", func(t *testing.T) {
+		test := newTest()
+		test.Status.Active = &v1beta1.PostgresRestoreTestRun{
+			ClusterName: "nightly-abc", StartTime: metav1.Now(),
+		}
+		r := newReconciler(t, test)
+		recorder := r.Recorder.(*events.Recorder)
+
+		_, err := r.Reconcile(ctx, request(test))
+		assert.NilError(t, err)
+
+		assert.NilError(t, r.Client.Get(ctx, client.ObjectKeyFromObject(test), test))
+		assert.Assert(t, test.Status.Active == nil)
+		assert.Assert(t, test.Status.LastRun != nil)
+		assert.Assert(t, !test.Status.LastRun.Succeeded)
+		assert.Assert(t, cmp.Contains(test.Status.LastRun.Message, `"hippo" not found`))
+		assert.Assert(t, test.Status.LastSuccessfulTime == nil)
+
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, "RestoreTestFailed")
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		test := newTest()
+		test.Spec.Timeout, _ = v1beta1.NewDuration("1h")
+		test.Status.Active = &v1beta1.PostgresRestoreTestRun{
+			ClusterName: "nightly-abc",
+			StartTime:   metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+		}
+		r := newReconciler(t, test, source)
+
+		_, err := r.Reconcile(ctx, request(test))
+		assert.NilError(t, err)
+
+		assert.NilError(t, r.Client.Get(ctx, client.ObjectKeyFromObject(test), test))
+		assert.Assert(t, test.Status.LastRun != nil)
+		assert.Assert(t, cmp.Contains(test.Status.LastRun.Message, "timed out"))
+
+		// The cluster created during this reconcile remains until the outcome is stored.
+		key := client.ObjectKey{Namespace: "ns1", Name: "nightly-abc"}
+		assert.NilError(t, r.Client.Get(ctx, key, v1beta1.NewPostgresCluster()))
+
+		_, err = r.Reconcile(ctx, request(test))
+		assert.NilError(t, err)
+
+		err = r.Client.Get(ctx, key, v1beta1.NewPostgresCluster())
+		assert.Assert(t, apierrors.IsNotFound(err), "got %v", err)
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		test := newTest()
+		test.Spec.Validation = &corev1.ConfigMapKeySelector{Key: "check.sql"}
+		test.Spec.Validation.Name = "checks"
+		test.Status.Active = &v1beta1.PostgresRestoreTestRun{
+			ClusterName:    "nightly-abc",
+			StartTime:      metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+			RecoveryTarget: "latest",
+		}
+
+		configmap := &corev1.ConfigMap{}
+		configmap.Namespace, configmap.Name = "ns1", "checks"
+		configmap.Data = map[string]string{"check.sql": "SELECT 1 FROM orders LIMIT 1;"}
+
+		for _, tt := range []struct {
+			name      string
+			exec      error
+			succeeded bool
+			reason    string
+		}{
+			{name: "Success", succeeded: true, reason: "RestoreTestSucceeded"},
+			{name: "Failure", exec: errors.New("exit 3"), reason: "RestoreTestFailed"},
+		} {
+			t.Run(tt.name, func(t *testing.T) {
+				test := test.DeepCopy()
+				restored := generateCluster(test, source)
+				restored.OwnerReferences = []metav1.OwnerReference{{
+					APIVersion: v1beta1.GroupVersion.String(), Kind: "PostgresRestoreTest",
+					Name: test.Name, UID: "test-uid", Controller: initialize.Bool(true),
+				}}
+				test.UID = "test-uid"
+				restored.Status.InstanceSets = []v1beta1.PostgresInstanceSetStatus{{Name: "00", ReadyReplicas: 1}}
+				restored.Status.Conditions = []metav1.Condition{{
+					Type: conditionPostgresDataInitialized, Status: metav1.ConditionTrue,
+					Reason: "PGBackRestRestoreComplete", LastTransitionTime: metav1.Now(),
+				}}
+
+				r := newReconciler(t, test, source, restored, configmap, primaryPod(restored))
+				recorder := r.Recorder.(*events.Recorder)
+
+				var calls int
+				r.PodExec = func(
+					ctx context.Context, namespace, pod, container string,
+					stdin io.Reader, stdout, stderr io.Writer, command ...string,
+				) error {
+					calls++
+					assert.Equal(t, pod, "nightly-abc-00-abcd-0")
+					assert.Assert(t, cmp.Contains(command, "--set=ON_ERROR_STOP=on"))
+
+					// Statements stop when the test would time out.
+					b, _ := io.ReadAll(stdin)
+					first, rest, _ := strings.Cut(string(b), "\n")
+					assert.Assert(t, cmp.Regexp(`^SET statement_timeout = (6[5-9]|70)[0-9]{5};$`, first))
+					assert.Equal(t, rest, "SELECT 1 FROM orders LIMIT 1;")
+
+					if tt.exec != nil {
+						_, _ = io.WriteString(stderr, `ERROR:  relation "orders" does not exist`)
+					}
+					return tt.exec
+				}
+
+				_, err := r.Reconcile(ctx, request(test))
+				assert.NilError(t, err)
+				assert.Equal(t, calls, 1)
+
+				assert.NilError(t, r.Client.Get(ctx, client.ObjectKeyFromObject(test), test))
+				assert.Assert(t, test.Status.Active == nil)
+				assert.Equal(t, test.Status.LastRun.Succeeded, tt.succeeded)
+				assert.Equal(t, test.Status.LastRun.RecoveryTarget, "latest")
+				assert.Assert(t, test.Status.LastRun.Duration != nil)
+				assert.Assert(t, test.Status.NextScheduleTime != nil)
+				assert.Equal(t, test.Status.LastSuccessfulTime != nil, tt.succeeded)
+
+				if !tt.succeeded {
+					assert.Assert(t, cmp.Contains(test.Status.LastRun.Message, `relation "orders" does not exist`))
+				}
+
+				assert.Equal(t, len(recorder.Events), 1)
+				assert.Equal(t, recorder.Events[0].Reason, tt.reason)
+
+				// The cluster is deleted after the outcome is stored.
+				_, err = r.Reconcile(ctx, request(test))
+				assert.NilError(t, err)
+				assert.Equal(t, calls, 1)
+
+				err = r.Client.Get(ctx, client.ObjectKeyFromObject(restored), v1beta1.NewPostgresCluster())
+				assert.Assert(t, apierrors.IsNotFound(err), "got %v", err)
+			})
+		}
+	})
+}
+
+func TestRestoreFailure(t *testing.T) {
+	cluster := v1beta1.NewPostgresCluster()
+	assert.NilError(t, restoreFailure(cluster))
+
+	cluster.Status.Conditions = []metav1.Condition{{
+		Type: conditionPostgresDataInitialized, Status: metav1.ConditionFalse,
+		Reason: "PGBackRestRestoreFailed", Message: "pgBackRest restore failed",
+	}}
+	assert.ErrorContains(t, restoreFailure(cluster), "pgBackRest restore failed")
+
+	cluster.Status.Conditions = []metav1.Condition{{
+		Type: conditionRestoreProgressing, Status: metav1.ConditionFalse,
+		Reason: "InvalidRecoveryTarget", Message: "Unable to restore from repo1: target time is in the future",
+	}}
+	assert.ErrorContains(t, restoreFailure(cluster), "target time is in the future")
+}
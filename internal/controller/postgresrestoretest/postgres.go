@@ -0,0 +1,113 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgresrestoretest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crunchydata/postgres-operator/internal/logging"
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/internal/postgres"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+//+kubebuilder:rbac:groups="",resources="pods",verbs={list,watch}
+//+kubebuilder:rbac:groups="",resources="pods/exec",verbs={create}
+
+// primaryExecutor returns an Executor that runs "psql" in the PostgreSQL
+// instance of cluster that can write system catalogs. It returns nil when
+// there is no such instance.
+func (r *Reconciler) primaryExecutor(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+) (postgres.Executor, error) {
+	const container = naming.ContainerDatabase
+
+	selector, err := naming.AsSelector(naming.ClusterPrimary(cluster.Name))
+
+	var pods corev1.PodList
+	if err == nil {
+		err = errors.WithStack(r.Client.List(ctx, &pods,
+			client.InNamespace(cluster.Namespace),
+			client.MatchingLabelsSelector{Selector: selector},
+		))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+
+		var running bool
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name == container {
+				running = status.State.Running != nil
+			}
+		}
+
+		if terminating := pod.DeletionTimestamp != nil; running && !terminating {
+			return func(
+				ctx context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+			) error {
+				ctx = logging.NewContext(ctx, logging.FromContext(ctx).WithValues("pod", pod.Name))
+				return r.PodExec(ctx, pod.Namespace, pod.Name, container, stdin, stdout, stderr, command...)
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+//+kubebuilder:rbac:groups="",resources="configmaps",verbs={get}
+
+// validate runs the validation SQL of test in the "postgres" database using
+// exec. Each statement is canceled when the test would time out at now. It
+// returns an error when the SQL cannot be found or does not succeed.
+func (r *Reconciler) validate(
+	ctx context.Context, test *v1beta1.PostgresRestoreTest, exec postgres.Executor,
+	now time.Time,
+) error {
+	// Without validation SQL, check that PostgreSQL accepts queries.
+	sql := "SELECT pg_catalog.current_database();"
+
+	if selector := test.Spec.Validation; selector != nil {
+		var configmap corev1.ConfigMap
+		err := r.Client.Get(ctx, client.ObjectKey{
+			Namespace: test.Namespace, Name: selector.Name,
+		}, &configmap)
+
+		if err != nil {
+			return fmt.Errorf("unable to read validation SQL from ConfigMap %q: %w", selector.Name, err)
+		}
+
+		value, ok := configmap.Data[selector.Key]
+		if !ok {
+			return fmt.Errorf("ConfigMap %q has no key %q", selector.Name, selector.Key)
+		}
+		sql = value
+	}
+
+	// Stop statements that run past the timeout of the test; the controller
+	// is waiting on them. The value is in milliseconds.
+	// - https://www.postgresql.org/docs/current/runtime-config-client.html#GUC-STATEMENT-TIMEOUT
+	remaining := test.Status.Active.StartTime.Add(test.Spec.Timeout.AsDuration().Duration).Sub(now)
+	sql = fmt.Sprintf("SET statement_timeout = %d;\n", max(remaining, time.Second).Milliseconds()) + sql
+
+	_, stderr, err := exec.Exec(ctx, strings.NewReader(sql), map[string]string{
+		"ON_ERROR_STOP": "on",
+	})
+	if err != nil {
+		return fmt.Errorf("validation failed: %s", strings.TrimSpace(stderr))
+	}
+	return nil
+}
@@ -21,6 +21,34 @@ const (
 	// ID associated with a specific manual backup Job.
 	PGBackRestBackup = annotationPrefix + "pgbackrest-backup"
 
+	// PGBackRestExpire is the annotation that is added to a PostgresCluster to remove backups
+	// from a pgBackRest repository on demand.  The value of the annotation is a unique identifier
+	// for an expire Job (e.g. a timestamp), which is stored in the PostgresCluster status to track
+	// completion of the Job.  Also used to annotate the expire Job itself.
+	PGBackRestExpire = annotationPrefix + "pgbackrest-expire"
+
+	// PGBackRestRestorePoint is the annotation that is added to a PostgresCluster to create a
+	// named restore point in the WAL stream.  The value of the annotation is the name of the
+	// restore point, which is stored in the PostgresCluster status once it is created.
+	PGBackRestRestorePoint = annotationPrefix + "pgbackrest-restore-point"
+
+	// PostgresUserRotatePassword is the annotation added to a PostgreSQL user Secret to generate
+	// a new password for a user with password rotation. The value of the annotation is any unique
+	// identifier (e.g. a timestamp), which is stored in the PostgresCluster status so that each
+	// value generates one password.
+	PostgresUserRotatePassword = annotationPrefix + "rotate-password"
+
+	// RotateRootCertificate is the annotation added to a PostgresCluster to begin a rotation of
+	// the root certificate authority that it shares with other PostgresClusters in its namespace.
+	// The value of the annotation is any unique identifier (e.g. a timestamp), which is stored in
+	// the PostgresCluster status so that each value begins one rotation.
+	RotateRootCertificate = annotationPrefix + "rotate-root-certificate"
+
+	// RootCertificateRotationTime is the annotation added to the root certificate Secret while
+	// its rotation is in progress. It holds an RFC3339 formatted timestamp of when the current
+	// phase of the rotation began.
+	RootCertificateRotationTime = annotationPrefix + "root-certificate-rotation-time"
+
 	// PGBackRestBackupJobCompletion is the annotation that is added to restore jobs, pvcs, and
 	// VolumeSnapshots that are involved in the volume snapshot creation process. The annotation
 	// holds a RFC3339 formatted timestamp that corresponds to the completion time of the associated
@@ -5,6 +5,8 @@
 package naming
 
 const (
-	ControllerBridge  = "bridge-controller"
-	ControllerPGAdmin = "pgadmin-controller"
+	ControllerBridge              = "bridge-controller"
+	ControllerPGAdmin             = "pgadmin-controller"
+	ControllerPostgresDatabase    = "postgresdatabase-controller"
+	ControllerPostgresRestoreTest = "postgresrestoretest-controller"
 )
@@ -50,6 +50,9 @@ const (
 	// LabelPGBackRestBackup is used to indicate that a resource is for a pgBackRest backup
 	LabelPGBackRestBackup = labelPrefix + "pgbackrest-backup"
 
+	// LabelPGBackRestExpire is used to indicate that a Job is for a pgBackRest expire
+	LabelPGBackRestExpire = labelPrefix + "pgbackrest-expire"
+
 	// LabelPGBackRestConfig is used to indicate that a ConfigMap or Secret is for pgBackRest
 	LabelPGBackRestConfig = labelPrefix + "pgbackrest-config"
 
@@ -81,6 +84,9 @@ const (
 	// LabelPostgresUser identifies the PostgreSQL user an object is for or about.
 	LabelPostgresUser = labelPrefix + "pguser"
 
+	// LabelRestoreTest identifies the PostgresRestoreTest that created a PostgresCluster.
+	LabelRestoreTest = labelPrefix + "restore-test"
+
 	// LabelStartupInstance is used to indicate the startup instance associated with a resource
 	LabelStartupInstance = labelPrefix + "startup-instance"
 
@@ -203,6 +209,17 @@ func PGBackRestBackupJobSelector(clusterName, repoName string,
 	return PGBackRestBackupJobLabels(clusterName, repoName, backupType).AsSelector()
 }
 
+// PGBackRestExpireJobLabels provides labels for a Job that expires backups in a
+// pgBackRest repository.
+func PGBackRestExpireJobLabels(clusterName, repoName string) labels.Set {
+	repoLabels := PGBackRestLabels(clusterName)
+	jobLabels := map[string]string{
+		LabelPGBackRestRepo:   repoName,
+		LabelPGBackRestExpire: "",
+	}
+	return labels.Merge(jobLabels, repoLabels)
+}
+
 // PGBackRestRestoreConfigLabels provides labels for configuration (e.g. ConfigMaps and Secrets)
 // generated to perform a pgBackRest restore.
 //
@@ -27,6 +27,7 @@ func TestLabelsValid(t *testing.T) {
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGBackRestBackup))
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGBackRestConfig))
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGBackRestDedicated))
+	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGBackRestExpire))
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGBackRestRepo))
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGBackRestRepoVolume))
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGBackRestRestore))
@@ -166,6 +167,14 @@ func TestPGBackRestLabelFuncs(t *testing.T) {
 		BackupReplicaCreate)
 	assert.Check(t, pgBackRestBackupJobSelector.Matches(pgBackRestReplicaBackupLabels))
 
+	// verify the labels that identify pgBackRest expire resources
+	pgBackRestExpireLabels := PGBackRestExpireJobLabels(clusterName, repoName)
+	assert.Equal(t, pgBackRestExpireLabels.Get(LabelCluster), clusterName)
+	assert.Check(t, pgBackRestExpireLabels.Has(LabelPGBackRest))
+	assert.Check(t, pgBackRestExpireLabels.Has(LabelPGBackRestExpire))
+	assert.Check(t, !pgBackRestExpireLabels.Has(LabelPGBackRestBackup))
+	assert.Equal(t, pgBackRestExpireLabels.Get(LabelPGBackRestRepo), repoName)
+
 	// verify the labels that identify pgBackRest repo resources
 	pgBackRestRepoLabels := PGBackRestRepoLabels(clusterName, repoName)
 	assert.Equal(t, pgBackRestRepoLabels.Get(LabelCluster), clusterName)
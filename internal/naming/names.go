@@ -459,6 +459,14 @@ func PGBackRestBackupJob(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
 	}
 }
 
+// PGBackRestExpireJob returns the ObjectMeta for a pgBackRest expire Job
+func PGBackRestExpireJob(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      cluster.GetName() + "-expire-" + rand.String(4),
+		Namespace: cluster.GetNamespace(),
+	}
+}
+
 // PGBackRestCronJob returns the ObjectMeta for a pgBackRest CronJob
 func PGBackRestCronJob(cluster *v1beta1.PostgresCluster, backuptype, repoName string) metav1.ObjectMeta {
 	return metav1.ObjectMeta{
@@ -510,6 +518,16 @@ func PGBackRestSecret(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
 	}
 }
 
+// PGBackRestCipherSecret returns the ObjectMeta for the Secret that holds the
+// generated passphrases of encrypted pgBackRest repositories. It is not owned
+// by the cluster so that the passphrases outlive it.
+func PGBackRestCipherSecret(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      cluster.GetName() + "-pgbackrest-cipher",
+		Namespace: cluster.GetNamespace(),
+	}
+}
+
 // DeprecatedPostgresUserSecret returns the ObjectMeta necessary to lookup the
 // old Secret containing the default Postgres user and connection information.
 // Use PostgresUserSecret instead.
@@ -538,6 +556,43 @@ func PostgresTLSSecret(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
 	}
 }
 
+// CertManagerClusterCertificate returns the ObjectMeta of the cert-manager
+// Certificate, and its Secret, for the PostgreSQL server.
+func CertManagerClusterCertificate(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: cluster.Namespace,
+		Name:      cluster.Name + "-cluster-tls",
+	}
+}
+
+// CertManagerReplicationCertificate returns the ObjectMeta of the cert-manager
+// Certificate, and its Secret, for the replication user.
+func CertManagerReplicationCertificate(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: cluster.Namespace,
+		Name:      cluster.Name + "-replication-tls",
+	}
+}
+
+// CertManagerPGBouncerCertificate returns the ObjectMeta of the cert-manager
+// Certificate, and its Secret, for PgBouncer clients.
+func CertManagerPGBouncerCertificate(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: cluster.Namespace,
+		Name:      cluster.Name + "-pgbouncer-tls",
+	}
+}
+
+// CertManagerTrustBundle returns the ObjectMeta of the trust-manager Bundle
+// that distributes the certificate authority of cluster. Bundles are not
+// namespaced, so the name includes the namespace of cluster. The ConfigMaps
+// it writes have the same name.
+func CertManagerTrustBundle(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name: cluster.Namespace + "-" + cluster.Name + "-ca",
+	}
+}
+
 // MovePGDataDirJob returns the ObjectMeta for a pgData directory move Job
 func MovePGDataDirJob(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
 	return metav1.ObjectMeta{
@@ -109,6 +109,7 @@ func TestClusterNamesUniqueAndValid(t *testing.T) {
 	t.Run("Jobs", func(t *testing.T) {
 		testUniqueAndValid(t, []test{
 			{"PGBackRestBackupJob", PGBackRestBackupJob(cluster)},
+			{"PGBackRestExpireJob", PGBackRestExpireJob(cluster)},
 			{"PGBackRestRestoreJob", PGBackRestRestoreJob(cluster)},
 		})
 	})
@@ -136,6 +137,9 @@ func TestClusterNamesUniqueAndValid(t *testing.T) {
 
 	t.Run("Secrets", func(t *testing.T) {
 		names := testUniqueAndValid(t, []test{
+			{"CertManagerClusterCertificate", CertManagerClusterCertificate(cluster)},
+			{"CertManagerPGBouncerCertificate", CertManagerPGBouncerCertificate(cluster)},
+			{"CertManagerReplicationCertificate", CertManagerReplicationCertificate(cluster)},
 			{"ClusterPGBouncer", ClusterPGBouncer(cluster)},
 			{"DeprecatedPostgresUserSecret", DeprecatedPostgresUserSecret(cluster)},
 			{"PostgresTLSSecret", PostgresTLSSecret(cluster)},
@@ -5,9 +5,17 @@
 package patroni
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
 	"encoding"
+	"encoding/hex"
+	"path"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+
+	"github.com/crunchydata/postgres-operator/internal/logging"
 )
 
 const (
@@ -54,3 +62,35 @@ func instanceCertificates(certificates *corev1.Secret) []corev1.VolumeProjection
 		},
 	}}
 }
+
+// CertificatesLoaded calls "sha256sum" to read the certificate files of
+// Patroni and reports whether they match those in certificates, the Secret
+// that was projected into the instance.
+func (exec Executor) CertificatesLoaded(
+	ctx context.Context, certificates *corev1.Secret,
+) (bool, error) {
+	var stdout, stderr bytes.Buffer
+
+	err := exec(ctx, nil, &stdout, &stderr, "sha256sum", "--",
+		path.Join(configDirectory, certAuthorityConfigPath),
+		path.Join(configDirectory, certServerConfigPath))
+
+	if err != nil {
+		logging.FromContext(ctx).V(1).Info("unable to read certificate files",
+			"stdout", stdout.String(), "stderr", stderr.String())
+		return false, err
+	}
+
+	// Each line of output is the hexadecimal hash of one file followed by its
+	// path, in the order they were given.
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	loaded := len(lines) == 2
+
+	for i, key := range []string{certAuthorityFileKey, certServerFileKey} {
+		if loaded {
+			sum := sha256.Sum256(certificates.Data[key])
+			loaded = strings.HasPrefix(lines[i], hex.EncodeToString(sum[:])+" ")
+		}
+	}
+	return loaded, nil
+}
@@ -5,7 +5,11 @@
 package patroni
 
 import (
+	"context"
+	"crypto/sha256"
 	"errors"
+	"fmt"
+	"io"
 	"testing"
 
 	"gotest.tools/v3/assert"
@@ -48,3 +52,50 @@ func TestInstanceCertificates(t *testing.T) {
     name: some-name
 	`))
 }
+
+func TestExecutorCertificatesLoaded(t *testing.T) {
+	ctx := context.Background()
+	secret := &corev1.Secret{Data: map[string][]byte{
+		"patroni.ca-roots":     []byte("roots"),
+		"patroni.crt-combined": []byte("combined"),
+	}}
+
+	// sha256sum prints one line for each file.
+	output := func(roots, combined string) Executor {
+		return func(
+			_ context.Context, stdin io.Reader, stdout, _ io.Writer, command ...string,
+		) error {
+			assert.DeepEqual(t, command, []string{"sha256sum", "--",
+				"/etc/patroni/~postgres-operator/patroni.ca-roots",
+				"/etc/patroni/~postgres-operator/patroni.crt+key",
+			})
+			assert.Assert(t, stdin == nil)
+
+			for _, text := range []string{roots, combined} {
+				sum := sha256.Sum256([]byte(text))
+				_, _ = fmt.Fprintf(stdout, "%x  some/path\n", sum)
+			}
+			return nil
+		}
+	}
+
+	loaded, err := output("roots", "combined").CertificatesLoaded(ctx, secret)
+	assert.NilError(t, err)
+	assert.Assert(t, loaded)
+
+	loaded, err = output("roots", "previous").CertificatesLoaded(ctx, secret)
+	assert.NilError(t, err)
+	assert.Assert(t, !loaded)
+
+	loaded, err = output("previous", "combined").CertificatesLoaded(ctx, secret)
+	assert.NilError(t, err)
+	assert.Assert(t, !loaded)
+
+	expected := errors.New("bang")
+	_, err = Executor(func(
+		context.Context, io.Reader, io.Writer, io.Writer, ...string,
+	) error {
+		return expected
+	}).CertificatesLoaded(ctx, secret)
+	assert.Equal(t, err, expected)
+}
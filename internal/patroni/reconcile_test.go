@@ -76,7 +76,7 @@ func TestReconcileInstanceCertificates(t *testing.T) {
 	secret := new(corev1.Secret)
 
 	assert.NilError(t, InstanceCertificates(ctx,
-		root.Certificate, leaf.Certificate, leaf.PrivateKey, secret))
+		root.Trust(), leaf.Certificate, leaf.PrivateKey, secret))
 
 	assert.DeepEqual(t, secret.Data["patroni.ca-roots"], dataCA)
 	assert.DeepEqual(t, secret.Data["patroni.crt-combined"], dataCert)
@@ -84,7 +84,7 @@ func TestReconcileInstanceCertificates(t *testing.T) {
 	// No change when called again.
 	before := secret.DeepCopy()
 	assert.NilError(t, InstanceCertificates(ctx,
-		root.Certificate, leaf.Certificate, leaf.PrivateKey, secret))
+		root.Trust(), leaf.Certificate, leaf.PrivateKey, secret))
 	assert.DeepEqual(t, secret, before)
 }
 
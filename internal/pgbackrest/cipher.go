@@ -0,0 +1,156 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgbackrest
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/internal/util"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+const (
+	// cipherConfigProjectionPath is a pgBackRest configuration file that sets
+	// the cipher options of encrypted repositories.
+	cipherConfigProjectionPath = "~postgres-operator_cipher.conf"
+
+	cipherConfigSecretKey        = "pgbackrest-cipher.conf"         // #nosec G101 this is a name, not a credential
+	cipherRestoreConfigSecretKey = "pgbackrest-restore-cipher.conf" // #nosec G101 this is a name, not a credential
+
+	// cipherPassLength is the number of characters in a generated passphrase.
+	// pgBackRest recommends a long, random passphrase.
+	// - https://pgbackrest.org/configuration.html#section-repository/option-repo-cipher-pass
+	cipherPassLength = 64
+
+	// defaultCipherType is the cipher used when a repository does not specify one.
+	defaultCipherType = "aes-256-cbc"
+)
+
+// cipherPassSecretKey returns the key of the cipher Secret that holds the
+// passphrase of the repository named repoName.
+func cipherPassSecretKey(repoName string) string {
+	return repoName + "-cipher-pass"
+}
+
+// cipherType returns the cipher pgBackRest uses to encrypt repo.
+func cipherType(repo v1beta1.PGBackRestRepo) string {
+	if repo.Encryption != nil && repo.Encryption.CipherType != "" {
+		return repo.Encryption.CipherType
+	}
+	return defaultCipherType
+}
+
+// encrypted returns whether or not any repository of cluster is encrypted.
+func encrypted(cluster *v1beta1.PostgresCluster) bool {
+	for _, repo := range cluster.Spec.Backups.PGBackRest.Repos {
+		if repo.Encryption != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// CipherSecret populates outSecret with a passphrase for each repository of
+// inCluster that is encrypted without a passphrase of the user's choosing.
+// Every passphrase in inSecret is kept, including those of repositories that
+// were removed; changing one would make its repository unreadable.
+func CipherSecret(inCluster *v1beta1.PostgresCluster, inSecret, outSecret *corev1.Secret) error {
+	initialize.Map(&outSecret.Data)
+
+	for key, value := range inSecret.Data {
+		outSecret.Data[key] = append([]byte(nil), value...)
+	}
+
+	for _, repo := range inCluster.Spec.Backups.PGBackRest.Repos {
+		if key := cipherPassSecretKey(repo.Name); repo.Encryption != nil &&
+			repo.Encryption.Passphrase == nil && len(outSecret.Data[key]) == 0 {
+			pass, err := util.GenerateAlphaNumericPassword(cipherPassLength)
+			if err != nil {
+				return err
+			}
+			outSecret.Data[key] = []byte(pass)
+		}
+	}
+
+	return nil
+}
+
+// CipherPassphrase returns the passphrase of repo that [CipherSecret] stores
+// in secret, if any.
+func CipherPassphrase(repo v1beta1.PGBackRestRepo, secret *corev1.Secret) string {
+	return string(secret.Data[cipherPassSecretKey(repo.Name)])
+}
+
+// cipherConfiguration populates outSecret with a configuration file that sets
+// the cipher options of each encrypted repository of inCluster. Repositories
+// without a passphrase in inPassphrases are left out.
+func cipherConfiguration(
+	inCluster *v1beta1.PostgresCluster, inPassphrases map[string]string,
+	inSecret, outSecret *corev1.Secret,
+) error {
+	global := iniMultiSet{}
+
+	for _, repo := range inCluster.Spec.Backups.PGBackRest.Repos {
+		if pass := inPassphrases[repo.Name]; repo.Encryption != nil && pass != "" {
+			global.Set(repo.Name+"-cipher-pass", pass)
+			global.Set(repo.Name+"-cipher-type", cipherType(repo))
+		}
+	}
+
+	if len(global) > 0 {
+		outSecret.Data[cipherConfigSecretKey] = []byte(iniGeneratedWarning +
+			iniSectionSet{"global": global}.String())
+	}
+
+	// Keep the cipher options of a data source copied by [RestoreConfig]
+	// until the cluster no longer restores from it.
+	if inCluster.Spec.DataSource != nil && len(inSecret.Data[cipherRestoreConfigSecretKey]) > 0 {
+		outSecret.Data[cipherRestoreConfigSecretKey] = append([]byte(nil),
+			inSecret.Data[cipherRestoreConfigSecretKey]...)
+	}
+
+	return nil
+}
+
+// cipherConfigurationItem returns a projection of key in the pgBackRest Secret
+// as the cipher configuration file.
+func cipherConfigurationItem(key string) corev1.KeyToPath {
+	return corev1.KeyToPath{
+		Key:  key,
+		Path: cipherConfigProjectionPath,
+
+		// The file contains passphrases; keep it from other users.
+		Mode: initialize.Int32(0o600),
+	}
+}
+
+// cipherEnvironment returns environment variables that set the cipher options
+// of repo from its passphrase Secret. pgBackRest reads options from variables
+// named for them.
+// - https://pgbackrest.org/command.html#introduction
+func cipherEnvironment(repo v1beta1.PGBackRestRepo) []corev1.EnvVar {
+	if repo.Encryption == nil || repo.Encryption.Passphrase == nil {
+		return nil
+	}
+
+	prefix := "PGBACKREST_" + strings.ToUpper(repo.Name) + "_CIPHER_"
+	return []corev1.EnvVar{
+		{
+			Name: prefix + "PASS",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: repo.Encryption.Passphrase.Name,
+					},
+					Key: repo.Encryption.Passphrase.Key,
+				},
+			},
+		},
+		{Name: prefix + "TYPE", Value: cipherType(repo)},
+	}
+}
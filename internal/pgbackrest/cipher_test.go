@@ -0,0 +1,144 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgbackrest
+
+import (
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+func TestCipherSecret(t *testing.T) {
+	cluster := new(v1beta1.PostgresCluster)
+	cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{
+		{Name: "repo1"},
+		{Name: "repo2", Encryption: &v1beta1.PGBackRestRepoEncryption{}},
+		{Name: "repo3", Encryption: &v1beta1.PGBackRestRepoEncryption{
+			Passphrase: &v1beta1.SecretKeyRef{Name: "some-secret", Key: "some-key"},
+		}},
+	}
+
+	existing := new(corev1.Secret)
+	intent := new(corev1.Secret)
+	assert.NilError(t, CipherSecret(cluster, existing, intent))
+
+	// A strong passphrase is generated only for the repository that is
+	// encrypted without one of the user's choosing.
+	pass := CipherPassphrase(cluster.Spec.Backups.PGBackRest.Repos[1], intent)
+	assert.Equal(t, len(pass), 64)
+	assert.Equal(t, string(intent.Data["repo2-cipher-pass"]), pass)
+	assert.Equal(t, len(intent.Data), 1)
+
+	t.Run("Kept", func(t *testing.T) {
+		existing := &corev1.Secret{Data: map[string][]byte{
+			"repo2-cipher-pass": []byte("two"),
+			"repo4-cipher-pass": []byte("four"),
+		}}
+
+		// Passphrases are kept, even those of repositories that were removed.
+		again := new(corev1.Secret)
+		assert.NilError(t, CipherSecret(cluster, existing, again))
+		assert.DeepEqual(t, again.Data, existing.Data)
+	})
+}
+
+func TestCipherConfiguration(t *testing.T) {
+	cluster := new(v1beta1.PostgresCluster)
+	cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{
+		{Name: "repo1"},
+		{Name: "repo2", Encryption: &v1beta1.PGBackRestRepoEncryption{}},
+		{Name: "repo3", Encryption: &v1beta1.PGBackRestRepoEncryption{
+			Passphrase: &v1beta1.SecretKeyRef{Name: "some-secret", Key: "some-key"},
+		}},
+	}
+
+	t.Run("Unencrypted", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Backups.PGBackRest.Repos = cluster.Spec.Backups.PGBackRest.Repos[:1]
+
+		intent := &corev1.Secret{Data: map[string][]byte{}}
+		assert.NilError(t, cipherConfiguration(cluster, nil, new(corev1.Secret), intent))
+		assert.Equal(t, len(intent.Data), 0)
+	})
+
+	passphrases := map[string]string{
+		"repo1": "ignored",
+		"repo2": "generated",
+		"repo3": "chosen",
+	}
+
+	existing := new(corev1.Secret)
+	intent := &corev1.Secret{Data: map[string][]byte{}}
+	assert.NilError(t, cipherConfiguration(cluster, passphrases, existing, intent))
+
+	// Passphrases are stored in their own Secret, not this one.
+	assert.Equal(t, len(intent.Data), 1)
+	assert.Equal(t, string(intent.Data["pgbackrest-cipher.conf"]), strings.Join([]string{
+		`# Generated by postgres-operator. DO NOT EDIT.`,
+		`# Your changes will not be saved.`,
+		``,
+		`[global]`,
+		`repo2-cipher-pass = generated`,
+		`repo2-cipher-type = aes-256-cbc`,
+		`repo3-cipher-pass = chosen`,
+		`repo3-cipher-type = aes-256-cbc`,
+	}, "\n")+"\n")
+
+	t.Run("MissingPassphrase", func(t *testing.T) {
+		again := &corev1.Secret{Data: map[string][]byte{}}
+		assert.NilError(t, cipherConfiguration(cluster,
+			map[string]string{"repo3": "chosen"}, existing, again))
+
+		// Repositories without a passphrase are left out.
+		assert.Assert(t, cmp.Contains(string(again.Data["pgbackrest-cipher.conf"]), "repo3-cipher-pass"))
+		assert.Assert(t, !strings.Contains(string(again.Data["pgbackrest-cipher.conf"]), "repo2"))
+	})
+
+	t.Run("DataSource", func(t *testing.T) {
+		existing := &corev1.Secret{Data: map[string][]byte{
+			"pgbackrest-restore-cipher.conf": []byte("[global]\n"),
+		}}
+
+		// The cipher options of a data source are dropped after it is removed.
+		again := &corev1.Secret{Data: map[string][]byte{}}
+		assert.NilError(t, cipherConfiguration(cluster, passphrases, existing, again))
+		assert.Assert(t, again.Data["pgbackrest-restore-cipher.conf"] == nil)
+
+		cluster := cluster.DeepCopy()
+		cluster.Spec.DataSource = &v1beta1.DataSource{
+			PostgresCluster: &v1beta1.PostgresClusterDataSource{ClusterName: "other"},
+		}
+		assert.NilError(t, cipherConfiguration(cluster, passphrases, existing, again))
+		assert.Equal(t, string(again.Data["pgbackrest-restore-cipher.conf"]), "[global]\n")
+	})
+}
+
+func TestCipherEnvironment(t *testing.T) {
+	assert.Assert(t, cipherEnvironment(v1beta1.PGBackRestRepo{Name: "repo1"}) == nil)
+	assert.Assert(t, cipherEnvironment(v1beta1.PGBackRestRepo{
+		Name: "repo1", Encryption: &v1beta1.PGBackRestRepoEncryption{},
+	}) == nil)
+
+	assert.Assert(t, cmp.MarshalMatches(cipherEnvironment(v1beta1.PGBackRestRepo{
+		Name: "repo3",
+		Encryption: &v1beta1.PGBackRestRepoEncryption{
+			CipherType: "aes-256-cbc",
+			Passphrase: &v1beta1.SecretKeyRef{Name: "some-secret", Key: "some-key"},
+		},
+	}), `
+- name: PGBACKREST_REPO3_CIPHER_PASS
+  valueFrom:
+    secretKeyRef:
+      key: some-key
+      name: some-secret
+- name: PGBACKREST_REPO3_CIPHER_TYPE
+  value: aes-256-cbc
+	`))
+}
@@ -7,7 +7,9 @@ package pgbackrest
 import (
 	"context"
 	"fmt"
+	"maps"
 	"path"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -51,6 +53,9 @@ const (
 	// repoMountPath is where to mount the pgBackRest repo volume.
 	repoMountPath = "/pgbackrest"
 
+	// spoolMountPath is where to mount a dedicated spool volume on instances.
+	spoolMountPath = "/pgbackrest-spool"
+
 	serverConfigAbsolutePath   = configDirectory + "/" + serverConfigProjectionPath
 	serverConfigProjectionPath = "~postgres-operator_server.conf"
 
@@ -104,14 +109,15 @@ func CreatePGBackRestConfigMapIntent(ctx context.Context, postgresCluster *v1bet
 	pgdataDir := postgres.DataDirectory(postgresCluster)
 	// Port will always be populated, since the API will set a default of 5432 if not provided
 	pgPort := *postgresCluster.Spec.Port
-	cm.Data[CMInstanceKey] = iniGeneratedWarning +
-		populatePGInstanceConfigurationMap(
-			serviceName, serviceNamespace, repoHostName, pgdataDir,
-			config.FetchKeyCommand(&postgresCluster.Spec),
-			strconv.Itoa(postgresCluster.Spec.PostgresVersion),
-			pgPort, postgresCluster.Spec.Backups.PGBackRest.Repos,
-			postgresCluster.Spec.Backups.PGBackRest.Global,
-		).String()
+	instance := populatePGInstanceConfigurationMap(
+		serviceName, serviceNamespace, repoHostName, pgdataDir,
+		config.FetchKeyCommand(&postgresCluster.Spec),
+		strconv.Itoa(postgresCluster.Spec.PostgresVersion),
+		pgPort, postgresCluster.Spec.Backups.PGBackRest.Repos,
+		postgresCluster.Spec.Backups.PGBackRest.Global,
+	)
+	addArchivePushOptions(instance, postgresCluster.Spec.Backups.PGBackRest.ArchivePush)
+	cm.Data[CMInstanceKey] = iniGeneratedWarning + instance.String()
 
 	// As the cluster transitions from having a repository host to having none,
 	// PostgreSQL instances that have not rolled out expect to mount a server
@@ -408,6 +414,9 @@ func populatePGInstanceConfigurationMap(
 				global.Set(option, val)
 			}
 		}
+		for option, val := range getRetentionConfigs(repo) {
+			global.Set(option, val)
+		}
 
 		// Only "volume" (i.e. PVC-based) repos should ever have a repo host configured.  This
 		// means cloud-based repos (S3, GCS or Azure) should not have a repo host configured.
@@ -443,6 +452,32 @@ func populatePGInstanceConfigurationMap(
 	}
 }
 
+// addArchivePushOptions sets the options in sections that tune how a
+// PostgreSQL instance archives WAL. These apply only to "archive-push" except
+// the spool path, which "archive-get" uses also.
+// - https://pgbackrest.org/configuration.html#section-archive
+func addArchivePushOptions(sections iniSectionSet, archive *v1beta1.PGBackRestArchivePush) {
+	if archive == nil {
+		return
+	}
+
+	push := iniMultiSet{}
+	if archive.ProcessMax != nil {
+		push.Set("process-max", fmt.Sprint(*archive.ProcessMax))
+	}
+	if archive.QueueMax != nil {
+		// pgBackRest understands sizes in bytes without a suffix.
+		push.Set("archive-push-queue-max", strconv.FormatInt(archive.QueueMax.Value(), 10))
+	}
+	if len(push) > 0 {
+		sections["global:archive-push"] = push
+	}
+
+	if archive.Spool != nil {
+		sections["global"].Set("spool-path", spoolMountPath)
+	}
+}
+
 // populateRepoHostConfigurationMap returns options representing the pgBackRest configuration for
 // a pgBackRest dedicated repository host
 func populateRepoHostConfigurationMap(
@@ -467,6 +502,9 @@ func populateRepoHostConfigurationMap(
 				global.Set(option, val)
 			}
 		}
+		for option, val := range getRetentionConfigs(repo) {
+			global.Set(option, val)
+		}
 
 		if !pgBackRestLogPathSet && repo.Volume != nil {
 			// pgBackRest will log to the first configured repo volume when commands
@@ -537,6 +575,9 @@ func populateCloudRepoConfigurationMap(
 		for option, val := range getExternalRepoConfigs(repo) {
 			global.Set(option, val)
 		}
+		for option, val := range getRetentionConfigs(repo) {
+			global.Set(option, val)
+		}
 	}
 
 	// If we are given a log path, set it in the config. Otherwise, turn off logging to file.
@@ -597,11 +638,159 @@ func getExternalRepoConfigs(repo v1beta1.PGBackRestRepo) map[string]string {
 		repoConfigs[repo.Name+"-s3-bucket"] = repo.S3.Bucket
 		repoConfigs[repo.Name+"-s3-endpoint"] = repo.S3.Endpoint
 		repoConfigs[repo.Name+"-s3-region"] = repo.S3.Region
+	} else if repo.SFTP != nil {
+		repoConfigs = sftpRepoConfigs(repo)
 	}
 
 	return repoConfigs
 }
 
+// getRetentionConfigs returns the pgBackRest options for the retention policy
+// of repo, if any.
+func getRetentionConfigs(repo v1beta1.PGBackRestRepo) map[string]string {
+	retention := repo.Retention
+	repoConfigs := make(map[string]string)
+
+	if retention == nil {
+		return repoConfigs
+	}
+	if retention.Full != nil {
+		repoConfigs[repo.Name+"-retention-full"] = fmt.Sprint(*retention.Full)
+	}
+	if retention.FullType != "" {
+		repoConfigs[repo.Name+"-retention-full-type"] = retention.FullType
+	}
+	if retention.Diff != nil {
+		repoConfigs[repo.Name+"-retention-diff"] = fmt.Sprint(*retention.Diff)
+	}
+	if retention.Archive != nil {
+		repoConfigs[repo.Name+"-retention-archive"] = fmt.Sprint(*retention.Archive)
+	}
+	if retention.ArchiveType != "" {
+		repoConfigs[repo.Name+"-retention-archive-type"] = retention.ArchiveType
+	}
+
+	return repoConfigs
+}
+
+// RetentionOptions returns command line options that apply retention to the
+// repository named repoName in place of the options in its configuration.
+// - https://pgbackrest.org/command.html#command-expire
+func RetentionOptions(repoName string, retention *v1beta1.PGBackRestRetention) []string {
+	configs := getRetentionConfigs(v1beta1.PGBackRestRepo{Name: repoName, Retention: retention})
+
+	options := make([]string, 0, len(configs))
+	for _, key := range slices.Sorted(maps.Keys(configs)) {
+		options = append(options, "--"+key+"="+configs[key])
+	}
+	return options
+}
+
+// RetentionPolicy returns the retention policy pgBackRest applies to repo
+// after considering its defaults and any options in global. It returns nil
+// when backups in repo never expire.
+// - https://pgbackrest.org/configuration.html#section-repository
+func RetentionPolicy(
+	repo v1beta1.PGBackRestRepo, global map[string]string,
+) *v1beta1.PGBackRestRetention {
+	policy := new(v1beta1.PGBackRestRetention)
+	if repo.Retention != nil {
+		repo.Retention.DeepCopyInto(policy)
+	}
+
+	// Options in global are rendered after the typed fields, so they win.
+	// Values that pgBackRest rejects are reported by [InvalidRetentionOptions].
+	number := func(option string, field **int32) {
+		if value, ok := global[repo.Name+option]; ok {
+			if i, ok := retentionNumber(value); ok {
+				*field = initialize.Int32(i)
+			}
+		}
+	}
+	number("-retention-full", &policy.Full)
+	number("-retention-diff", &policy.Diff)
+	number("-retention-archive", &policy.Archive)
+
+	if value, ok := global[repo.Name+"-retention-full-type"]; ok &&
+		slices.Contains(retentionFullTypes, value) {
+		policy.FullType = value
+	}
+	if value, ok := global[repo.Name+"-retention-archive-type"]; ok &&
+		slices.Contains(retentionArchiveTypes, value) {
+		policy.ArchiveType = value
+	}
+
+	if policy.Full == nil && policy.Diff == nil && policy.Archive == nil {
+		return nil
+	}
+
+	if policy.FullType == "" {
+		policy.FullType = "count"
+	}
+	if policy.ArchiveType == "" {
+		policy.ArchiveType = "full"
+	}
+
+	// WAL is kept for as many backups as are kept of the same type.
+	if policy.Archive == nil {
+		switch {
+		case policy.ArchiveType == "full" && policy.FullType == "count" && policy.Full != nil:
+			policy.Archive = initialize.Int32(*policy.Full)
+		case policy.ArchiveType == "diff" && policy.Diff != nil:
+			policy.Archive = initialize.Int32(*policy.Diff)
+		}
+	}
+
+	return policy
+}
+
+// These are the values pgBackRest accepts for the retention type options.
+var (
+	retentionFullTypes    = []string{"count", "time"}
+	retentionArchiveTypes = []string{"full", "diff", "incr"}
+)
+
+// retentionNumber parses value as pgBackRest parses the retention count options.
+func retentionNumber(value string) (int32, bool) {
+	i, err := strconv.ParseInt(value, 10, 32)
+	return int32(i), err == nil && i >= 1 && i <= 9999999
+}
+
+// InvalidRetentionOptions returns the retention options for repo in global
+// that pgBackRest does not accept, including those it does not know. These are
+// left out of [RetentionPolicy].
+// - https://pgbackrest.org/configuration.html#section-repository
+func InvalidRetentionOptions(repo v1beta1.PGBackRestRepo, global map[string]string) []string {
+	var invalid []string
+
+	for key, value := range global {
+		option, ok := strings.CutPrefix(key, repo.Name+"-retention")
+		if !ok {
+			continue
+		}
+
+		switch option {
+		case "-archive", "-diff", "-full":
+			_, ok = retentionNumber(value)
+		case "-archive-type":
+			ok = slices.Contains(retentionArchiveTypes, value)
+		case "-full-type":
+			ok = slices.Contains(retentionFullTypes, value)
+		case "-history":
+			i, err := strconv.ParseInt(value, 10, 32)
+			ok = err == nil && i >= 0 && i <= 9999999
+		default:
+			ok = false
+		}
+		if !ok {
+			invalid = append(invalid, key)
+		}
+	}
+
+	slices.Sort(invalid)
+	return invalid
+}
+
 // reloadCommand returns an entrypoint that convinces the pgBackRest TLS server
 // to reload its options and certificate files when they change. The process
 // will appear as name in `ps` and `top`.
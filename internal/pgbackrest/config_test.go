@@ -498,6 +498,110 @@ pg1-socket-path = /tmp/postgres
 		})
 	})
 
+	t.Run("Retention", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Backups.PGBackRest.Global = map[string]string{
+			"repo2-retention-full": "5",
+		}
+		cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{
+			{
+				Name:   "repo1",
+				Volume: &v1beta1.RepoPVC{},
+				Retention: &v1beta1.PGBackRestRetention{
+					Full: initialize.Int32(14), FullType: "time",
+					Archive: initialize.Int32(3), ArchiveType: "diff",
+				},
+			},
+			{
+				Name: "repo2",
+				S3:   &v1beta1.RepoS3{Bucket: "b", Endpoint: "e", Region: "r"},
+				Retention: &v1beta1.PGBackRestRetention{
+					Full: initialize.Int32(2), Diff: initialize.Int32(6),
+				},
+			},
+		}
+
+		configmap, err := CreatePGBackRestConfigMapIntent(context.Background(), cluster,
+			"repo-hostname", "number", "pod-service-name", "test-ns", "",
+			[]string{"some-instance"})
+		assert.NilError(t, err)
+
+		for _, key := range []string{
+			"pgbackrest_instance.conf", "pgbackrest_repo.conf",
+		} {
+			assert.Assert(t, cmp.Contains(configmap.Data[key], `
+repo1-retention-archive = 3
+repo1-retention-archive-type = diff
+repo1-retention-full = 14
+repo1-retention-full-type = time
+`), "%q", key)
+
+			// Options in global take precedence.
+			assert.Assert(t, cmp.Contains(configmap.Data[key], `
+repo2-retention-diff = 6
+repo2-retention-full = 5
+`), "%q", key)
+		}
+	})
+
+	t.Run("SFTP", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{{
+			Name: "repo3",
+			SFTP: &v1beta1.RepoSFTP{
+				Host: "sftp.example.com", Port: initialize.Int32(2222),
+				User: "backups", Path: "/srv/pgbackrest",
+				HostKeyFingerprint: "SHA256:47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU",
+			},
+		}}
+
+		configmap, err := CreatePGBackRestConfigMapIntent(context.Background(), cluster,
+			"", "number", "pod-service-name", "test-ns", "",
+			[]string{"some-instance"})
+		assert.NilError(t, err)
+
+		assert.Assert(t, cmp.Contains(configmap.Data["pgbackrest_instance.conf"], `
+repo3-path = /srv/pgbackrest
+repo3-sftp-host = sftp.example.com
+repo3-sftp-host-fingerprint = e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855
+repo3-sftp-host-key-check-type = fingerprint
+repo3-sftp-host-key-hash-type = sha256
+repo3-sftp-host-port = 2222
+repo3-sftp-host-user = backups
+repo3-sftp-private-key-file = /etc/pgbackrest/conf.d/~postgres-operator/repo3-sftp.key
+repo3-type = sftp
+`))
+	})
+
+	t.Run("ArchivePush", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Backups.PGBackRest.ArchivePush = &v1beta1.PGBackRestArchivePush{
+			ProcessMax: initialize.Int32(4),
+			QueueMax:   resource.NewQuantity(2<<30, resource.BinarySI),
+			Spool:      &v1beta1.PGBackRestSpoolVolume{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		}
+
+		configmap, err := CreatePGBackRestConfigMapIntent(context.Background(), cluster,
+			"", "number", "pod-service-name", "test-ns", "",
+			[]string{"some-instance"})
+		assert.NilError(t, err)
+
+		// Asynchronous archiving uses the dedicated spool volume.
+		assert.Assert(t, cmp.Contains(configmap.Data["pgbackrest_instance.conf"], `
+archive-async = y
+`))
+		assert.Assert(t, cmp.Contains(configmap.Data["pgbackrest_instance.conf"], `
+spool-path = /pgbackrest-spool
+`))
+
+		// Other options apply only to pushing WAL.
+		assert.Assert(t, cmp.Contains(configmap.Data["pgbackrest_instance.conf"], `
+[global:archive-push]
+archive-push-queue-max = 2147483648
+process-max = 4
+`))
+	})
+
 	t.Run("EnabledTDE", func(t *testing.T) {
 		cluster := cluster.DeepCopy()
 		cluster.Spec.Patroni = &v1beta1.PatroniSpec{
@@ -549,6 +653,117 @@ pg1-socket-path = /tmp/postgres
 	})
 }
 
+func TestRetentionPolicy(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		repo := v1beta1.PGBackRestRepo{Name: "repo1"}
+		assert.Assert(t, RetentionPolicy(repo, nil) == nil)
+
+		repo.Retention = new(v1beta1.PGBackRestRetention)
+		assert.Assert(t, RetentionPolicy(repo, map[string]string{
+			"repo2-retention-full": "2",
+		}) == nil)
+	})
+
+	t.Run("Defaults", func(t *testing.T) {
+		repo := v1beta1.PGBackRestRepo{Name: "repo1"}
+		repo.Retention = &v1beta1.PGBackRestRetention{Full: initialize.Int32(4)}
+
+		assert.DeepEqual(t, RetentionPolicy(repo, nil), &v1beta1.PGBackRestRetention{
+			Full: initialize.Int32(4), FullType: "count",
+			Archive: initialize.Int32(4), ArchiveType: "full",
+		})
+
+		// The spec is not modified.
+		assert.DeepEqual(t, repo.Retention, &v1beta1.PGBackRestRetention{
+			Full: initialize.Int32(4),
+		})
+
+		repo.Retention = &v1beta1.PGBackRestRetention{
+			Full: initialize.Int32(30), FullType: "time", Diff: initialize.Int32(2),
+		}
+		assert.DeepEqual(t, RetentionPolicy(repo, nil), &v1beta1.PGBackRestRetention{
+			Full: initialize.Int32(30), FullType: "time", Diff: initialize.Int32(2),
+			ArchiveType: "full",
+		})
+
+		repo.Retention.ArchiveType = "diff"
+		assert.DeepEqual(t, RetentionPolicy(repo, nil), &v1beta1.PGBackRestRetention{
+			Full: initialize.Int32(30), FullType: "time", Diff: initialize.Int32(2),
+			Archive: initialize.Int32(2), ArchiveType: "diff",
+		})
+	})
+
+	t.Run("Global", func(t *testing.T) {
+		repo := v1beta1.PGBackRestRepo{Name: "repo2"}
+		repo.Retention = &v1beta1.PGBackRestRetention{Full: initialize.Int32(4)}
+
+		assert.DeepEqual(t, RetentionPolicy(repo, map[string]string{
+			"repo1-retention-full":         "1",
+			"repo2-retention-full":         "7",
+			"repo2-retention-archive":      "2",
+			"repo2-retention-archive-type": "incr",
+			"repo2-retention-diff":         "many",
+		}), &v1beta1.PGBackRestRetention{
+			Full: initialize.Int32(7), FullType: "count",
+			Archive: initialize.Int32(2), ArchiveType: "incr",
+		})
+
+		repo.Retention = nil
+		assert.DeepEqual(t, RetentionPolicy(repo, map[string]string{
+			"repo2-retention-diff": "3",
+		}), &v1beta1.PGBackRestRetention{
+			Diff: initialize.Int32(3), FullType: "count", ArchiveType: "full",
+		})
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		repo := v1beta1.PGBackRestRepo{Name: "repo2"}
+		repo.Retention = &v1beta1.PGBackRestRetention{Full: initialize.Int32(4)}
+		global := map[string]string{
+			"repo1-retention-full":         "none",
+			"repo2-retention-full":         "0",
+			"repo2-retention-diff":         "many",
+			"repo2-retention-archive":      "3",
+			"repo2-retention-archive-type": "weekly",
+			"repo2-retention-history":      "30",
+			"repo2-retention-fulls":        "2",
+			"repo2-retention":              "forever",
+		}
+
+		// Invalid values do not replace those in the spec.
+		assert.DeepEqual(t, RetentionPolicy(repo, global), &v1beta1.PGBackRestRetention{
+			Full: initialize.Int32(4), FullType: "count",
+			Archive: initialize.Int32(3), ArchiveType: "full",
+		})
+		assert.DeepEqual(t, InvalidRetentionOptions(repo, global), []string{
+			"repo2-retention",
+			"repo2-retention-archive-type",
+			"repo2-retention-diff",
+			"repo2-retention-full",
+			"repo2-retention-fulls",
+		})
+
+		assert.Assert(t, InvalidRetentionOptions(repo, map[string]string{
+			"repo2-retention-full": "7", "repo2-retention-full-type": "time",
+		}) == nil)
+	})
+}
+
+func TestRetentionOptions(t *testing.T) {
+	assert.Assert(t, cmp.Len(RetentionOptions("repo1", nil), 0))
+	assert.Assert(t, cmp.Len(RetentionOptions("repo1", &v1beta1.PGBackRestRetention{}), 0))
+
+	assert.DeepEqual(t, RetentionOptions("repo3", &v1beta1.PGBackRestRetention{
+		Full: initialize.Int32(7), FullType: "time",
+		Diff: initialize.Int32(1), ArchiveType: "diff",
+	}), []string{
+		"--repo3-retention-archive-type=diff",
+		"--repo3-retention-diff=1",
+		"--repo3-retention-full=7",
+		"--repo3-retention-full-type=time",
+	})
+}
+
 func TestMakePGBackrestLogDir(t *testing.T) {
 	podTemplate := &corev1.PodTemplateSpec{Spec: corev1.PodSpec{
 		InitContainers: []corev1.Container{
@@ -0,0 +1,133 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgbackrest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+// Info is the output of "pgbackrest info" for one stanza.
+// - https://pgbackrest.org/command.html#command-info
+type Info struct {
+	Name string `json:"name"`
+
+	Archive []struct {
+		Database infoDatabase `json:"database"`
+		Max      string       `json:"max"`
+		Min      string       `json:"min"`
+	} `json:"archive"`
+
+	Backup []struct {
+		Database infoDatabase `json:"database"`
+		Label    string       `json:"label"`
+		Type     string       `json:"type"`
+
+		Archive struct {
+			Start string `json:"start"`
+			Stop  string `json:"stop"`
+		} `json:"archive"`
+
+		Info struct {
+			Size       int64 `json:"size"`
+			Repository struct {
+				Size int64 `json:"size"`
+			} `json:"repository"`
+		} `json:"info"`
+
+		Timestamp struct {
+			Start int64 `json:"start"`
+			Stop  int64 `json:"stop"`
+		} `json:"timestamp"`
+	} `json:"backup"`
+}
+
+type infoDatabase struct {
+	RepoKey int `json:"repo-key"`
+}
+
+// Info runs the pgBackRest "info" command and returns what it reports about
+// the stanza in every repository.
+func (exec Executor) Info(ctx context.Context) (*Info, error) {
+	var stdout, stderr bytes.Buffer
+	var stanzas []Info
+
+	err := exec(ctx, nil, &stdout, &stderr,
+		"pgbackrest", "info", "--output=json", "--stanza="+DefaultStanzaName)
+	if err != nil {
+		return nil, errors.WithStack(fmt.Errorf("%w: %v", err, stderr.String()))
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &stanzas); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	for i := range stanzas {
+		if stanzas[i].Name == DefaultStanzaName {
+			return &stanzas[i], nil
+		}
+	}
+	return nil, errors.Errorf("pgbackrest info: missing stanza %q", DefaultStanzaName)
+}
+
+// Catalog returns the newest backups, oldest first, and the recovery window
+// of the repository named repoName. The window is nil when there are no backups.
+func (info *Info) Catalog(repoName string, limit int) (
+	[]v1beta1.PGBackRestBackupStatus, *v1beta1.PGBackRestRecoveryWindow,
+) {
+	// pgBackRest identifies repositories by the number in their option names.
+	key, _ := strconv.Atoi(strings.TrimPrefix(repoName, "repo"))
+
+	var backups []v1beta1.PGBackRestBackupStatus
+	var window *v1beta1.PGBackRestRecoveryWindow
+
+	// pgBackRest lists backups oldest first.
+	for _, backup := range info.Backup {
+		if backup.Database.RepoKey != key {
+			continue
+		}
+		backups = append(backups, v1beta1.PGBackRestBackupStatus{
+			Label:        backup.Label,
+			Type:         backup.Type,
+			StartTime:    metav1.NewTime(time.Unix(backup.Timestamp.Start, 0).UTC()),
+			StopTime:     metav1.NewTime(time.Unix(backup.Timestamp.Stop, 0).UTC()),
+			WALStart:     backup.Archive.Start,
+			WALStop:      backup.Archive.Stop,
+			DatabaseSize: backup.Info.Size,
+			RepoSize:     backup.Info.Repository.Size,
+		})
+	}
+
+	if len(backups) > 0 {
+		window = &v1beta1.PGBackRestRecoveryWindow{
+			Start: backups[0].StopTime,
+			End:   backups[len(backups)-1].StopTime,
+		}
+		for _, archive := range info.Archive {
+			if archive.Database.RepoKey != key {
+				continue
+			}
+			if window.WALStart == "" {
+				window.WALStart = archive.Min
+			}
+			window.WALStop = archive.Max
+		}
+	}
+
+	if len(backups) > limit {
+		backups = backups[len(backups)-limit:]
+	}
+
+	return backups, window
+}
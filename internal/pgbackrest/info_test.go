@@ -0,0 +1,161 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgbackrest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+// infoOutput is an abbreviated "pgbackrest info --output=json" from pgBackRest v2.54.
+const infoOutput = `[{
+	"archive": [
+		{"database": {"id": 1, "repo-key": 1}, "id": "16-1", "max": "000000010000000000000009", "min": "000000010000000000000002"},
+		{"database": {"id": 1, "repo-key": 2}, "id": "16-1", "max": "000000010000000000000009", "min": "000000010000000000000005"}
+	],
+	"backup": [
+		{
+			"archive": {"start": "000000010000000000000002", "stop": "000000010000000000000002"},
+			"database": {"id": 1, "repo-key": 1},
+			"info": {"delta": 31457280, "repository": {"delta": 4194304, "size": 4194304}, "size": 31457280},
+			"label": "20250101-000000F",
+			"timestamp": {"start": 1735689600, "stop": 1735689660},
+			"type": "full"
+		},
+		{
+			"archive": {"start": "000000010000000000000005", "stop": "000000010000000000000005"},
+			"database": {"id": 1, "repo-key": 2},
+			"info": {"delta": 31457280, "repository": {"delta": 4194304, "size": 4194304}, "size": 31457280},
+			"label": "20250102-000000F",
+			"timestamp": {"start": 1735776000, "stop": 1735776060},
+			"type": "full"
+		},
+		{
+			"archive": {"start": "000000010000000000000007", "stop": "000000010000000000000007"},
+			"database": {"id": 1, "repo-key": 1},
+			"info": {"delta": 1048576, "repository": {"delta": 65536, "size": 4194304}, "size": 31457280},
+			"label": "20250101-000000F_20250103-000000I",
+			"timestamp": {"start": 1735862400, "stop": 1735862430},
+			"type": "incr"
+		}
+	],
+	"name": "db",
+	"status": {"code": 0, "message": "ok"}
+}]`
+
+func TestInfo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Command", func(t *testing.T) {
+		exec := func(
+			_ context.Context, _ io.Reader, stdout, _ io.Writer, command ...string,
+		) error {
+			assert.DeepEqual(t, command, []string{
+				"pgbackrest", "info", "--output=json", "--stanza=db",
+			})
+			_, err := io.WriteString(stdout, infoOutput)
+			return err
+		}
+
+		info, err := Executor(exec).Info(ctx)
+		assert.NilError(t, err)
+		assert.Equal(t, info.Name, "db")
+		assert.Equal(t, len(info.Backup), 3)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		exec := func(
+			_ context.Context, _ io.Reader, _, stderr io.Writer, _ ...string,
+		) error {
+			_, _ = io.WriteString(stderr, "ERROR: [055]: unable to load info file")
+			return errors.New("exit code 55")
+		}
+
+		_, err := Executor(exec).Info(ctx)
+		assert.ErrorContains(t, err, "exit code 55: ERROR: [055]")
+	})
+
+	t.Run("MissingStanza", func(t *testing.T) {
+		exec := func(
+			_ context.Context, _ io.Reader, stdout, _ io.Writer, _ ...string,
+		) error {
+			_, err := io.WriteString(stdout, `[]`)
+			return err
+		}
+
+		_, err := Executor(exec).Info(ctx)
+		assert.ErrorContains(t, err, "missing stanza")
+	})
+}
+
+func TestInfoCatalog(t *testing.T) {
+	var stanzas []Info
+	assert.NilError(t, json.NewDecoder(strings.NewReader(infoOutput)).Decode(&stanzas))
+	info := &stanzas[0]
+
+	unix := func(seconds int64) metav1.Time {
+		return metav1.NewTime(time.Unix(seconds, 0).UTC())
+	}
+
+	t.Run("Repo1", func(t *testing.T) {
+		backups, window := info.Catalog("repo1", 10)
+
+		assert.DeepEqual(t, backups, []v1beta1.PGBackRestBackupStatus{
+			{
+				Label:        "20250101-000000F",
+				Type:         "full",
+				StartTime:    unix(1735689600),
+				StopTime:     unix(1735689660),
+				WALStart:     "000000010000000000000002",
+				WALStop:      "000000010000000000000002",
+				DatabaseSize: 31457280,
+				RepoSize:     4194304,
+			},
+			{
+				Label:        "20250101-000000F_20250103-000000I",
+				Type:         "incr",
+				StartTime:    unix(1735862400),
+				StopTime:     unix(1735862430),
+				WALStart:     "000000010000000000000007",
+				WALStop:      "000000010000000000000007",
+				DatabaseSize: 31457280,
+				RepoSize:     4194304,
+			},
+		})
+		assert.DeepEqual(t, window, &v1beta1.PGBackRestRecoveryWindow{
+			Start:    unix(1735689660),
+			End:      unix(1735862430),
+			WALStart: "000000010000000000000002",
+			WALStop:  "000000010000000000000009",
+		})
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		backups, window := info.Catalog("repo1", 1)
+
+		assert.Equal(t, len(backups), 1)
+		assert.Equal(t, backups[0].Label, "20250101-000000F_20250103-000000I")
+
+		// The window still includes backups that are not listed.
+		assert.DeepEqual(t, window.Start, unix(1735689660))
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		backups, window := info.Catalog("repo3", 10)
+
+		assert.Assert(t, backups == nil)
+		assert.Assert(t, window == nil)
+	})
+}
@@ -115,6 +115,11 @@ func AddConfigToInstancePod(
 	secret.Secret.Items = append(secret.Secret.Items, clientCertificates()...)
 	secret.Secret.Optional = initialize.Bool(true)
 
+	if encrypted(cluster) {
+		secret.Secret.Items = append(secret.Secret.Items,
+			cipherConfigurationItem(cipherConfigSecretKey))
+	}
+
 	// Start with a copy of projections specified in the cluster. Items later in
 	// the list take precedence over earlier items (that is, last write wins).
 	// - https://kubernetes.io/docs/concepts/storage/volumes/#projected
@@ -127,7 +132,13 @@ func AddConfigToInstancePod(
 		sources = append(sources, configmap)
 	}
 
+	sources = append(sources, sftpPrivateKeys(cluster.Spec.Backups.PGBackRest.Repos...)...)
+
 	addConfigVolumeAndMounts(pod, sources)
+
+	if archive := cluster.Spec.Backups.PGBackRest.ArchivePush; archive != nil && archive.Spool != nil {
+		addSpoolVolumeAndMounts(pod, archive.Spool)
+	}
 }
 
 // AddConfigToRepoPod adds and mounts the pgBackRest configuration volumes for
@@ -148,13 +159,19 @@ func AddConfigToRepoPod(
 	secret.Secret.Name = naming.PGBackRestSecret(cluster).Name
 	secret.Secret.Items = append(secret.Secret.Items, clientCertificates()...)
 
+	if encrypted(cluster) {
+		secret.Secret.Items = append(secret.Secret.Items,
+			cipherConfigurationItem(cipherConfigSecretKey))
+	}
+
 	// Start with a copy of projections specified in the cluster. Items later in
 	// the list take precedence over earlier items (that is, last write wins).
 	// - https://kubernetes.io/docs/concepts/storage/volumes/#projected
 	sources := append([]corev1.VolumeProjection{},
 		cluster.Spec.Backups.PGBackRest.Configuration...)
 
-	addConfigVolumeAndMounts(pod, append(sources, configmap, secret))
+	addConfigVolumeAndMounts(pod, append(append(sources, configmap, secret),
+		sftpPrivateKeys(cluster.Spec.Backups.PGBackRest.Repos...)...))
 }
 
 // AddConfigToCloudBackupJob adds and mounts the pgBackRest configuration volumes
@@ -172,13 +189,19 @@ func AddConfigToCloudBackupJob(
 	secret.Secret.Name = naming.PGBackRestSecret(cluster).Name
 	secret.Secret.Items = append(secret.Secret.Items, clientCertificates()...)
 
+	if encrypted(cluster) {
+		secret.Secret.Items = append(secret.Secret.Items,
+			cipherConfigurationItem(cipherConfigSecretKey))
+	}
+
 	// Start with a copy of projections specified in the cluster. Items later in
 	// the list take precedence over earlier items (that is, last write wins).
 	// - https://kubernetes.io/docs/concepts/storage/volumes/#projected
 	sources := append([]corev1.VolumeProjection{},
 		cluster.Spec.Backups.PGBackRest.Configuration...)
 
-	addConfigVolumeAndMounts(&podTemplateSpec.Spec, append(sources, configmap, secret))
+	addConfigVolumeAndMounts(&podTemplateSpec.Spec, append(append(sources, configmap, secret),
+		sftpPrivateKeys(cluster.Spec.Backups.PGBackRest.Repos...)...))
 
 	// Add tmp directory for pgbackrest lock files
 	AddTMPEmptyDir(podTemplateSpec)
@@ -209,6 +232,16 @@ func AddConfigToRestorePod(
 	secret.Secret.Items = append(secret.Secret.Items, clientCertificates()...)
 	secret.Secret.Optional = initialize.Bool(true)
 
+	// Mount the cipher options of the source cluster. Those of another cluster
+	// are copied by [RestoreConfig].
+	if sourceCluster != nil && encrypted(sourceCluster) {
+		key := cipherRestoreConfigSecretKey
+		if sourceCluster.Name == cluster.Name && sourceCluster.Namespace == cluster.Namespace {
+			key = cipherConfigSecretKey
+		}
+		secret.Secret.Items = append(secret.Secret.Items, cipherConfigurationItem(key))
+	}
+
 	// Start with a copy of projections specified in the cluster. Items later in
 	// the list take precedence over earlier items (that is, last write wins).
 	// - https://kubernetes.io/docs/concepts/storage/volumes/#projected
@@ -219,6 +252,7 @@ func AddConfigToRestorePod(
 	// the source cluster for the restore.
 	if sourceCluster != nil {
 		sources = append(sources, sourceCluster.Spec.Backups.PGBackRest.Configuration...)
+		sources = append(sources, sftpPrivateKeys(sourceCluster.Spec.Backups.PGBackRest.Repos...)...)
 	}
 
 	// Currently the spec accepts a dataSource with both a PostgresCluster and
@@ -237,6 +271,19 @@ func AddConfigToRestorePod(
 
 		sources = append([]corev1.VolumeProjection{},
 			cluster.Spec.DataSource.PGBackRest.Configuration...)
+		sources = append(sources, sftpPrivateKeys(cluster.Spec.DataSource.PGBackRest.Repo)...)
+
+		// The passphrase of an encrypted data source is in a Secret of the
+		// user's choosing; pass it to pgBackRest in the environment.
+		if env := cipherEnvironment(cluster.Spec.DataSource.PGBackRest.Repo); len(env) > 0 {
+			for i := range pod.Containers {
+				container := &pod.Containers[i]
+
+				if container.Name == naming.PGBackRestRestoreContainerName {
+					container.Env = append(container.Env, env...)
+				}
+			}
+		}
 	}
 
 	// mount any provided configuration files to the restore Job Pod
@@ -295,6 +342,39 @@ func addConfigVolumeAndMounts(
 	pod.Volumes = append(pod.Volumes, configVolume)
 }
 
+// addSpoolVolumeAndMounts adds spool as the pgBackRest spool volume to pod. It
+// mounts that volume to the database container where PostgreSQL archives WAL.
+func addSpoolVolumeAndMounts(pod *corev1.PodSpec, spool *v1beta1.PGBackRestSpoolVolume) {
+	spoolVolumeMount := corev1.VolumeMount{
+		Name:      "pgbackrest-spool",
+		MountPath: spoolMountPath,
+	}
+
+	spoolVolume := corev1.Volume{Name: spoolVolumeMount.Name}
+	if spool.VolumeClaimSpec != nil {
+		spoolVolume.Ephemeral = &corev1.EphemeralVolumeSource{
+			VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+				Spec: spool.VolumeClaimSpec.AsPersistentVolumeClaimSpec(),
+			},
+		}
+	} else {
+		spoolVolume.EmptyDir = new(corev1.EmptyDirVolumeSource)
+		if spool.EmptyDir != nil {
+			spool.EmptyDir.DeepCopyInto(spoolVolume.EmptyDir)
+		}
+	}
+
+	for i := range pod.Containers {
+		container := &pod.Containers[i]
+
+		if container.Name == naming.ContainerDatabase {
+			container.VolumeMounts = append(container.VolumeMounts, spoolVolumeMount)
+		}
+	}
+
+	pod.Volumes = append(pod.Volumes, spoolVolume)
+}
+
 // addServerContainerAndVolume adds the TLS server container and certificate
 // projections to pod. Any PostgreSQL data and WAL volumes in pod are also mounted.
 func addServerContainerAndVolume(
@@ -521,14 +601,22 @@ func RestoreConfig(
 		for _, item := range clientCertificates() {
 			targetSecret.Data[item.Key] = bytesClone(sourceSecret.Data[item.Key])
 		}
+
+		// Use the cipher options of the source cluster's encrypted repositories.
+		if len(sourceSecret.Data[cipherConfigSecretKey]) > 0 {
+			targetSecret.Data[cipherRestoreConfigSecretKey] =
+				bytesClone(sourceSecret.Data[cipherConfigSecretKey])
+		}
 	}
 }
 
-// Secret populates the pgBackRest Secret.
+// Secret populates the pgBackRest Secret. The passphrases of encrypted
+// repositories are in inPassphrases by repository name.
 func Secret(ctx context.Context,
 	inCluster *v1beta1.PostgresCluster,
 	inRepoHost *appsv1.StatefulSet,
 	inRoot *pki.RootCertificateAuthority,
+	inPassphrases map[string]string,
 	inSecret *corev1.Secret,
 	outSecret *corev1.Secret,
 ) error {
@@ -558,7 +646,7 @@ func Secret(ctx context.Context,
 	}
 
 	if err == nil {
-		outSecret.Data[certAuthoritySecretKey], err = certFile(inRoot.Certificate)
+		outSecret.Data[certAuthoritySecretKey], err = certFile(inRoot.Trust())
 	}
 	if err == nil {
 		outSecret.Data[certClientPrivateKeySecretKey], err = certFile(leaf.PrivateKey)
@@ -594,5 +682,10 @@ func Secret(ctx context.Context,
 		}
 	}
 
+	// Set the passphrase of each encrypted repository.
+	if err == nil {
+		err = cipherConfiguration(inCluster, inPassphrases, inSecret, outSecret)
+	}
+
 	return err
 }
@@ -324,6 +324,90 @@ func TestAddConfigToInstancePod(t *testing.T) {
         optional: true
 		`))
 	})
+
+	t.Run("SFTPRepo", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{
+			{
+				Name: "repo1",
+				SFTP: &v1beta1.RepoSFTP{
+					PrivateKey: v1beta1.SecretKeyRef{Name: "sftp-keys", Key: "id_ed25519"},
+				},
+			},
+		}
+
+		out := pod.DeepCopy()
+		AddConfigToInstancePod(cluster, out)
+		alwaysExpect(t, out)
+
+		// The private key is projected after the operator's configuration.
+		assert.Assert(t, cmp.MarshalContains(out.Volumes, `
+        name: hippo-pgbackrest
+        optional: true
+    - secret:
+        items:
+        - key: id_ed25519
+          mode: 384
+          path: ~postgres-operator/repo1-sftp.key
+        name: sftp-keys
+`))
+	})
+
+	t.Run("SpoolVolume", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Backups.PGBackRest.ArchivePush = &v1beta1.PGBackRestArchivePush{
+			Spool: &v1beta1.PGBackRestSpoolVolume{
+				VolumeClaimSpec: &v1beta1.VolumeClaimSpec{
+					AccessModes: []corev1.PersistentVolumeAccessMode{"ReadWriteOnce"},
+					Resources: corev1.VolumeResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: resource.MustParse("1Gi"),
+						},
+					},
+				},
+			},
+		}
+
+		out := pod.DeepCopy()
+		AddConfigToInstancePod(cluster, out)
+
+		// Only the database container mounts the spool.
+		assert.Assert(t, cmp.MarshalContains(out.Containers[0].VolumeMounts, `
+- mountPath: /pgbackrest-spool
+  name: pgbackrest-spool
+`))
+		assert.Assert(t, cmp.MarshalMatches(out.Containers[2].VolumeMounts, `
+- mountPath: /etc/pgbackrest/conf.d
+  name: pgbackrest-config
+  readOnly: true
+		`))
+
+		assert.Assert(t, cmp.MarshalContains(out.Volumes, `
+- ephemeral:
+    volumeClaimTemplate:
+      metadata:
+        creationTimestamp: null
+      spec:
+        accessModes:
+        - ReadWriteOnce
+        resources:
+          requests:
+            storage: 1Gi
+  name: pgbackrest-spool
+`))
+
+		cluster.Spec.Backups.PGBackRest.ArchivePush.Spool = &v1beta1.PGBackRestSpoolVolume{
+			EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory},
+		}
+
+		out = pod.DeepCopy()
+		AddConfigToInstancePod(cluster, out)
+		assert.Assert(t, cmp.MarshalContains(out.Volumes, `
+- emptyDir:
+    medium: Memory
+  name: pgbackrest-spool
+`))
+	})
 }
 
 func TestAddConfigToRepoPod(t *testing.T) {
@@ -644,6 +728,108 @@ func TestAddConfigToRestorePod(t *testing.T) {
         optional: true
 		`))
 	})
+
+	t.Run("EncryptedSourceCluster", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Name = "target"
+
+		sourceCluster := cluster.DeepCopy()
+		sourceCluster.Name = "source"
+		sourceCluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{{
+			Name:       "repo1",
+			Encryption: &v1beta1.PGBackRestRepoEncryption{},
+		}}
+
+		out := pod.DeepCopy()
+		AddConfigToRestorePod(cluster, sourceCluster, out)
+		alwaysExpect(t, out)
+
+		// The cipher options of the source cluster are copied to the target.
+		assert.Assert(t, cmp.MarshalContains(out.Volumes, `
+    - secret:
+        items:
+        - key: pgbackrest.ca-roots
+          path: ~postgres-operator/tls-ca.crt
+        - key: pgbackrest-client.crt
+          path: ~postgres-operator/client-tls.crt
+        - key: pgbackrest-client.key
+          mode: 384
+          path: ~postgres-operator/client-tls.key
+        - key: pgbackrest-restore-cipher.conf
+          mode: 384
+          path: ~postgres-operator_cipher.conf
+        name: target-pgbackrest
+        optional: true
+`))
+
+		// The cipher options of an in-place restore are those of the cluster.
+		out = pod.DeepCopy()
+		AddConfigToRestorePod(sourceCluster, sourceCluster.DeepCopy(), out)
+		assert.Assert(t, cmp.MarshalContains(out.Volumes, `
+        - key: pgbackrest-cipher.conf
+          mode: 384
+          path: ~postgres-operator_cipher.conf
+        name: source-pgbackrest
+`))
+	})
+
+	t.Run("EncryptedCloudBasedDataSource", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.DataSource = &v1beta1.DataSource{
+			PGBackRest: &v1beta1.PGBackRestDataSource{
+				Repo: v1beta1.PGBackRestRepo{
+					Name: "repo2",
+					Encryption: &v1beta1.PGBackRestRepoEncryption{
+						Passphrase: &v1beta1.SecretKeyRef{Name: "old-pgbackrest", Key: "repo2-cipher-pass"},
+					},
+				},
+			},
+		}
+
+		out := &corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "pgbackrest-restore"}},
+		}
+		AddConfigToRestorePod(cluster, nil, out)
+
+		// The passphrase is passed through the environment.
+		assert.Assert(t, cmp.MarshalMatches(out.Containers[0].Env, `
+- name: PGBACKREST_REPO2_CIPHER_PASS
+  valueFrom:
+    secretKeyRef:
+      key: repo2-cipher-pass
+      name: old-pgbackrest
+- name: PGBACKREST_REPO2_CIPHER_TYPE
+  value: aes-256-cbc
+		`))
+	})
+
+	t.Run("SFTPCloudBasedDataSource", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.DataSource = &v1beta1.DataSource{
+			PGBackRest: &v1beta1.PGBackRestDataSource{
+				Repo: v1beta1.PGBackRestRepo{
+					Name: "repo3",
+					SFTP: &v1beta1.RepoSFTP{
+						PrivateKey: v1beta1.SecretKeyRef{Name: "sftp-keys", Key: "id_rsa"},
+					},
+				},
+			},
+		}
+
+		out := pod.DeepCopy()
+		AddConfigToRestorePod(cluster, nil, out)
+		alwaysExpect(t, out)
+
+		// The private key of the data source is projected.
+		assert.Assert(t, cmp.MarshalContains(out.Volumes, `
+    - secret:
+        items:
+        - key: id_rsa
+          mode: 384
+          path: ~postgres-operator/repo3-sftp.key
+        name: sftp-keys
+`))
+	})
 }
 
 func TestAddServerToInstancePod(t *testing.T) {
@@ -1109,7 +1295,7 @@ func TestSecret(t *testing.T) {
 
 	t.Run("NoRepoHost", func(t *testing.T) {
 		// We always add the pgbackrest server certs
-		assert.NilError(t, Secret(ctx, cluster, nil, root, existing, intent))
+		assert.NilError(t, Secret(ctx, cluster, nil, root, nil, existing, intent))
 		assert.Assert(t, len(intent.Data["pgbackrest-client.crt"]) > 0)
 		assert.Assert(t, len(intent.Data["pgbackrest-client.key"]) > 0)
 		assert.Assert(t, len(intent.Data["pgbackrest.ca-roots"]) > 0)
@@ -1124,7 +1310,7 @@ func TestSecret(t *testing.T) {
 
 	// The existing Secret does not change.
 	constant := existing.DeepCopy()
-	assert.NilError(t, Secret(ctx, cluster, host, root, existing, intent))
+	assert.NilError(t, Secret(ctx, cluster, host, root, nil, existing, intent))
 	assert.DeepEqual(t, constant, existing)
 
 	// There is a leaf certificate and private key for the repository host.
@@ -1142,14 +1328,14 @@ func TestSecret(t *testing.T) {
 	// Assuming the intent is written, no change when called again.
 	existing.Data = intent.Data
 	before := intent.DeepCopy()
-	assert.NilError(t, Secret(ctx, cluster, host, root, existing, intent))
+	assert.NilError(t, Secret(ctx, cluster, host, root, nil, existing, intent))
 	assert.DeepEqual(t, before, intent)
 
 	t.Run("Rotation", func(t *testing.T) {
 		// The leaf certificate is regenerated when the root authority changes.
 		root2, err := pki.NewRootCertificateAuthority()
 		assert.NilError(t, err)
-		assert.NilError(t, Secret(ctx, cluster, host, root2, existing, intent))
+		assert.NilError(t, Secret(ctx, cluster, host, root2, nil, existing, intent))
 
 		leaf2 := &pki.LeafCertificate{}
 		assert.NilError(t, leaf2.Certificate.UnmarshalText(intent.Data["pgbackrest-repo-host.crt"]))
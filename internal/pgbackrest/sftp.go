@@ -0,0 +1,86 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgbackrest
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+// sftpPrivateKeyProjectionPath returns the path of the private key of the SFTP
+// repository named repoName in the configuration volume. Files in subdirectories
+// are not loaded by pgBackRest as configuration.
+func sftpPrivateKeyProjectionPath(repoName string) string {
+	return "~postgres-operator/" + repoName + "-sftp.key"
+}
+
+// sftpHostFingerprint converts an OpenSSH fingerprint, such as the output of
+// "ssh-keygen -l", to the hexadecimal form pgBackRest compares to the hash of
+// the host key. It returns an empty string when fingerprint is not SHA256.
+// - https://pgbackrest.org/configuration.html#section-repository/option-repo-sftp-host-fingerprint
+func sftpHostFingerprint(fingerprint string) string {
+	encoded, found := strings.CutPrefix(fingerprint, "SHA256:")
+	if !found {
+		return ""
+	}
+
+	// OpenSSH prints the digest in base64 without padding.
+	digest, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		return ""
+	}
+	return hex.EncodeToString(digest)
+}
+
+// sftpRepoConfigs returns the pgBackRest options of the SFTP repository repo.
+func sftpRepoConfigs(repo v1beta1.PGBackRestRepo) map[string]string {
+	options := map[string]string{
+		repo.Name + "-type":                     "sftp",
+		repo.Name + "-sftp-host":                repo.SFTP.Host,
+		repo.Name + "-sftp-host-user":           repo.SFTP.User,
+		repo.Name + "-sftp-host-key-check-type": "fingerprint",
+		repo.Name + "-sftp-host-key-hash-type":  "sha256",
+		repo.Name + "-sftp-host-fingerprint":    sftpHostFingerprint(repo.SFTP.HostKeyFingerprint),
+		repo.Name + "-sftp-private-key-file": configDirectory + "/" +
+			sftpPrivateKeyProjectionPath(repo.Name),
+	}
+
+	if repo.SFTP.Port != nil {
+		options[repo.Name+"-sftp-host-port"] = fmt.Sprint(*repo.SFTP.Port)
+	}
+	if repo.SFTP.Path != "" {
+		options[repo.Name+"-path"] = repo.SFTP.Path
+	}
+
+	return options
+}
+
+// sftpPrivateKeys returns projections of the private keys of SFTP repositories
+// to include in a configuration volume.
+func sftpPrivateKeys(repos ...v1beta1.PGBackRestRepo) []corev1.VolumeProjection {
+	var projections []corev1.VolumeProjection
+
+	for _, repo := range repos {
+		if repo.SFTP == nil {
+			continue
+		}
+
+		secret := repo.SFTP.PrivateKey.AsProjection(sftpPrivateKeyProjectionPath(repo.Name))
+
+		// Keep the private key from other users.
+		secret.Items[0].Mode = initialize.Int32(0o600)
+
+		projections = append(projections, corev1.VolumeProjection{Secret: &secret})
+	}
+
+	return projections
+}
@@ -0,0 +1,153 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgbackrest
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+	"gotest.tools/v3/assert"
+
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+// sftpServerHostKey starts an SSH server on the loopback interface, connects
+// to it, and returns the host key it presented.
+func sftpServerHostKey(t *testing.T) ssh.PublicKey {
+	t.Helper()
+
+	_, hostPrivate, err := ed25519.GenerateKey(rand.Reader)
+	assert.NilError(t, err)
+	hostSigner, err := ssh.NewSignerFromKey(hostPrivate)
+	assert.NilError(t, err)
+
+	_, clientPrivate, err := ed25519.GenerateKey(rand.Reader)
+	assert.NilError(t, err)
+	clientSigner, err := ssh.NewSignerFromKey(clientPrivate)
+	assert.NilError(t, err)
+
+	server := &ssh.ServerConfig{
+		PublicKeyCallback: func(ssh.ConnMetadata, ssh.PublicKey) (*ssh.Permissions, error) {
+			return nil, nil
+		},
+	}
+	server.AddHostKey(hostSigner)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		if sc, channels, requests, err := ssh.NewServerConn(conn, server); err == nil {
+			go ssh.DiscardRequests(requests)
+			for channel := range channels {
+				_ = channel.Reject(ssh.Prohibited, "no sessions")
+			}
+			_ = sc.Close()
+		}
+	}()
+
+	var presented ssh.PublicKey
+	client, err := ssh.Dial("tcp", listener.Addr().String(), &ssh.ClientConfig{
+		User: "pgbackrest",
+		Auth: []ssh.AuthMethod{ssh.PublicKeys(clientSigner)},
+		HostKeyCallback: func(_ string, _ net.Addr, key ssh.PublicKey) error {
+			presented = key
+			return nil
+		},
+	})
+	assert.NilError(t, err)
+	assert.NilError(t, client.Close())
+
+	assert.Assert(t, presented != nil)
+	return presented
+}
+
+func TestSFTPHostFingerprint(t *testing.T) {
+	t.Run("Server", func(t *testing.T) {
+		key := sftpServerHostKey(t)
+
+		// pgBackRest compares the hash of the key the server presents.
+		digest := sha256.Sum256(key.Marshal())
+
+		assert.Equal(t, sftpHostFingerprint(ssh.FingerprintSHA256(key)),
+			hex.EncodeToString(digest[:]))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Equal(t, sftpHostFingerprint(""), "")
+		assert.Equal(t, sftpHostFingerprint("MD5:00:11:22"), "")
+		assert.Equal(t, sftpHostFingerprint("SHA256:!!!"), "")
+	})
+}
+
+func TestSFTPRepoConfigs(t *testing.T) {
+	key := sftpServerHostKey(t)
+	digest := sha256.Sum256(key.Marshal())
+
+	repo := v1beta1.PGBackRestRepo{
+		Name: "repo2",
+		SFTP: &v1beta1.RepoSFTP{
+			Host:               "sftp.example.com",
+			User:               "backups",
+			HostKeyFingerprint: ssh.FingerprintSHA256(key),
+			PrivateKey:         v1beta1.SecretKeyRef{Name: "some-secret", Key: "id_ed25519"},
+		},
+	}
+
+	assert.DeepEqual(t, sftpRepoConfigs(repo), map[string]string{
+		"repo2-type":                     "sftp",
+		"repo2-sftp-host":                "sftp.example.com",
+		"repo2-sftp-host-user":           "backups",
+		"repo2-sftp-host-key-check-type": "fingerprint",
+		"repo2-sftp-host-key-hash-type":  "sha256",
+		"repo2-sftp-host-fingerprint":    hex.EncodeToString(digest[:]),
+		"repo2-sftp-private-key-file":    "/etc/pgbackrest/conf.d/~postgres-operator/repo2-sftp.key",
+	})
+
+	t.Run("PortAndPath", func(t *testing.T) {
+		repo := *repo.DeepCopy()
+		repo.SFTP.Port = initialize.Int32(2222)
+		repo.SFTP.Path = "/srv/pgbackrest"
+
+		options := sftpRepoConfigs(repo)
+		assert.Equal(t, options["repo2-sftp-host-port"], "2222")
+		assert.Equal(t, options["repo2-path"], "/srv/pgbackrest")
+	})
+}
+
+func TestSFTPPrivateKeys(t *testing.T) {
+	assert.Assert(t, cmp.Len(sftpPrivateKeys(), 0))
+	assert.Assert(t, cmp.Len(sftpPrivateKeys(v1beta1.PGBackRestRepo{Name: "repo1"}), 0))
+
+	projections := sftpPrivateKeys(
+		v1beta1.PGBackRestRepo{Name: "repo1"},
+		v1beta1.PGBackRestRepo{Name: "repo2", SFTP: &v1beta1.RepoSFTP{
+			PrivateKey: v1beta1.SecretKeyRef{Name: "some-secret", Key: "id_ed25519"},
+		}},
+	)
+
+	assert.Assert(t, cmp.MarshalMatches(projections, `
+- secret:
+    items:
+    - key: id_ed25519
+      mode: 384
+      path: ~postgres-operator/repo2-sftp.key
+    name: some-secret
+	`))
+}
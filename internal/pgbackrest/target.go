@@ -0,0 +1,121 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgbackrest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+// RecoveryTargetOptions returns the "pgbackrest restore" options that stop
+// recovery at target. Values are quoted for the Bash in [RestoreCommand].
+// - https://pgbackrest.org/command.html#command-restore
+func RecoveryTargetOptions(target *v1beta1.PGBackRestRecoveryTarget) []string {
+	if target == nil {
+		return nil
+	}
+
+	var options []string
+	switch {
+	case target.Time != nil:
+		// PostgreSQL parses this using the rules for "timestamp with time zone".
+		// - https://www.postgresql.org/docs/current/datatype-datetime.html
+		options = append(options, "--type=time", fmt.Sprintf(`--target="%s"`,
+			target.Time.UTC().Format("2006-01-02 15:04:05+00")))
+	case target.LSN != "":
+		options = append(options, "--type=lsn", "--target="+target.LSN)
+	case target.TransactionID != nil:
+		options = append(options, "--type=xid",
+			"--target="+strconv.FormatInt(*target.TransactionID, 10))
+	case target.Name != "":
+		options = append(options, "--type=name", "--target='"+target.Name+"'")
+	}
+
+	if target.Exclusive != nil && *target.Exclusive {
+		options = append(options, "--target-exclusive")
+	}
+	if target.Timeline != "" {
+		options = append(options, "--target-timeline="+target.Timeline)
+	}
+
+	return options
+}
+
+// CheckRecoveryTarget returns an error when target is outside what window can
+// restore as of now. It cannot check transaction IDs nor restore point names,
+// and it returns nil when window is nil.
+func CheckRecoveryTarget(
+	target *v1beta1.PGBackRestRecoveryTarget, window *v1beta1.PGBackRestRecoveryWindow, now time.Time,
+) error {
+	if target == nil || window == nil {
+		return nil
+	}
+
+	if target.Time != nil {
+		switch {
+		case target.Time.Before(&window.Start):
+			return errors.Errorf("target time %s is before the oldest backup finished at %s",
+				target.Time.UTC().Format(time.RFC3339), window.Start.UTC().Format(time.RFC3339))
+		case target.Time.After(now):
+			return errors.Errorf("target time %s is in the future",
+				target.Time.UTC().Format(time.RFC3339))
+		}
+	}
+
+	if target.LSN != "" && window.WALStart != "" {
+		lsn, err := parseLSN(target.LSN)
+		start, ok := walSegmentLSN(window.WALStart)
+
+		if err == nil && ok && lsn < start {
+			return errors.Errorf("target LSN %s is before the oldest WAL segment %s",
+				target.LSN, window.WALStart)
+		}
+	}
+
+	return nil
+}
+
+// parseLSN returns the position of a PostgreSQL "pg_lsn" value.
+func parseLSN(value string) (uint64, error) {
+	high, low, found := strings.Cut(value, "/")
+	if !found {
+		return 0, errors.Errorf("invalid LSN %q", value)
+	}
+
+	h, err := strconv.ParseUint(high, 16, 32)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	l, err := strconv.ParseUint(low, 16, 32)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return h<<32 | l, nil
+}
+
+// walSegmentLSN returns the position at which a WAL segment begins, assuming
+// the default segment size of 16MiB. It returns false when segment is not the
+// name of a WAL segment.
+// - https://www.postgresql.org/docs/current/wal-internals.html
+func walSegmentLSN(segment string) (uint64, bool) {
+	if len(segment) < 24 {
+		return 0, false
+	}
+
+	// The name is the timeline, the high 32 bits of the position, and the
+	// segment number within those bits.
+	log, err1 := strconv.ParseUint(segment[8:16], 16, 32)
+	seg, err2 := strconv.ParseUint(segment[16:24], 16, 32)
+	if err1 != nil || err2 != nil {
+		return 0, false
+	}
+	return log<<32 | seg<<24, true
+}
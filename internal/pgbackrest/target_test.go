@@ -0,0 +1,103 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgbackrest
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+func TestRecoveryTargetOptions(t *testing.T) {
+	assert.Assert(t, RecoveryTargetOptions(nil) == nil)
+
+	at := metav1.NewTime(time.Date(2025, 1, 2, 10, 4, 5, 0, time.FixedZone("", -5*60*60)))
+
+	for _, tt := range []struct {
+		target   v1beta1.PGBackRestRecoveryTarget
+		expected []string
+	}{
+		{
+			target:   v1beta1.PGBackRestRecoveryTarget{Time: &at},
+			expected: []string{"--type=time", `--target="2025-01-02 15:04:05+00"`},
+		},
+		{
+			target:   v1beta1.PGBackRestRecoveryTarget{LSN: "0/3000060", Exclusive: initialize.Bool(true)},
+			expected: []string{"--type=lsn", "--target=0/3000060", "--target-exclusive"},
+		},
+		{
+			target:   v1beta1.PGBackRestRecoveryTarget{TransactionID: initialize.Pointer(int64(7890))},
+			expected: []string{"--type=xid", "--target=7890"},
+		},
+		{
+			target:   v1beta1.PGBackRestRecoveryTarget{Name: "before-upgrade", Timeline: "current"},
+			expected: []string{"--type=name", "--target='before-upgrade'", "--target-timeline=current"},
+		},
+		{
+			target:   v1beta1.PGBackRestRecoveryTarget{Timeline: "3", Exclusive: initialize.Bool(false)},
+			expected: []string{"--target-timeline=3"},
+		},
+	} {
+		assert.DeepEqual(t, RecoveryTargetOptions(&tt.target), tt.expected)
+	}
+}
+
+func TestCheckRecoveryTarget(t *testing.T) {
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	window := &v1beta1.PGBackRestRecoveryWindow{
+		Start:    metav1.NewTime(time.Date(2025, 1, 1, 0, 1, 0, 0, time.UTC)),
+		End:      metav1.NewTime(time.Date(2025, 1, 3, 0, 0, 30, 0, time.UTC)),
+		WALStart: "000000010000000000000002",
+		WALStop:  "000000010000000000000009",
+	}
+	timeAt := func(t time.Time) *v1beta1.PGBackRestRecoveryTarget {
+		return &v1beta1.PGBackRestRecoveryTarget{Time: &metav1.Time{Time: t}}
+	}
+
+	assert.NilError(t, CheckRecoveryTarget(nil, window, now))
+	assert.NilError(t, CheckRecoveryTarget(timeAt(time.Time{}), nil, now))
+
+	t.Run("Time", func(t *testing.T) {
+		assert.NilError(t, CheckRecoveryTarget(timeAt(window.Start.Time), window, now))
+
+		// Recovery can continue past the newest backup using archived WAL.
+		assert.NilError(t, CheckRecoveryTarget(timeAt(now.Add(-time.Hour)), window, now))
+
+		assert.ErrorContains(t,
+			CheckRecoveryTarget(timeAt(window.Start.Add(-time.Second)), window, now),
+			"target time 2025-01-01T00:00:59Z is before the oldest backup finished at 2025-01-01T00:01:00Z")
+
+		assert.ErrorContains(t,
+			CheckRecoveryTarget(timeAt(now.Add(time.Minute)), window, now),
+			"target time 2025-01-10T00:01:00Z is in the future")
+	})
+
+	t.Run("LSN", func(t *testing.T) {
+		lsn := func(value string) *v1beta1.PGBackRestRecoveryTarget {
+			return &v1beta1.PGBackRestRecoveryTarget{LSN: value}
+		}
+
+		assert.NilError(t, CheckRecoveryTarget(lsn("0/2000000"), window, now))
+		assert.NilError(t, CheckRecoveryTarget(lsn("0/9FFFFFF"), window, now))
+		assert.NilError(t, CheckRecoveryTarget(lsn("1/0"), window, now))
+
+		assert.ErrorContains(t, CheckRecoveryTarget(lsn("0/1FFFFFF"), window, now),
+			"target LSN 0/1FFFFFF is before the oldest WAL segment 000000010000000000000002")
+	})
+
+	t.Run("Unchecked", func(t *testing.T) {
+		assert.NilError(t, CheckRecoveryTarget(&v1beta1.PGBackRestRecoveryTarget{
+			TransactionID: initialize.Pointer(int64(3)),
+		}, window, now))
+		assert.NilError(t, CheckRecoveryTarget(&v1beta1.PGBackRestRecoveryTarget{
+			Name: "anything",
+		}, window, now))
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/rand"
 
+	"github.com/crunchydata/postgres-operator/internal/initialize"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
@@ -80,6 +81,10 @@ func CalculateConfigHashes(
 		case repo.S3 != nil:
 			hash, err = hashFunc([]string{repo.S3.Bucket, repo.S3.Endpoint, repo.S3.Region})
 			name = repo.Name
+		case repo.SFTP != nil:
+			hash, err = hashFunc([]string{repo.SFTP.Host, fmt.Sprint(initialize.FromPointer(repo.SFTP.Port)),
+				repo.SFTP.User, repo.SFTP.Path, repo.SFTP.HostKeyFingerprint})
+			name = repo.Name
 		default:
 			return map[string]string{}, "", errors.New("found unexpected repo type")
 		}
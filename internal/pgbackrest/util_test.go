@@ -13,6 +13,7 @@ import (
 	"gotest.tools/v3/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/crunchydata/postgres-operator/internal/initialize"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
@@ -120,4 +121,27 @@ func TestCalculateConfigHashes(t *testing.T) {
 		repo := "repo" + strconv.Itoa(i+1)
 		assert.Assert(t, hashMap[repo] != configHashMap[repo])
 	}
+
+	t.Run("SFTP", func(t *testing.T) {
+		cluster := postgresCluster.DeepCopy()
+		cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{{
+			Name: "repo1",
+			SFTP: &v1beta1.RepoSFTP{
+				Host: "example.com", Port: initialize.Int32(2222),
+				User: "pgbackrest", Path: "/backups",
+			},
+		}}
+
+		// The hash depends on the port number, not the address of its pointer.
+		_, one, err := CalculateConfigHashes(cluster.DeepCopy())
+		assert.NilError(t, err)
+		_, two, err := CalculateConfigHashes(cluster.DeepCopy())
+		assert.NilError(t, err)
+		assert.Equal(t, one, two)
+
+		cluster.Spec.Backups.PGBackRest.Repos[0].SFTP.Port = initialize.Int32(22)
+		_, three, err := CalculateConfigHashes(cluster)
+		assert.NilError(t, err)
+		assert.Assert(t, one != three)
+	})
 }
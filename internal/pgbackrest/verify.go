@@ -0,0 +1,29 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgbackrest
+
+import (
+	"regexp"
+	"slices"
+)
+
+// verifyFileError matches the message "pgbackrest verify" logs for each file
+// that it cannot verify. The first submatch is the path of the file in the
+// repository.
+// - https://github.com/pgbackrest/pgbackrest/blob/release/2.54.0/src/command/verify/verify.c
+var verifyFileError = regexp.MustCompile(
+	`(?:file missing|invalid checksum|invalid size|invalid result) '([^']+)'`)
+
+// VerifyCorruptFiles returns the files that "pgbackrest verify" reported as
+// missing or corrupt in its log, in the order they first appear.
+func VerifyCorruptFiles(log string) []string {
+	var files []string
+	for _, match := range verifyFileError.FindAllStringSubmatch(log, -1) {
+		if !slices.Contains(files, match[1]) {
+			files = append(files, match[1])
+		}
+	}
+	return files
+}
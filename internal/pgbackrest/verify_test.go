@@ -0,0 +1,34 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgbackrest
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestVerifyCorruptFiles(t *testing.T) {
+	assert.Assert(t, VerifyCorruptFiles("") == nil)
+	assert.Assert(t, VerifyCorruptFiles(`
+P00   INFO: verify command begin 2.54.0: --log-level-console=info --repo=1 --stanza=db
+P00   INFO: verify command end: completed successfully (1203ms)
+	`) == nil)
+
+	assert.DeepEqual(t, VerifyCorruptFiles(`
+P00   INFO: verify command begin 2.54.0: --log-level-console=info --repo=2 --stanza=db
+P01   INFO: invalid checksum '20250101-000000F/pg_data/base/5/1259.gz'
+P01   INFO: file missing '20250101-000000F/pg_data/global/pg_control.gz'
+P02   INFO: invalid size 'archive/db/16-1/0000000100000000/000000010000000000000003-abc.gz'
+P01   INFO: invalid checksum '20250101-000000F/pg_data/base/5/1259.gz'
+P00   INFO: stanza: db
+                status: error
+P00  ERROR: [028]: 3 fatal errors encountered, see log for details
+	`), []string{
+		"20250101-000000F/pg_data/base/5/1259.gz",
+		"20250101-000000F/pg_data/global/pg_control.gz",
+		"archive/db/16-1/0000000100000000/000000010000000000000003-abc.gz",
+	})
+}
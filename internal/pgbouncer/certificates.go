@@ -5,7 +5,13 @@
 package pgbouncer
 
 import (
+	"context"
+
 	corev1 "k8s.io/api/core/v1"
+
+	"github.com/crunchydata/postgres-operator/internal/certmanager"
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
 const (
@@ -54,6 +60,51 @@ func backendAuthority(postgres *corev1.SecretProjection) corev1.VolumeProjection
 	return corev1.VolumeProjection{Secret: result}
 }
 
+// customCertificate returns a projection of the PgBouncer certificate when it
+// is not issued by the operator. A custom secret takes precedence over one
+// issued by cert-manager. It returns nil when the operator issues it, which
+// includes while cert-manager has not yet issued every certificate.
+func customCertificate(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+) *corev1.SecretProjection {
+	if custom := cluster.Spec.Proxy.PGBouncer.CustomTLSSecret; custom != nil {
+		return custom
+	}
+	if certmanager.Active(ctx, cluster) {
+		return &corev1.SecretProjection{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: naming.CertManagerPGBouncerCertificate(cluster).Name,
+			},
+		}
+	}
+	return nil
+}
+
+// FrontendCertificateProjection returns a projection of the Secret that
+// contains the certificate PgBouncer presents to clients. Its items map keys to
+// the paths "tls.crt", "tls.key", and "ca.crt", or it has no items and those
+// are the keys. It returns nil when PgBouncer is disabled.
+func FrontendCertificateProjection(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+) *corev1.SecretProjection {
+	if cluster.Spec.Proxy == nil || cluster.Spec.Proxy.PGBouncer == nil {
+		return nil
+	}
+	if custom := customCertificate(ctx, cluster); custom != nil {
+		return custom
+	}
+	return &corev1.SecretProjection{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: naming.ClusterPGBouncer(cluster).Name,
+		},
+		Items: []corev1.KeyToPath{
+			{Key: certFrontendAuthoritySecretKey, Path: tlsAuthoritySecretKey},
+			{Key: certFrontendPrivateKeySecretKey, Path: tlsPrivateKeySecretKey},
+			{Key: certFrontendSecretKey, Path: tlsCertificateSecretKey},
+		},
+	}
+}
+
 // frontendCertificate creates a volume projection of the PgBouncer certificate.
 func frontendCertificate(
 	custom *corev1.SecretProjection, secret *corev1.Secret,
@@ -5,12 +5,17 @@
 package pgbouncer
 
 import (
+	"context"
 	"testing"
 
 	"gotest.tools/v3/assert"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/crunchydata/postgres-operator/internal/certmanager"
+	"github.com/crunchydata/postgres-operator/internal/kubernetes"
 	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
 func TestBackendAuthority(t *testing.T) {
@@ -95,3 +100,65 @@ secret:
 		`))
 	})
 }
+
+func TestCustomCertificate(t *testing.T) {
+	ctx := context.Background()
+	cluster := new(v1beta1.PostgresCluster)
+	cluster.Name = "hippo"
+	cluster.Spec.Proxy = &v1beta1.PostgresProxySpec{
+		PGBouncer: new(v1beta1.PGBouncerPodSpec),
+	}
+
+	assert.Assert(t, customCertificate(ctx, cluster) == nil)
+
+	cluster.Spec.CertManager = new(v1beta1.CertManagerSpec)
+	assert.Assert(t, customCertificate(ctx, cluster) == nil,
+		"expected nil when cert-manager is not installed")
+
+	ctx = kubernetes.NewAPIContext(ctx, kubernetes.NewAPISet(
+		kubernetes.API{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"},
+	))
+	assert.Assert(t, customCertificate(ctx, cluster) == nil,
+		"expected nil until cert-manager issues every certificate")
+
+	cluster.Status.Conditions = []metav1.Condition{{
+		Type: certmanager.ConditionIssued, Status: metav1.ConditionTrue,
+	}}
+	assert.Assert(t, cmp.MarshalMatches(customCertificate(ctx, cluster), `
+name: hippo-pgbouncer-tls
+	`))
+
+	custom := &corev1.SecretProjection{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "some-name"},
+	}
+	cluster.Spec.Proxy.PGBouncer.CustomTLSSecret = custom
+	assert.Equal(t, customCertificate(ctx, cluster), custom)
+}
+
+func TestFrontendCertificateProjection(t *testing.T) {
+	ctx := context.Background()
+	cluster := new(v1beta1.PostgresCluster)
+	cluster.Name = "hippo"
+
+	assert.Assert(t, FrontendCertificateProjection(ctx, cluster) == nil)
+
+	cluster.Spec.Proxy = &v1beta1.PostgresProxySpec{
+		PGBouncer: new(v1beta1.PGBouncerPodSpec),
+	}
+	assert.Assert(t, cmp.MarshalMatches(FrontendCertificateProjection(ctx, cluster), `
+items:
+- key: pgbouncer-frontend.ca-roots
+  path: ca.crt
+- key: pgbouncer-frontend.key
+  path: tls.key
+- key: pgbouncer-frontend.crt
+  path: tls.crt
+name: hippo-pgbouncer
+	`))
+
+	custom := &corev1.SecretProjection{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "some-name"},
+	}
+	cluster.Spec.Proxy.PGBouncer.CustomTLSSecret = custom
+	assert.Equal(t, FrontendCertificateProjection(ctx, cluster), custom)
+}
@@ -90,7 +90,7 @@ func Secret(ctx context.Context,
 		outSecret.Data[verifierSecretKey] = []byte(verifier)
 	}
 
-	if inCluster.Spec.Proxy.PGBouncer.CustomTLSSecret == nil {
+	if customCertificate(ctx, inCluster) == nil {
 		leaf := &pki.LeafCertificate{}
 		dnsNames := naming.ServiceDNSNames(ctx, inService)
 		dnsFQDN := dnsNames[0]
@@ -107,7 +107,7 @@ func Secret(ctx context.Context,
 		}
 
 		if err == nil {
-			outSecret.Data[certFrontendAuthoritySecretKey], err = inRoot.Certificate.MarshalText()
+			outSecret.Data[certFrontendAuthoritySecretKey], err = inRoot.Trust().MarshalText()
 		}
 		if err == nil {
 			outSecret.Data[certFrontendPrivateKeySecretKey], err = leaf.PrivateKey.MarshalText()
@@ -141,7 +141,7 @@ func Pod(
 	configVolume.Projected = &corev1.ProjectedVolumeSource{
 		Sources: append(append([]corev1.VolumeProjection{},
 			podConfigFiles(inCluster.Spec.Proxy.PGBouncer.Config, inConfigMap, inSecret)...),
-			frontendCertificate(inCluster.Spec.Proxy.PGBouncer.CustomTLSSecret, inSecret),
+			frontendCertificate(customCertificate(ctx, inCluster), inSecret),
 			backendAuthority(inPostgreSQLCertificate),
 		),
 	}
@@ -56,6 +56,21 @@ func (c *Certificate) UnmarshalText(data []byte) error {
 	return err
 }
 
+var _ encoding.TextMarshaler = TrustBundle{}
+
+// MarshalText returns the PEM encodings of b, one after another.
+func (b TrustBundle) MarshalText() ([]byte, error) {
+	var out []byte
+	for i := range b {
+		text, err := b[i].MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, text...)
+	}
+	return out, nil
+}
+
 var (
 	_ encoding.TextMarshaler   = PrivateKey{}
 	_ encoding.TextMarshaler   = (*PrivateKey)(nil)
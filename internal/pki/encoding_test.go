@@ -89,6 +89,33 @@ func TestCertificateTextMarshaling(t *testing.T) {
 	})
 }
 
+func TestTrustBundleTextMarshaling(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		txt, err := TrustBundle{}.MarshalText()
+		assert.NilError(t, err)
+		assert.Equal(t, len(txt), 0)
+	})
+
+	t.Run("Zero", func(t *testing.T) {
+		_, err := TrustBundle{{}}.MarshalText()
+		assert.ErrorContains(t, err, "malformed")
+	})
+
+	one, err := NewRootCertificateAuthority()
+	assert.NilError(t, err)
+	two, err := NewRootCertificateAuthority()
+	assert.NilError(t, err)
+
+	oneText, err := one.Certificate.MarshalText()
+	assert.NilError(t, err)
+	twoText, err := two.Certificate.MarshalText()
+	assert.NilError(t, err)
+
+	txt, err := TrustBundle{one.Certificate, two.Certificate}.MarshalText()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, txt, bytes.Join([][]byte{oneText, twoText}, nil))
+}
+
 func TestPrivateKeyTextMarshaling(t *testing.T) {
 	t.Run("Zero", func(t *testing.T) {
 		// Zero cannot marshal.
@@ -42,6 +42,22 @@ func (c Certificate) DNSNames() []string {
 	return append([]string{}, c.x509.DNSNames...)
 }
 
+// Issuer returns the distinguished name of the authority that signed c.
+func (c Certificate) Issuer() string {
+	if c.x509 == nil {
+		return ""
+	}
+	return c.x509.Issuer.String()
+}
+
+// NotAfter returns the time after which c is no longer valid.
+func (c Certificate) NotAfter() time.Time {
+	if c.x509 == nil {
+		return time.Time{}
+	}
+	return c.x509.NotAfter
+}
+
 // hasSubject checks that c has these values in its subject.
 func (c Certificate) hasSubject(commonName string, dnsNames []string) bool {
 	ok := c.x509 != nil &&
@@ -75,6 +91,19 @@ type LeafCertificate struct {
 type RootCertificateAuthority struct {
 	Certificate Certificate
 	PrivateKey  PrivateKey
+
+	// Trusted are other authorities that should be trusted alongside this one,
+	// such as those before or after a rotation. They do not sign anything.
+	Trusted []Certificate
+}
+
+// TrustBundle is a list of certificate authorities that are trusted together.
+type TrustBundle []Certificate
+
+// Trust returns the authorities that should be trusted by anything verifying
+// certificates generated by root.
+func (root *RootCertificateAuthority) Trust() TrustBundle {
+	return append(TrustBundle{root.Certificate}, root.Trusted...)
 }
 
 // NewRootCertificateAuthority generates a new key and self-signed certificate
@@ -131,6 +160,15 @@ func RootIsValid(root *RootCertificateAuthority) bool {
 	return ok
 }
 
+// RootNeedsRenewal checks if root is past the time when it should be replaced,
+// as defined by the before and after times of its expiration and the default
+// ratio. Certificates it generates are valid until then.
+func RootNeedsRenewal(root *RootCertificateAuthority) bool {
+	return root != nil && root.Certificate.x509 != nil &&
+		!isBeforeRenewalTime(root.Certificate.x509.NotBefore,
+			root.Certificate.x509.NotAfter)
+}
+
 // GenerateLeafCertificate generates a new key and certificate signed by root.
 func (root *RootCertificateAuthority) GenerateLeafCertificate(
 	commonName string, dnsNames []string,
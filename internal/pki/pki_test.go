@@ -34,6 +34,28 @@ func TestCertificateDNSNames(t *testing.T) {
 	assert.Assert(t, zero.DNSNames() == nil)
 }
 
+func TestCertificateIssuer(t *testing.T) {
+	zero := Certificate{}
+	assert.Equal(t, zero.Issuer(), "")
+
+	root, err := NewRootCertificateAuthority()
+	assert.NilError(t, err)
+	assert.Equal(t, root.Certificate.Issuer(), "CN=postgres-operator-ca")
+
+	leaf, err := root.GenerateLeafCertificate("some-leaf", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, leaf.Certificate.Issuer(), "CN=postgres-operator-ca")
+}
+
+func TestCertificateNotAfter(t *testing.T) {
+	zero := Certificate{}
+	assert.Assert(t, zero.NotAfter().IsZero())
+
+	root, err := NewRootCertificateAuthority()
+	assert.NilError(t, err)
+	assert.Equal(t, root.Certificate.NotAfter(), root.Certificate.x509.NotAfter)
+}
+
 func TestCertificateHasSubject(t *testing.T) {
 	zero := Certificate{}
 
@@ -134,6 +156,45 @@ func TestRootCertificateAuthority(t *testing.T) {
 	// a proper chain in [TestLeafCertificate].
 }
 
+func TestRootNeedsRenewal(t *testing.T) {
+	assert.Assert(t, !RootNeedsRenewal(nil))
+	assert.Assert(t, !RootNeedsRenewal(&RootCertificateAuthority{}))
+
+	root, err := NewRootCertificateAuthority()
+	assert.NilError(t, err)
+	assert.Assert(t, !RootNeedsRenewal(root))
+
+	original := currentTime
+	t.Cleanup(func() { currentTime = original })
+
+	// The root is valid for ten years; renew during the last third.
+	currentTime = func() time.Time { return time.Now().AddDate(6, 0, 0) }
+	assert.Assert(t, !RootNeedsRenewal(root))
+
+	currentTime = func() time.Time { return time.Now().AddDate(7, 0, 0) }
+	assert.Assert(t, RootNeedsRenewal(root))
+	assert.Assert(t, RootIsValid(root), "still valid")
+}
+
+func TestRootTrust(t *testing.T) {
+	root, err := NewRootCertificateAuthority()
+	assert.NilError(t, err)
+
+	assert.DeepEqual(t, root.Trust(), TrustBundle{root.Certificate})
+
+	other, err := NewRootCertificateAuthority()
+	assert.NilError(t, err)
+
+	root.Trusted = []Certificate{other.Certificate}
+	assert.DeepEqual(t, root.Trust(), TrustBundle{root.Certificate, other.Certificate})
+
+	// Leaves are still generated by root alone.
+	leaf, err := root.GenerateLeafCertificate("", nil)
+	assert.NilError(t, err)
+	assert.Assert(t, root.leafIsValid(leaf))
+	assert.Assert(t, !other.leafIsValid(leaf))
+}
+
 func TestRootIsInvalid(t *testing.T) {
 	t.Run("NoCertificate", func(t *testing.T) {
 		assert.Assert(t, !RootIsValid(nil))
@@ -172,7 +233,9 @@ func TestRootIsInvalid(t *testing.T) {
 		leaf, err := root.GenerateLeafCertificate("", nil)
 		assert.NilError(t, err)
 
-		assert.Assert(t, !RootIsValid((*RootCertificateAuthority)(leaf)))
+		assert.Assert(t, !RootIsValid(&RootCertificateAuthority{
+			Certificate: leaf.Certificate, PrivateKey: leaf.PrivateKey,
+		}))
 	})
 
 	t.Run("TooEarly", func(t *testing.T) {
@@ -356,7 +419,9 @@ func TestLeafIsInvalid(t *testing.T) {
 	})
 
 	t.Run("IsAuthority", func(t *testing.T) {
-		assert.Assert(t, !root.leafIsValid((*LeafCertificate)(root)))
+		assert.Assert(t, !root.leafIsValid(&LeafCertificate{
+			Certificate: root.Certificate, PrivateKey: root.PrivateKey,
+		}))
 	})
 
 	t.Run("TooEarly", func(t *testing.T) {
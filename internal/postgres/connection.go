@@ -0,0 +1,103 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import "strings"
+
+// escapeKeywordValue is called by KeywordValueString to add backslashes before
+// quotes and backslashes in a value.
+var escapeKeywordValue = strings.NewReplacer(`'`, `\'`, `\`, `\\`).Replace
+
+// escapePassword is called by PasswordFileLine to add backslashes before
+// colons and backslashes in a field.
+var escapePassword = strings.NewReplacer(`:`, `\:`, `\`, `\\`).Replace
+
+// KeywordValueString returns a libpq connection string of the keywords and
+// values in pairs, which alternate between the two. Pairs with empty values
+// are omitted. Values are quoted when they are empty or contain spaces,
+// quotes, or backslashes.
+// - https://www.postgresql.org/docs/current/libpq-connect.html#LIBPQ-CONNSTRING-KEYWORD-VALUE
+func KeywordValueString(pairs ...string) string {
+	var b strings.Builder
+	for i := 0; i+1 < len(pairs); i += 2 {
+		keyword, value := pairs[i], pairs[i+1]
+		if value == "" {
+			continue
+		}
+		if b.Len() > 0 {
+			_ = b.WriteByte(' ')
+		}
+		_, _ = b.WriteString(keyword)
+		_ = b.WriteByte('=')
+
+		if strings.ContainsAny(value, " \t\n\r\f\v'\\") {
+			_ = b.WriteByte('\'')
+			_, _ = b.WriteString(escapeKeywordValue(value))
+			_ = b.WriteByte('\'')
+		} else {
+			_, _ = b.WriteString(value)
+		}
+	}
+	return b.String()
+}
+
+// PasswordFileLine returns one line of a libpq password file that matches the
+// host, port, database, and user. An empty database matches any database.
+// - https://www.postgresql.org/docs/current/libpq-pgpass.html
+func PasswordFileLine(host, port, database, user, password string) string {
+	if database == "" {
+		database = "*"
+	} else {
+		database = escapePassword(database)
+	}
+	return strings.Join([]string{
+		escapePassword(host), escapePassword(port), database,
+		escapePassword(user), escapePassword(password),
+	}, ":") + "\n"
+}
+
+// ServiceFileSection returns a section of a libpq connection service file
+// called name with the keywords and values in pairs, which alternate between
+// the two. Pairs with empty values are omitted. Values are not quoted; each
+// extends to the end of its line.
+// - https://www.postgresql.org/docs/current/libpq-pgservice.html
+func ServiceFileSection(name string, pairs ...string) string {
+	var b strings.Builder
+	_, _ = b.WriteString("[" + name + "]\n")
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] != "" {
+			_, _ = b.WriteString(pairs[i] + "=" + pairs[i+1] + "\n")
+		}
+	}
+	return b.String()
+}
+
+// DotNetConnectionString returns an ADO.NET connection string, as used by
+// Npgsql, of the keywords and values in pairs, which alternate between the
+// two. Pairs with empty values are omitted. Values are enclosed in double
+// quotes when they contain quotes, semicolons, or surrounding spaces.
+// - https://www.npgsql.org/doc/connection-string-parameters.html
+// - https://learn.microsoft.com/dotnet/framework/data/adonet/connection-string-syntax
+func DotNetConnectionString(pairs ...string) string {
+	var b strings.Builder
+	for i := 0; i+1 < len(pairs); i += 2 {
+		keyword, value := pairs[i], pairs[i+1]
+		if value == "" {
+			continue
+		}
+		_, _ = b.WriteString(keyword)
+		_ = b.WriteByte('=')
+
+		if strings.ContainsAny(value, `;'"`) || strings.TrimSpace(value) != value {
+			_ = b.WriteByte('"')
+			_, _ = b.WriteString(strings.ReplaceAll(value, `"`, `""`))
+			_ = b.WriteByte('"')
+		} else {
+			_, _ = b.WriteString(value)
+		}
+		_ = b.WriteByte(';')
+	}
+	return b.String()
+}
@@ -0,0 +1,39 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestKeywordValueString(t *testing.T) {
+	assert.Equal(t, KeywordValueString(), ``)
+	assert.Equal(t, KeywordValueString("host", "h1", "dbname", "", "port", "5432"),
+		`host=h1 port=5432`)
+	assert.Equal(t, KeywordValueString("password", `a b'c\d`, "user", "u1"),
+		`password='a b\'c\\d' user=u1`)
+}
+
+func TestPasswordFileLine(t *testing.T) {
+	assert.Equal(t, PasswordFileLine("h1", "5432", "", "u1", "pw"),
+		"h1:5432:*:u1:pw\n")
+	assert.Equal(t, PasswordFileLine("h1", "5432", "db", "u1", `a:b\c*`),
+		"h1:5432:db:u1:a\\:b\\\\c*\n")
+}
+
+func TestServiceFileSection(t *testing.T) {
+	assert.Equal(t, ServiceFileSection("svc", "host", "h1", "dbname", "", "user", "u1"),
+		"[svc]\nhost=h1\nuser=u1\n")
+}
+
+func TestDotNetConnectionString(t *testing.T) {
+	assert.Equal(t, DotNetConnectionString(), ``)
+	assert.Equal(t, DotNetConnectionString("Host", "h1", "Database", "", "Port", "5432"),
+		`Host=h1;Port=5432;`)
+	assert.Equal(t, DotNetConnectionString("Password", `a;b"c`, "Username", " u1"),
+		`Password="a;b""c";Username=" u1";`)
+}
@@ -8,8 +8,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"strconv"
+	"strings"
 
 	"github.com/crunchydata/postgres-operator/internal/logging"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
 // CreateDatabasesInPostgreSQL calls exec to create databases that do not exist
@@ -70,3 +73,196 @@ SELECT pg_catalog.format('CREATE DATABASE %I',
 
 	return err
 }
+
+// WriteDatabaseInPostgreSQL calls exec to create the database described by
+// spec when it does not exist in PostgreSQL. Once it exists, it sets the owner
+// and connection limit of the database and creates any schemas and extensions
+// that do not exist in it. It returns true when it created the database, even
+// when a later statement fails.
+func WriteDatabaseInPostgreSQL(
+	ctx context.Context, exec Executor, spec *v1beta1.PostgresDatabaseSpec,
+) (bool, error) {
+	log := logging.FromContext(ctx)
+
+	var err error
+	var sql bytes.Buffer
+
+	// Prevent unexpected dereferences by emptying "search_path". The "pg_catalog"
+	// schema is still searched, and only temporary objects can be created.
+	// - https://www.postgresql.org/docs/current/runtime-config-client.html#GUC-SEARCH-PATH
+	_, _ = sql.WriteString(`SET search_path TO '';`)
+
+	// Create the database when it does not already exist. Options that are
+	// empty are omitted so that PostgreSQL can choose their values. Print
+	// whether or not it was created once that succeeds.
+	// - https://www.postgresql.org/docs/current/sql-createdatabase.html
+	// - https://www.postgresql.org/docs/current/app-psql.html#APP-PSQL-META-COMMAND-GSET
+	_, _ = sql.WriteString(`
+SELECT NOT EXISTS (
+       SELECT 1 FROM pg_catalog.pg_database WHERE datname = :'database') AS created
+\gset
+SELECT pg_catalog.concat(
+       pg_catalog.format('CREATE DATABASE %I', :'database'),
+       CASE WHEN :'template' <> '' THEN pg_catalog.format(' TEMPLATE %I', :'template') END,
+       CASE WHEN :'encoding' <> '' THEN pg_catalog.format(' ENCODING %L', :'encoding') END,
+       CASE WHEN :'locale' <> '' THEN pg_catalog.format(' LOCALE %L', :'locale') END,
+       CASE WHEN :'locale_provider' <> '' THEN pg_catalog.format(' LOCALE_PROVIDER %I', :'locale_provider') END,
+       CASE WHEN :'icu_locale' <> '' THEN pg_catalog.format(' ICU_LOCALE %L', :'icu_locale') END)
+ WHERE NOT EXISTS (
+       SELECT 1 FROM pg_catalog.pg_database WHERE datname = :'database')
+\gexec
+\echo :created
+`)
+
+	// Set the owner and connection limit of the database.
+	// - https://www.postgresql.org/docs/current/sql-alterdatabase.html
+	_, _ = sql.WriteString(`
+SELECT pg_catalog.format('ALTER DATABASE %I OWNER TO %I', :'database', :'owner')
+ WHERE :'owner' <> ''
+\gexec
+SELECT pg_catalog.format('ALTER DATABASE %I WITH CONNECTION LIMIT %s',
+       :'database', CAST(:'connection_limit' AS integer))
+\gexec
+`)
+
+	// Connect to the database to create objects inside it.
+	// - https://www.postgresql.org/docs/current/app-psql.html#APP-PSQL-META-COMMAND-CONNECT
+	_, _ = sql.WriteString(`\connect :"database"` + "\n")
+	_, _ = sql.WriteString(`SET search_path TO '';`)
+
+	// Create schemas that do not already exist and set their owners.
+	// - https://www.postgresql.org/docs/current/sql-createschema.html
+	// - https://www.postgresql.org/docs/current/sql-alterschema.html
+	_, _ = sql.WriteString(`
+SELECT pg_catalog.format('CREATE SCHEMA IF NOT EXISTS %I', name)
+  FROM pg_catalog.json_to_recordset(:'schemas') AS schemas (name text, owner text)
+\gexec
+SELECT pg_catalog.format('ALTER SCHEMA %I OWNER TO %I', name, owner)
+  FROM pg_catalog.json_to_recordset(:'schemas') AS schemas (name text, owner text)
+ WHERE owner IS NOT NULL
+\gexec
+`)
+
+	// Create extensions that do not already exist along with any extensions
+	// they depend on.
+	// - https://www.postgresql.org/docs/current/sql-createextension.html
+	_, _ = sql.WriteString(`
+SELECT pg_catalog.concat(
+       pg_catalog.format('CREATE EXTENSION IF NOT EXISTS %I', name),
+       CASE WHEN schema IS NOT NULL THEN pg_catalog.format(' SCHEMA %I', schema) END,
+       ' CASCADE')
+  FROM pg_catalog.json_to_recordset(:'extensions') AS extensions (name text, schema text)
+\gexec
+`)
+
+	connectionLimit := int32(-1)
+	if spec.ConnectionLimit != nil {
+		connectionLimit = *spec.ConnectionLimit
+	}
+
+	// Send empty lists rather than null so that every query above is valid.
+	schemas, _ := json.Marshal(append([]v1beta1.PostgresDatabaseSchema{}, spec.Schemas...))
+	extensions, _ := json.Marshal(append([]v1beta1.PostgresDatabaseExtension{}, spec.Extensions...))
+
+	stdout, stderr, err := exec.Exec(ctx, &sql,
+		map[string]string{
+			"database":         spec.Name,
+			"connection_limit": strconv.FormatInt(int64(connectionLimit), 10),
+			"encoding":         spec.Encoding,
+			"extensions":       string(extensions),
+			"icu_locale":       spec.ICULocale,
+			"locale":           spec.Locale,
+			"locale_provider":  spec.LocaleProvider,
+			"owner":            spec.Owner,
+			"schemas":          string(schemas),
+			"template":         spec.Template,
+
+			"ON_ERROR_STOP": "on", // Abort when any one statement fails.
+			"QUIET":         "on", // Do not print successful statements to stdout.
+		})
+
+	log.V(1).Info("wrote PostgreSQL database", "stdout", stdout, "stderr", stderr)
+
+	return strings.HasPrefix(stdout, "t\n"), err
+}
+
+// DropDatabaseInPostgreSQL calls exec to disconnect any sessions from database
+// and drop it when it exists in PostgreSQL.
+func DropDatabaseInPostgreSQL(ctx context.Context, exec Executor, database string) error {
+	log := logging.FromContext(ctx)
+
+	stdout, stderr, err := exec.Exec(ctx, strings.NewReader(strings.Join([]string{
+		// Quiet NOTICE messages from IF EXISTS statements.
+		// - https://www.postgresql.org/docs/current/runtime-config-client.html
+		`SET client_min_messages = WARNING;`,
+
+		// Prevent unexpected dereferences by emptying "search_path".
+		// - https://www.postgresql.org/docs/current/runtime-config-client.html#GUC-SEARCH-PATH
+		`SET search_path TO '';`,
+
+		// Prevent new sessions then end existing ones. PostgreSQL cannot drop a
+		// database while anyone is connected to it.
+		// - https://www.postgresql.org/docs/current/functions-admin.html#FUNCTIONS-ADMIN-SIGNAL
+		`SELECT pg_catalog.format('ALTER DATABASE %I WITH ALLOW_CONNECTIONS false', :'database')`,
+		`  FROM pg_catalog.pg_database WHERE datname = :'database'`,
+		`\gexec`,
+		`SELECT pg_catalog.pg_terminate_backend(pid) FROM pg_catalog.pg_stat_activity`,
+		` WHERE datname = :'database' AND pid <> pg_catalog.pg_backend_pid()`,
+		`\g /dev/null`,
+
+		// - https://www.postgresql.org/docs/current/sql-dropdatabase.html
+		`DROP DATABASE IF EXISTS :"database";`,
+	}, "\n")),
+		map[string]string{
+			"database": database,
+
+			"ON_ERROR_STOP": "on", // Abort when any one statement fails.
+			"QUIET":         "on", // Do not print successful statements to stdout.
+		})
+
+	log.V(1).Info("dropped PostgreSQL database", "stdout", stdout, "stderr", stderr)
+
+	return err
+}
+
+// DatabaseSizeInPostgreSQL calls exec to report whether or not database exists
+// in PostgreSQL and the number of bytes it occupies on disk.
+func DatabaseSizeInPostgreSQL(
+	ctx context.Context, exec Executor, database string,
+) (bool, int64, error) {
+	stdout, stderr, err := exec.Exec(ctx, strings.NewReader(strings.Join([]string{
+		// Print only the value of each row.
+		// - https://www.postgresql.org/docs/current/app-psql.html#APP-PSQL-META-COMMAND-PSET
+		`\pset format unaligned`,
+		`\pset tuples_only on`,
+
+		// Prevent unexpected dereferences by emptying "search_path".
+		// - https://www.postgresql.org/docs/current/runtime-config-client.html#GUC-SEARCH-PATH
+		`SET search_path TO '';`,
+
+		// - https://www.postgresql.org/docs/current/functions-admin.html#FUNCTIONS-ADMIN-DBSIZE
+		`SELECT pg_catalog.pg_database_size(oid)`,
+		`  FROM pg_catalog.pg_database WHERE datname = :'database';`,
+	}, "\n")),
+		map[string]string{
+			"database": database,
+
+			"ON_ERROR_STOP": "on", // Abort when any one statement fails.
+			"QUIET":         "on", // Do not print successful statements to stdout.
+		})
+
+	if err == nil && strings.TrimSpace(stdout) == "" {
+		return false, 0, nil
+	}
+
+	var size int64
+	if err == nil {
+		size, err = strconv.ParseInt(strings.TrimSpace(stdout), 10, 64)
+	}
+	if err != nil {
+		logging.FromContext(ctx).V(1).Info("unable to read PostgreSQL database size",
+			"stdout", stdout, "stderr", stderr)
+	}
+
+	return err == nil, size, err
+}
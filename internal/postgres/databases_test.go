@@ -14,6 +14,8 @@ import (
 	"gotest.tools/v3/assert"
 
 	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/internal/testing/require"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
 func TestCreateDatabasesInPostgreSQL(t *testing.T) {
@@ -90,3 +92,207 @@ SELECT pg_catalog.format('CREATE DATABASE %I',
 		assert.Equal(t, calls, 1)
 	})
 }
+
+func TestWriteDatabaseInPostgreSQL(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Arguments", func(t *testing.T) {
+		expected := errors.New("pass-through")
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			assert.Assert(t, stdout != nil, "should capture stdout")
+			assert.Assert(t, stderr != nil, "should capture stderr")
+			return expected
+		}
+
+		spec := new(v1beta1.PostgresDatabaseSpec)
+		_, err := WriteDatabaseInPostgreSQL(ctx, exec, spec)
+		assert.Equal(t, expected, err)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		calls := 0
+		exec := func(
+			_ context.Context, stdin io.Reader, _, _ io.Writer, command ...string,
+		) error {
+			calls++
+
+			assert.DeepEqual(t, command[3:], []string{
+				`--set=ON_ERROR_STOP=on`,
+				`--set=QUIET=on`,
+				`--set=connection_limit=-1`,
+				`--set=database=db1`,
+				`--set=encoding=`,
+				`--set=extensions=[]`,
+				`--set=icu_locale=`,
+				`--set=locale=`,
+				`--set=locale_provider=`,
+				`--set=owner=`,
+				`--set=schemas=[]`,
+				`--set=template=`,
+			})
+
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+			assert.Assert(t, cmp.Contains(string(b), `
+SELECT pg_catalog.format('ALTER DATABASE %I OWNER TO %I', :'database', :'owner')
+ WHERE :'owner' <> ''
+\gexec
+`))
+			assert.Assert(t, cmp.Contains(string(b), `
+\gexec
+\echo :created
+`))
+			assert.Assert(t, cmp.Contains(string(b), `
+\connect :"database"
+SET search_path TO '';
+`))
+			return nil
+		}
+
+		spec := new(v1beta1.PostgresDatabaseSpec)
+		spec.Name = "db1"
+
+		created, err := WriteDatabaseInPostgreSQL(ctx, exec, spec)
+		assert.NilError(t, err)
+		assert.Assert(t, !created)
+		assert.Equal(t, calls, 1)
+	})
+
+	t.Run("Full", func(t *testing.T) {
+		calls := 0
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, _ io.Writer, command ...string,
+		) error {
+			calls++
+
+			assert.DeepEqual(t, command[3:], []string{
+				`--set=ON_ERROR_STOP=on`,
+				`--set=QUIET=on`,
+				`--set=connection_limit=20`,
+				`--set=database=white space`,
+				`--set=encoding=UTF8`,
+				`--set=extensions=[{"name":"pg_trgm"},{"name":"hstore","schema":"app"}]`,
+				`--set=icu_locale=und-u-ks-level2`,
+				`--set=locale=C`,
+				`--set=locale_provider=icu`,
+				`--set=owner=app-owner`,
+				`--set=schemas=[{"name":"app","owner":"app-owner"},{"name":"eXaCtLy"}]`,
+				`--set=template=template0`,
+			})
+			_, _ = stdout.Write([]byte("t\n"))
+			return nil
+		}
+
+		spec := new(v1beta1.PostgresDatabaseSpec)
+		require.UnmarshalInto(t, spec, `{
+			name: white space,
+			owner: app-owner,
+			connectionLimit: 20,
+			encoding: UTF8,
+			locale: C,
+			localeProvider: icu,
+			icuLocale: und-u-ks-level2,
+			template: template0,
+			schemas: [{ name: app, owner: app-owner }, { name: eXaCtLy }],
+			extensions: [{ name: pg_trgm }, { name: hstore, schema: app }],
+		}`)
+
+		created, err := WriteDatabaseInPostgreSQL(ctx, exec, spec)
+		assert.NilError(t, err)
+		assert.Assert(t, created)
+		assert.Equal(t, calls, 1)
+	})
+}
+
+func TestDropDatabaseInPostgreSQL(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Arguments", func(t *testing.T) {
+		expected := errors.New("pass-through")
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			assert.Assert(t, stdout != nil, "should capture stdout")
+			assert.Assert(t, stderr != nil, "should capture stderr")
+			assert.Assert(t, cmp.Contains(command, `--set=database=some db`))
+			return expected
+		}
+
+		assert.Equal(t, expected, DropDatabaseInPostgreSQL(ctx, exec, "some db"))
+	})
+
+	t.Run("Statements", func(t *testing.T) {
+		exec := func(
+			_ context.Context, stdin io.Reader, _, _ io.Writer, command ...string,
+		) error {
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+			assert.Assert(t, cmp.Contains(string(b), `pg_catalog.pg_terminate_backend`))
+			assert.Assert(t, strings.HasSuffix(string(b), `DROP DATABASE IF EXISTS :"database";`))
+			return nil
+		}
+
+		assert.NilError(t, DropDatabaseInPostgreSQL(ctx, exec, "db1"))
+	})
+}
+
+func TestDatabaseSizeInPostgreSQL(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Error", func(t *testing.T) {
+		expected := errors.New("pass-through")
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			assert.Assert(t, cmp.Contains(command, `--set=database=db1`))
+			return expected
+		}
+
+		exists, _, err := DatabaseSizeInPostgreSQL(ctx, exec, "db1")
+		assert.Equal(t, expected, err)
+		assert.Assert(t, !exists)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			_, _ = stdout.Write([]byte("\n"))
+			return nil
+		}
+
+		exists, size, err := DatabaseSizeInPostgreSQL(ctx, exec, "db1")
+		assert.NilError(t, err)
+		assert.Assert(t, !exists)
+		assert.Equal(t, size, int64(0))
+	})
+
+	t.Run("Found", func(t *testing.T) {
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			_, _ = stdout.Write([]byte("7537443\n"))
+			return nil
+		}
+
+		exists, size, err := DatabaseSizeInPostgreSQL(ctx, exec, "db1")
+		assert.NilError(t, err)
+		assert.Assert(t, exists)
+		assert.Equal(t, size, int64(7537443))
+	})
+
+	t.Run("Unexpected", func(t *testing.T) {
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			_, _ = stdout.Write([]byte("nope\n"))
+			return nil
+		}
+
+		exists, _, err := DatabaseSizeInPostgreSQL(ctx, exec, "db1")
+		assert.ErrorContains(t, err, "nope")
+		assert.Assert(t, !exists)
+	})
+}
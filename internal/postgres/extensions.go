@@ -0,0 +1,212 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/crunchydata/postgres-operator/internal/logging"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+// extensionLibraries are the shared libraries that must be loaded when
+// PostgreSQL starts before an extension can be installed or used.
+var extensionLibraries = map[string][]string{
+	// - https://docs.citusdata.com/en/stable/installation/multi_node_rhel.html
+	"citus": {"citus"},
+
+	// - https://github.com/citusdata/pg_cron#setting-up-pg_cron
+	"pg_cron": {"pg_cron"},
+
+	// - https://github.com/cybertec-postgresql/pg_squeeze#configuration
+	"pg_squeeze": {"pg_squeeze"},
+
+	// - https://github.com/powa-team/pg_stat_kcache#installation
+	"pg_stat_kcache": {"pg_stat_statements", "pg_stat_kcache"},
+
+	// - https://www.postgresql.org/docs/current/pgstatstatements.html
+	"pg_stat_statements": {"pg_stat_statements"},
+
+	// - https://github.com/postgrespro/pg_wait_sampling#installation
+	"pg_wait_sampling": {"pg_wait_sampling"},
+
+	// - https://github.com/pgaudit/pgaudit#settings
+	"pgaudit": {"pgaudit"},
+
+	// - https://docs.timescale.com/self-hosted/latest/configuration/
+	"timescaledb": {"timescaledb"},
+}
+
+// ExtensionParameters sets the parameters required by the extensions in
+// cluster.spec.extensions.
+func ExtensionParameters(cluster *v1beta1.PostgresCluster, outParameters *Parameters) {
+	loaded := strings.Split(outParameters.Mandatory.Value("shared_preload_libraries"), ",")
+
+	for _, extension := range cluster.Spec.Extensions {
+		for _, library := range append(
+			slices.Clone(extensionLibraries[extension.Name]),
+			extension.SharedPreloadLibraries...,
+		) {
+			// Load each library once, after any that are already mandatory.
+			// PostgreSQL must be restarted when changing this value.
+			// - https://www.postgresql.org/docs/current/runtime-config-client.html
+			if !slices.Contains(loaded, library) {
+				loaded = append(loaded, library)
+				outParameters.Mandatory.AppendToList("shared_preload_libraries", library)
+			}
+		}
+	}
+}
+
+// WriteExtensionsInPostgreSQL calls exec to install extensions that do not
+// exist in their databases along with any extensions they depend on. Once they
+// exist, it updates them to their specified version or, when no version is
+// specified, the default version available in PostgreSQL.
+func WriteExtensionsInPostgreSQL(
+	ctx context.Context, exec Executor, extensions []v1beta1.PostgresExtensionSpec,
+) error {
+	log := logging.FromContext(ctx)
+
+	// Send an empty list rather than null so that every query below is valid.
+	specs, err := json.Marshal(append([]v1beta1.PostgresExtensionSpec{}, extensions...))
+	if err != nil {
+		return err
+	}
+
+	stdout, stderr, err := exec.ExecInAllDatabases(ctx,
+		strings.Join([]string{
+			// Quiet NOTICE messages from IF NOT EXISTS statements.
+			// - https://www.postgresql.org/docs/current/runtime-config-client.html
+			`SET client_min_messages = WARNING;`,
+
+			// Do not wait for changes to be replicated. [Since PostgreSQL v9.1]
+			// - https://www.postgresql.org/docs/current/runtime-config-wal.html
+			`SET synchronous_commit = LOCAL;`,
+
+			// Gather the extensions that belong in the current database.
+			`CREATE TEMPORARY VIEW input AS`,
+			`SELECT name, version, schema`,
+			`  FROM pg_catalog.json_to_recordset(:'extensions')`,
+			`    AS extensions (name text, version text, schema text, databases json)`,
+			` WHERE databases IS NULL OR pg_catalog.current_database() IN (`,
+			`       SELECT pg_catalog.json_array_elements_text(databases));`,
+
+			// Create extensions that do not already exist.
+			// - https://www.postgresql.org/docs/current/sql-createextension.html
+			`SELECT pg_catalog.concat(`,
+			`       pg_catalog.format('CREATE EXTENSION IF NOT EXISTS %I', name),`,
+			`       CASE WHEN schema IS NOT NULL THEN pg_catalog.format(' SCHEMA %I', schema) END,`,
+			`       CASE WHEN version IS NOT NULL THEN pg_catalog.format(' VERSION %L', version) END,`,
+			`       ' CASCADE')`,
+			`  FROM input`,
+			`\gexec`,
+
+			// Update extensions that are not at their intended version.
+			// - https://www.postgresql.org/docs/current/sql-alterextension.html
+			`SELECT pg_catalog.format('ALTER EXTENSION %I UPDATE TO %L', input.name,`,
+			`       COALESCE(input.version, available.default_version))`,
+			`  FROM input`,
+			`  JOIN pg_catalog.pg_extension installed ON installed.extname = input.name`,
+			`  JOIN pg_catalog.pg_available_extensions available ON available.name = input.name`,
+			` WHERE installed.extversion <> COALESCE(input.version, available.default_version)`,
+			`\gexec`,
+		}, "\n"),
+		map[string]string{
+			"extensions": string(specs),
+
+			"ON_ERROR_STOP": "on", // Abort when any one statement fails.
+			"QUIET":         "on", // Do not print successful statements to stdout.
+		})
+
+	log.V(1).Info("wrote PostgreSQL extensions", "stdout", stdout, "stderr", stderr)
+
+	return err
+}
+
+// ExtensionsInPostgreSQL calls exec to read the available and installed
+// versions of extensions in every database that allows connections.
+func ExtensionsInPostgreSQL(
+	ctx context.Context, exec Executor, extensions []v1beta1.PostgresExtensionSpec,
+) ([]v1beta1.PostgresExtensionStatus, error) {
+	names := make([]string, len(extensions))
+	for i := range extensions {
+		names[i] = extensions[i].Name
+	}
+
+	// Send an empty list rather than null so that the query below is valid.
+	input, _ := json.Marshal(append([]string{}, names...))
+
+	stdout, stderr, err := exec.ExecInAllDatabases(ctx,
+		strings.Join([]string{
+			// Print only the values of each row, without alignment or headers.
+			// - https://www.postgresql.org/docs/current/app-psql.html#APP-PSQL-META-COMMANDS-PSET
+			`\pset format unaligned`,
+			`\pset tuples_only on`,
+
+			// Print one line of JSON describing the extensions in the current database.
+			// - https://www.postgresql.org/docs/current/view-pg-available-extensions.html
+			// - https://www.postgresql.org/docs/current/catalog-pg-extension.html
+			`SELECT pg_catalog.json_build_object(`,
+			`       'database', pg_catalog.current_database(),`,
+			`       'available', (`,
+			`         SELECT pg_catalog.json_object_agg(name, default_version)`,
+			`           FROM pg_catalog.pg_available_extensions`,
+			`          WHERE name IN (SELECT pg_catalog.json_array_elements_text(:'names'))),`,
+			`       'installed', (`,
+			`         SELECT pg_catalog.json_object_agg(extname, extversion)`,
+			`           FROM pg_catalog.pg_extension`,
+			`          WHERE extname IN (SELECT pg_catalog.json_array_elements_text(:'names'))));`,
+		}, "\n"),
+		map[string]string{
+			"names": string(input),
+
+			"ON_ERROR_STOP": "on", // Abort when any one statement fails.
+			"QUIET":         "on", // Do not print successful statements to stdout.
+		})
+
+	if err != nil {
+		logging.FromContext(ctx).V(1).Info("read PostgreSQL extensions", "stdout", stdout, "stderr", stderr)
+		return nil, err
+	}
+
+	result := make([]v1beta1.PostgresExtensionStatus, len(names))
+	for i := range names {
+		result[i].Name = names[i]
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(stdout))
+	for scanner.Scan() {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+
+		var row struct {
+			Database  string
+			Available map[string]string
+			Installed map[string]string
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &row); err != nil {
+			return nil, err
+		}
+
+		for i := range result {
+			if version, ok := row.Available[result[i].Name]; ok {
+				result[i].AvailableVersion = version
+			}
+			if version, ok := row.Installed[result[i].Name]; ok {
+				if result[i].InstalledVersions == nil {
+					result[i].InstalledVersions = make(map[string]string)
+				}
+				result[i].InstalledVersions[row.Database] = version
+			}
+		}
+	}
+
+	return result, scanner.Err()
+}
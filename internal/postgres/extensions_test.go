@@ -0,0 +1,200 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/internal/testing/require"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+func TestExtensionParameters(t *testing.T) {
+	t.Run("Unspecified", func(t *testing.T) {
+		parameters := NewParameters()
+		ExtensionParameters(v1beta1.NewPostgresCluster(), &parameters)
+
+		_, found := parameters.Mandatory.Get("shared_preload_libraries")
+		assert.Assert(t, !found)
+	})
+
+	t.Run("Known", func(t *testing.T) {
+		cluster := v1beta1.NewPostgresCluster()
+		require.UnmarshalInto(t, &cluster.Spec.Extensions, `[
+			{ name: hstore },
+			{ name: pg_cron },
+			{ name: timescaledb },
+		]`)
+
+		parameters := NewParameters()
+		ExtensionParameters(cluster, &parameters)
+
+		assert.Equal(t, parameters.Mandatory.Value("shared_preload_libraries"),
+			"pg_cron,timescaledb")
+	})
+
+	t.Run("Specified", func(t *testing.T) {
+		cluster := v1beta1.NewPostgresCluster()
+		require.UnmarshalInto(t, &cluster.Spec.Extensions, `[
+			{ name: pg_stat_kcache },
+			{ name: custom, sharedPreloadLibraries: [custom_bgw, pg_stat_statements] },
+		]`)
+
+		parameters := NewParameters()
+		parameters.Mandatory.Add("shared_preload_libraries", "pg_stat_statements")
+		ExtensionParameters(cluster, &parameters)
+
+		assert.Equal(t, parameters.Mandatory.Value("shared_preload_libraries"),
+			"pg_stat_statements,pg_stat_kcache,custom_bgw",
+			"expected each library once")
+	})
+}
+
+func TestWriteExtensionsInPostgreSQL(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Arguments", func(t *testing.T) {
+		expected := errors.New("pass-through")
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			assert.Assert(t, stdout != nil, "should capture stdout")
+			assert.Assert(t, stderr != nil, "should capture stderr")
+			assert.Assert(t, cmp.Contains(command, `--set=extensions=[]`))
+			return expected
+		}
+
+		assert.Equal(t, expected, WriteExtensionsInPostgreSQL(ctx, exec, nil))
+	})
+
+	t.Run("Full", func(t *testing.T) {
+		calls := 0
+		exec := func(
+			_ context.Context, stdin io.Reader, _, _ io.Writer, command ...string,
+		) error {
+			calls++
+
+			// The SQL runs in every database.
+			assert.Equal(t, command[0], "bash")
+			assert.DeepEqual(t, command[len(command)-3:], []string{
+				`--set=ON_ERROR_STOP=on`,
+				`--set=QUIET=on`,
+				`--set=extensions=[` +
+					`{"name":"postgis","version":"3.4.2","databases":["app","template1"]},` +
+					`{"name":"pg_cron","schema":"cron"}]`,
+			})
+
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+			assert.Equal(t, string(b), strings.TrimSpace(`
+SET client_min_messages = WARNING;
+SET synchronous_commit = LOCAL;
+CREATE TEMPORARY VIEW input AS
+SELECT name, version, schema
+  FROM pg_catalog.json_to_recordset(:'extensions')
+    AS extensions (name text, version text, schema text, databases json)
+ WHERE databases IS NULL OR pg_catalog.current_database() IN (
+       SELECT pg_catalog.json_array_elements_text(databases));
+SELECT pg_catalog.concat(
+       pg_catalog.format('CREATE EXTENSION IF NOT EXISTS %I', name),
+       CASE WHEN schema IS NOT NULL THEN pg_catalog.format(' SCHEMA %I', schema) END,
+       CASE WHEN version IS NOT NULL THEN pg_catalog.format(' VERSION %L', version) END,
+       ' CASCADE')
+  FROM input
+\gexec
+SELECT pg_catalog.format('ALTER EXTENSION %I UPDATE TO %L', input.name,
+       COALESCE(input.version, available.default_version))
+  FROM input
+  JOIN pg_catalog.pg_extension installed ON installed.extname = input.name
+  JOIN pg_catalog.pg_available_extensions available ON available.name = input.name
+ WHERE installed.extversion <> COALESCE(input.version, available.default_version)
+\gexec
+`))
+			return nil
+		}
+
+		var extensions []v1beta1.PostgresExtensionSpec
+		require.UnmarshalInto(t, &extensions, `[
+			{ name: postgis, version: 3.4.2, databases: [app, template1] },
+			{ name: pg_cron, schema: cron },
+		]`)
+
+		assert.NilError(t, WriteExtensionsInPostgreSQL(ctx, exec, extensions))
+		assert.Equal(t, calls, 1)
+	})
+}
+
+func TestExtensionsInPostgreSQL(t *testing.T) {
+	ctx := context.Background()
+
+	extensions := []v1beta1.PostgresExtensionSpec{
+		{Name: "pg_cron"}, {Name: "postgis"}, {Name: "missing"},
+	}
+
+	t.Run("Error", func(t *testing.T) {
+		expected := errors.New("pass-through")
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			assert.Assert(t, cmp.Contains(command, `--set=names=["pg_cron","postgis","missing"]`))
+			return expected
+		}
+
+		_, err := ExtensionsInPostgreSQL(ctx, exec, extensions)
+		assert.Equal(t, expected, err)
+	})
+
+	t.Run("Found", func(t *testing.T) {
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			_, _ = stdout.Write([]byte(strings.Join([]string{
+				`{"database" : "postgres", "available" : {"pg_cron" : "1.6", "postgis" : "3.5.0"}, "installed" : null}`,
+				`{"database" : "app", "available" : {"pg_cron" : "1.6", "postgis" : "3.5.0"}, "installed" : {"postgis" : "3.4.2"}}`,
+				``,
+				`{"database" : "template1", "available" : {"pg_cron" : "1.6", "postgis" : "3.5.0"}, "installed" : {"pg_cron" : "1.6", "postgis" : "3.5.0"}}`,
+				``,
+			}, "\n")))
+			return nil
+		}
+
+		status, err := ExtensionsInPostgreSQL(ctx, exec, extensions)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, status, []v1beta1.PostgresExtensionStatus{
+			{
+				Name:              "pg_cron",
+				AvailableVersion:  "1.6",
+				InstalledVersions: map[string]string{"template1": "1.6"},
+			},
+			{
+				Name:              "postgis",
+				AvailableVersion:  "3.5.0",
+				InstalledVersions: map[string]string{"app": "3.4.2", "template1": "3.5.0"},
+			},
+			{
+				Name: "missing",
+			},
+		})
+	})
+
+	t.Run("Unexpected", func(t *testing.T) {
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			_, _ = stdout.Write([]byte("nope\n"))
+			return nil
+		}
+
+		_, err := ExtensionsInPostgreSQL(ctx, exec, extensions)
+		assert.ErrorContains(t, err, "invalid")
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"strings"
 
 	pg_query "github.com/pganalyze/pg_query_go/v6"
@@ -56,6 +57,23 @@ func sanitizeAlterRoleOptions(options string) string {
 	return strings.TrimPrefix(sql, AlterRolePrefix)
 }
 
+// AlternateUserName returns the name of the login role that alternates with
+// the user called name when its password rotates.
+func AlternateUserName(name string) string { return name + "-alt" }
+
+// UserRotationStatus returns the status of the user called name in cluster, or
+// nil when its password does not rotate.
+func UserRotationStatus(
+	cluster *v1beta1.PostgresCluster, name string,
+) *v1beta1.PostgresUserStatus {
+	for i := range cluster.Status.Users {
+		if cluster.Status.Users[i].Name == name {
+			return &cluster.Status.Users[i]
+		}
+	}
+	return nil
+}
+
 // WriteUsersInPostgreSQL calls exec to create users that do not exist in
 // PostgreSQL. Once they exist, it updates their options and passwords and
 // grants them access to their specified databases. The databases must already
@@ -101,13 +119,46 @@ CREATE TEMPORARY TABLE input (id serial, data json);
 			options = `LOGIN SUPERUSER`
 		}
 
+		data := map[string]any{
+			"databases": databases,
+			"options":   options,
+			"username":  spec.Name,
+			"verifier":  verifiers[spec.Name],
+		}
+
+		// Users with rotating passwords have two login roles. The current one
+		// gets the verifier, the previous one keeps its password during the
+		// grace period, and any other has its password removed.
+		if status := UserRotationStatus(cluster, spec.Name); status != nil {
+			alternate := AlternateUserName(spec.Name)
+			data["alternate"] = alternate
+			data["alternate_verifier"] = nil
+
+			switch status.Login {
+			case alternate:
+				data["alternate_verifier"] = verifiers[spec.Name]
+				data["verifier"] = nil
+			}
+			switch status.PreviousLogin {
+			case alternate:
+				delete(data, "alternate_verifier")
+			case spec.Name:
+				delete(data, "verifier")
+			}
+		}
+
+		// Include the privileges of users that have them. Send an empty list
+		// rather than null so that memberships not in the list are revoked.
+		if spec.Privileges != nil && spec.Name != "postgres" {
+			data["database_privileges"] = "CONNECT"
+			if spec.Privileges.Database == v1beta1.PostgresDatabasePrivilegesAll {
+				data["database_privileges"] = "ALL PRIVILEGES"
+			}
+			data["roles"] = append([]string{}, spec.Privileges.Roles...)
+		}
+
 		if err == nil {
-			err = encoder.Encode(map[string]any{
-				"databases": databases,
-				"options":   options,
-				"username":  spec.Name,
-				"verifier":  verifiers[spec.Name],
-			})
+			err = encoder.Encode(data)
 		}
 	}
 	_, _ = sql.WriteString(`\.` + "\n")
@@ -132,27 +183,125 @@ SELECT pg_catalog.format('CREATE USER %I',
 `)
 
 	// Set any options from the specification. Validation ensures that the value
-	// does not contain semicolons.
+	// does not contain semicolons. A null verifier removes the password, and a
+	// missing verifier leaves the password unchanged.
 	// - https://www.postgresql.org/docs/current/sql-alterrole.html
 	_, _ = sql.WriteString(`
-SELECT pg_catalog.format('ALTER ROLE %I WITH %s PASSWORD %L',
+SELECT pg_catalog.format('ALTER ROLE %I WITH %s%s',
        pg_catalog.json_extract_path_text(input.data, 'username'),
        pg_catalog.json_extract_path_text(input.data, 'options'),
-       pg_catalog.json_extract_path_text(input.data, 'verifier'))
+       CASE WHEN pg_catalog.json_extract_path(input.data, 'verifier') IS NOT NULL
+            THEN pg_catalog.format(' PASSWORD %L',
+                 pg_catalog.json_extract_path_text(input.data, 'verifier')) END)
   FROM input ORDER BY input.id
 \gexec
 `)
 
-	// Grant access to any specified databases.
+	// Create the alternate login role of users with rotating passwords. It is
+	// a member of the user, and its sessions act as the user so that objects
+	// it creates belong to the user.
+	// - https://www.postgresql.org/docs/current/sql-createrole.html
+	// - https://www.postgresql.org/docs/current/sql-set-role.html
+	_, _ = sql.WriteString(`
+SELECT pg_catalog.format('CREATE ROLE %I LOGIN IN ROLE %I',
+       pg_catalog.json_extract_path_text(input.data, 'alternate'),
+       pg_catalog.json_extract_path_text(input.data, 'username'))
+  FROM input
+ WHERE pg_catalog.json_extract_path_text(input.data, 'alternate') IS NOT NULL
+   AND NOT EXISTS (
+       SELECT 1 FROM pg_catalog.pg_roles
+       WHERE rolname = pg_catalog.json_extract_path_text(input.data, 'alternate'))
+ ORDER BY input.id
+\gexec
+
+SELECT pg_catalog.format('ALTER ROLE %I WITH LOGIN%s',
+       pg_catalog.json_extract_path_text(input.data, 'alternate'),
+       CASE WHEN pg_catalog.json_extract_path(input.data, 'alternate_verifier') IS NOT NULL
+            THEN pg_catalog.format(' PASSWORD %L',
+                 pg_catalog.json_extract_path_text(input.data, 'alternate_verifier')) END),
+       pg_catalog.format('ALTER ROLE %I SET role TO %I',
+       pg_catalog.json_extract_path_text(input.data, 'alternate'),
+       pg_catalog.json_extract_path_text(input.data, 'username'))
+  FROM input
+ WHERE pg_catalog.json_extract_path_text(input.data, 'alternate') IS NOT NULL
+ ORDER BY input.id
+\gexec
+`)
+
+	// Grant access to any specified databases. Users without privileges in
+	// their specification have all privileges on their databases.
 	// - https://www.postgresql.org/docs/current/sql-grant.html
 	_, _ = sql.WriteString(`
-SELECT pg_catalog.format('GRANT ALL PRIVILEGES ON DATABASE %I TO %I',
+SELECT pg_catalog.format('GRANT %s ON DATABASE %I TO %I',
+       COALESCE(pg_catalog.json_extract_path_text(input.data, 'database_privileges'), 'ALL PRIVILEGES'),
        pg_catalog.json_array_elements_text(
        pg_catalog.json_extract_path(
        pg_catalog.json_strip_nulls(input.data), 'databases')),
        pg_catalog.json_extract_path_text(input.data, 'username'))
   FROM input ORDER BY input.id
 \gexec
+`)
+
+	// Revoke database privileges that are not specified from users with
+	// privileges in their specification. Owners keep their privileges.
+	// - https://www.postgresql.org/docs/current/sql-revoke.html
+	// - https://www.postgresql.org/docs/current/functions-info.html#FUNCTIONS-ACLITEM-FN-TABLE
+	_, _ = sql.WriteString(`
+SELECT pg_catalog.format(
+       CASE WHEN datname IN (
+                 SELECT pg_catalog.json_array_elements_text(
+                        pg_catalog.json_extract_path(
+                        pg_catalog.json_strip_nulls(input.data), 'databases')))
+            THEN 'REVOKE CREATE, TEMPORARY ON DATABASE %I FROM %I'
+            ELSE 'REVOKE ALL PRIVILEGES ON DATABASE %I FROM %I' END,
+       datname, rolname)
+  FROM input
+  JOIN pg_catalog.pg_roles
+    ON rolname = pg_catalog.json_extract_path_text(input.data, 'username')
+  JOIN pg_catalog.pg_database
+    ON datdba <> pg_roles.oid AND pg_roles.oid IN (
+       SELECT grantee FROM pg_catalog.aclexplode(datacl))
+ WHERE pg_catalog.json_extract_path_text(input.data, 'database_privileges') = 'CONNECT'
+    OR (pg_catalog.json_extract_path_text(input.data, 'database_privileges') IS NOT NULL
+        AND datname NOT IN (
+            SELECT pg_catalog.json_array_elements_text(
+                   pg_catalog.json_extract_path(
+                   pg_catalog.json_strip_nulls(input.data), 'databases'))))
+ ORDER BY input.id, datname
+\gexec
+`)
+
+	// Grant memberships in roles then revoke memberships that are not specified
+	// from users with privileges in their specification.
+	// - https://www.postgresql.org/docs/current/role-membership.html
+	// - https://www.postgresql.org/docs/current/catalog-pg-auth-members.html
+	_, _ = sql.WriteString(`
+SELECT pg_catalog.format('GRANT %I TO %I', role,
+       pg_catalog.json_extract_path_text(input.data, 'username'))
+  FROM input, pg_catalog.json_array_elements_text(
+       pg_catalog.json_extract_path(
+       pg_catalog.json_strip_nulls(input.data), 'roles')) AS role
+ WHERE NOT EXISTS (
+       SELECT 1 FROM pg_catalog.pg_auth_members
+         JOIN pg_catalog.pg_roles granted ON granted.oid = pg_auth_members.roleid
+         JOIN pg_catalog.pg_roles grantee ON grantee.oid = pg_auth_members.member
+        WHERE granted.rolname = role
+          AND grantee.rolname = pg_catalog.json_extract_path_text(input.data, 'username'))
+ ORDER BY input.id
+\gexec
+
+SELECT pg_catalog.format('REVOKE %I FROM %I', granted.rolname, grantee.rolname)
+  FROM input
+  JOIN pg_catalog.pg_roles grantee
+    ON grantee.rolname = pg_catalog.json_extract_path_text(input.data, 'username')
+  JOIN pg_catalog.pg_auth_members ON pg_auth_members.member = grantee.oid
+  JOIN pg_catalog.pg_roles granted ON granted.oid = pg_auth_members.roleid
+ WHERE pg_catalog.json_extract_path(input.data, 'roles') IS NOT NULL
+   AND granted.rolname NOT IN (
+       SELECT pg_catalog.json_array_elements_text(
+              pg_catalog.json_extract_path(input.data, 'roles')))
+ ORDER BY input.id, granted.rolname
+\gexec
 `)
 
 	// Commit (finish) the transaction.
@@ -166,6 +315,19 @@ SELECT pg_catalog.format('GRANT ALL PRIVILEGES ON DATABASE %I TO %I',
 
 	log.V(1).Info("wrote PostgreSQL users", "stdout", stdout, "stderr", stderr)
 
+	// Grant and revoke privileges inside the databases of users that have
+	// privileges in their specification.
+	var missing error
+	if err == nil {
+		err = WriteUsersPrivilegesInPostgreSQL(ctx, exec, users)
+
+		// Schemas may be created later, perhaps by the statements below.
+		// Report them after doing everything else.
+		if errors.As(err, new(*MissingSchemasError)) {
+			missing, err = err, nil
+		}
+	}
+
 	// The operator will attempt to write schemas for the users in the spec if
 	// 	* the feature gate is enabled and
 	// 	* the cluster is annotated.
@@ -177,6 +339,9 @@ SELECT pg_catalog.format('GRANT ALL PRIVILEGES ON DATABASE %I TO %I',
 		}
 	}
 
+	if err == nil {
+		err = missing
+	}
 	return err
 }
 
@@ -250,3 +415,199 @@ func WriteUsersSchemasInPostgreSQL(ctx context.Context, exec Executor,
 	}
 	return err
 }
+
+// WriteUsersPrivilegesInPostgreSQL calls exec to grant users the privileges in
+// their specification and revoke any others they have on schemas, tables, and
+// sequences they do not own. Users without privileges in their specification
+// are skipped.
+func WriteUsersPrivilegesInPostgreSQL(
+	ctx context.Context, exec Executor, users []v1beta1.PostgresUserSpec,
+) error {
+	log := logging.FromContext(ctx)
+
+	type schema struct {
+		Database  string   `json:"database"`
+		Schema    string   `json:"schema"`
+		Owners    []string `json:"owners"`
+		Schemas   string   `json:"schema_privileges"`
+		Sequences string   `json:"sequence_privileges"`
+		Tables    string   `json:"table_privileges"`
+	}
+	type user struct {
+		Username string   `json:"username"`
+		Schemas  []schema `json:"schemas"`
+	}
+
+	var input []user
+	for i := range users {
+		spec := users[i]
+		if spec.Privileges == nil || spec.Name == "postgres" {
+			continue
+		}
+
+		item := user{Username: spec.Name, Schemas: []schema{}}
+		for _, grant := range spec.Privileges.Schemas {
+			next := schema{
+				Database:  grant.Database,
+				Schema:    grant.Schema,
+				Owners:    append([]string{}, grant.DefaultPrivilegesFor...),
+				Schemas:   "USAGE",
+				Sequences: "SELECT",
+				Tables:    "SELECT",
+			}
+			if grant.Access == v1beta1.PostgresSchemaAccessReadWrite {
+				next.Sequences = "SELECT, USAGE"
+				next.Tables = "SELECT, INSERT, UPDATE, DELETE"
+			}
+			item.Schemas = append(item.Schemas, next)
+		}
+		input = append(input, item)
+	}
+
+	// Nothing to do when no users have privileges in their specification.
+	if len(input) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+
+	stdout, stderr, err := exec.ExecInAllDatabases(ctx,
+		strings.Join([]string{
+			// Quiet NOTICE messages from IF EXISTS statements.
+			// - https://www.postgresql.org/docs/current/runtime-config-client.html
+			`SET client_min_messages = WARNING;`,
+
+			// Do not wait for changes to be replicated. [Since PostgreSQL v9.1]
+			// - https://www.postgresql.org/docs/current/runtime-config-wal.html
+			`SET synchronous_commit = LOCAL;`,
+
+			// Prevent unexpected dereferences by emptying "search_path". This
+			// also causes regclass values to be qualified by their schema.
+			// - https://www.postgresql.org/docs/current/runtime-config-client.html#GUC-SEARCH-PATH
+			`SET search_path TO '';`,
+
+			// Revoke then grant privileges in a transaction so that no other
+			// session sees the privileges in between.
+			// - https://www.postgresql.org/docs/current/ddl-priv.html
+			`BEGIN;`,
+
+			// Gather the users and the schemas in the current database.
+			`CREATE TEMPORARY VIEW input_users AS`,
+			`SELECT username, schemas`,
+			`  FROM pg_catalog.json_to_recordset(:'users') AS users (username text, schemas json);`,
+			`CREATE TEMPORARY VIEW input_schemas AS`,
+			`SELECT input_users.username, schemas.*`,
+			`  FROM input_users, pg_catalog.json_to_recordset(input_users.schemas) AS schemas (`,
+			`       database text, schema text, owners json,`,
+			`       schema_privileges text, sequence_privileges text, table_privileges text)`,
+			` WHERE schemas.database = pg_catalog.current_database();`,
+
+			// Revoke privileges on schemas, tables, and sequences that the users
+			// do not own. System schemas are left alone.
+			// - https://www.postgresql.org/docs/current/sql-revoke.html
+			// - https://www.postgresql.org/docs/current/functions-info.html#FUNCTIONS-ACLITEM-FN-TABLE
+			`SELECT pg_catalog.format('REVOKE ALL PRIVILEGES ON SCHEMA %I FROM %I', nspname, grantee.rolname)`,
+			`  FROM input_users`,
+			`  JOIN pg_catalog.pg_roles grantee ON grantee.rolname = input_users.username`,
+			`  JOIN pg_catalog.pg_namespace ON nspowner <> grantee.oid`,
+			`   AND grantee.oid IN (SELECT grantee FROM pg_catalog.aclexplode(nspacl))`,
+			` WHERE nspname NOT LIKE 'pg\_%' AND nspname <> 'information_schema'`,
+			`\gexec`,
+			`SELECT pg_catalog.format('REVOKE ALL PRIVILEGES ON %s %s FROM %I',`,
+			`       CASE relkind WHEN 'S' THEN 'SEQUENCE' ELSE 'TABLE' END,`,
+			`       CAST(pg_class.oid AS regclass), grantee.rolname)`,
+			`  FROM input_users`,
+			`  JOIN pg_catalog.pg_roles grantee ON grantee.rolname = input_users.username`,
+			`  JOIN pg_catalog.pg_class ON relowner <> grantee.oid`,
+			`   AND grantee.oid IN (SELECT grantee FROM pg_catalog.aclexplode(relacl))`,
+			`  JOIN pg_catalog.pg_namespace ON pg_namespace.oid = relnamespace`,
+			` WHERE relkind IN ('r', 'p', 'v', 'm', 'f', 'S')`,
+			`   AND nspname NOT LIKE 'pg\_%' AND nspname <> 'information_schema'`,
+			`\gexec`,
+
+			// Revoke default privileges on objects created in schemas.
+			// - https://www.postgresql.org/docs/current/sql-alterdefaultprivileges.html
+			// - https://www.postgresql.org/docs/current/catalog-pg-default-acl.html
+			`SELECT pg_catalog.format(`,
+			`       'ALTER DEFAULT PRIVILEGES FOR ROLE %I IN SCHEMA %I REVOKE ALL PRIVILEGES ON %s FROM %I',`,
+			`       owner.rolname, nspname, CASE defaclobjtype`,
+			`         WHEN 'r' THEN 'TABLES' WHEN 'S' THEN 'SEQUENCES'`,
+			`         WHEN 'f' THEN 'FUNCTIONS' WHEN 'T' THEN 'TYPES' END,`,
+			`       grantee.rolname)`,
+			`  FROM input_users`,
+			`  JOIN pg_catalog.pg_roles grantee ON grantee.rolname = input_users.username`,
+			`  JOIN pg_catalog.pg_default_acl`,
+			`    ON grantee.oid IN (SELECT grantee FROM pg_catalog.aclexplode(defaclacl))`,
+			`  JOIN pg_catalog.pg_namespace ON pg_namespace.oid = defaclnamespace`,
+			`  JOIN pg_catalog.pg_roles owner ON owner.oid = defaclrole`,
+			` WHERE defaclobjtype IN ('r', 'S', 'f', 'T')`,
+			`\gexec`,
+
+			// Grant the specified privileges on schemas and on the tables and
+			// sequences in them, now and in the future. Schemas and owners that
+			// do not exist are skipped so they do not abort the transaction.
+			// - https://www.postgresql.org/docs/current/sql-grant.html
+			`SELECT pg_catalog.format('GRANT %s ON SCHEMA %I TO %I', schema_privileges, schema, username),`,
+			`       pg_catalog.format('GRANT %s ON ALL TABLES IN SCHEMA %I TO %I', table_privileges, schema, username),`,
+			`       pg_catalog.format('GRANT %s ON ALL SEQUENCES IN SCHEMA %I TO %I', sequence_privileges, schema, username)`,
+			`  FROM input_schemas`,
+			`  JOIN pg_catalog.pg_namespace ON nspname = schema`,
+			`\gexec`,
+			`SELECT pg_catalog.format(`,
+			`       'ALTER DEFAULT PRIVILEGES FOR ROLE %I IN SCHEMA %I GRANT %s ON TABLES TO %I',`,
+			`       owner, schema, table_privileges, username),`,
+			`       pg_catalog.format(`,
+			`       'ALTER DEFAULT PRIVILEGES FOR ROLE %I IN SCHEMA %I GRANT %s ON SEQUENCES TO %I',`,
+			`       owner, schema, sequence_privileges, username)`,
+			`  FROM input_schemas, pg_catalog.json_array_elements_text(owners) AS owner`,
+			`  JOIN pg_catalog.pg_roles ON rolname = owner`,
+			` WHERE schema IN (SELECT nspname FROM pg_catalog.pg_namespace)`,
+			`\gexec`,
+
+			// Print the schemas that were skipped, one per line.
+			// - https://www.postgresql.org/docs/current/app-psql.html#APP-PSQL-META-COMMANDS-PSET
+			`\pset format unaligned`,
+			`\pset tuples_only on`,
+			`SELECT DISTINCT pg_catalog.format('%I.%I', database, schema)`,
+			`  FROM input_schemas`,
+			` WHERE schema NOT IN (SELECT nspname FROM pg_catalog.pg_namespace);`,
+
+			`COMMIT;`,
+		}, "\n"),
+		map[string]string{
+			"users": string(data),
+
+			"ON_ERROR_STOP": "on", // Abort when any one statement fails.
+			"QUIET":         "on", // Do not print successful statements to stdout.
+		})
+
+	log.V(1).Info("wrote PostgreSQL privileges", "stdout", stdout, "stderr", stderr)
+
+	if err == nil {
+		var missing []string
+		for _, line := range strings.Split(stdout, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				missing = append(missing, line)
+			}
+		}
+		if len(missing) > 0 {
+			err = &MissingSchemasError{Schemas: missing}
+		}
+	}
+
+	return err
+}
+
+// MissingSchemasError is returned when users have privileges on schemas that
+// do not exist. The other privileges of users are written.
+type MissingSchemasError struct {
+	// Schemas are the missing schemas, qualified by their database.
+	Schemas []string
+}
+
+func (e *MissingSchemasError) Error() string {
+	return "schemas not found: " + strings.Join(e.Schemas, ", ")
+}
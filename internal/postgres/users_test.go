@@ -15,6 +15,7 @@ import (
 	"gotest.tools/v3/assert"
 
 	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/internal/testing/require"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
@@ -77,20 +78,97 @@ SELECT pg_catalog.format('CREATE USER %I',
  ORDER BY input.id
 \gexec
 
-SELECT pg_catalog.format('ALTER ROLE %I WITH %s PASSWORD %L',
+SELECT pg_catalog.format('ALTER ROLE %I WITH %s%s',
        pg_catalog.json_extract_path_text(input.data, 'username'),
        pg_catalog.json_extract_path_text(input.data, 'options'),
-       pg_catalog.json_extract_path_text(input.data, 'verifier'))
+       CASE WHEN pg_catalog.json_extract_path(input.data, 'verifier') IS NOT NULL
+            THEN pg_catalog.format(' PASSWORD %L',
+                 pg_catalog.json_extract_path_text(input.data, 'verifier')) END)
   FROM input ORDER BY input.id
 \gexec
 
-SELECT pg_catalog.format('GRANT ALL PRIVILEGES ON DATABASE %I TO %I',
+SELECT pg_catalog.format('CREATE ROLE %I LOGIN IN ROLE %I',
+       pg_catalog.json_extract_path_text(input.data, 'alternate'),
+       pg_catalog.json_extract_path_text(input.data, 'username'))
+  FROM input
+ WHERE pg_catalog.json_extract_path_text(input.data, 'alternate') IS NOT NULL
+   AND NOT EXISTS (
+       SELECT 1 FROM pg_catalog.pg_roles
+       WHERE rolname = pg_catalog.json_extract_path_text(input.data, 'alternate'))
+ ORDER BY input.id
+\gexec
+
+SELECT pg_catalog.format('ALTER ROLE %I WITH LOGIN%s',
+       pg_catalog.json_extract_path_text(input.data, 'alternate'),
+       CASE WHEN pg_catalog.json_extract_path(input.data, 'alternate_verifier') IS NOT NULL
+            THEN pg_catalog.format(' PASSWORD %L',
+                 pg_catalog.json_extract_path_text(input.data, 'alternate_verifier')) END),
+       pg_catalog.format('ALTER ROLE %I SET role TO %I',
+       pg_catalog.json_extract_path_text(input.data, 'alternate'),
+       pg_catalog.json_extract_path_text(input.data, 'username'))
+  FROM input
+ WHERE pg_catalog.json_extract_path_text(input.data, 'alternate') IS NOT NULL
+ ORDER BY input.id
+\gexec
+
+SELECT pg_catalog.format('GRANT %s ON DATABASE %I TO %I',
+       COALESCE(pg_catalog.json_extract_path_text(input.data, 'database_privileges'), 'ALL PRIVILEGES'),
        pg_catalog.json_array_elements_text(
        pg_catalog.json_extract_path(
        pg_catalog.json_strip_nulls(input.data), 'databases')),
        pg_catalog.json_extract_path_text(input.data, 'username'))
   FROM input ORDER BY input.id
 \gexec
+
+SELECT pg_catalog.format(
+       CASE WHEN datname IN (
+                 SELECT pg_catalog.json_array_elements_text(
+                        pg_catalog.json_extract_path(
+                        pg_catalog.json_strip_nulls(input.data), 'databases')))
+            THEN 'REVOKE CREATE, TEMPORARY ON DATABASE %I FROM %I'
+            ELSE 'REVOKE ALL PRIVILEGES ON DATABASE %I FROM %I' END,
+       datname, rolname)
+  FROM input
+  JOIN pg_catalog.pg_roles
+    ON rolname = pg_catalog.json_extract_path_text(input.data, 'username')
+  JOIN pg_catalog.pg_database
+    ON datdba <> pg_roles.oid AND pg_roles.oid IN (
+       SELECT grantee FROM pg_catalog.aclexplode(datacl))
+ WHERE pg_catalog.json_extract_path_text(input.data, 'database_privileges') = 'CONNECT'
+    OR (pg_catalog.json_extract_path_text(input.data, 'database_privileges') IS NOT NULL
+        AND datname NOT IN (
+            SELECT pg_catalog.json_array_elements_text(
+                   pg_catalog.json_extract_path(
+                   pg_catalog.json_strip_nulls(input.data), 'databases'))))
+ ORDER BY input.id, datname
+\gexec
+
+SELECT pg_catalog.format('GRANT %I TO %I', role,
+       pg_catalog.json_extract_path_text(input.data, 'username'))
+  FROM input, pg_catalog.json_array_elements_text(
+       pg_catalog.json_extract_path(
+       pg_catalog.json_strip_nulls(input.data), 'roles')) AS role
+ WHERE NOT EXISTS (
+       SELECT 1 FROM pg_catalog.pg_auth_members
+         JOIN pg_catalog.pg_roles granted ON granted.oid = pg_auth_members.roleid
+         JOIN pg_catalog.pg_roles grantee ON grantee.oid = pg_auth_members.member
+        WHERE granted.rolname = role
+          AND grantee.rolname = pg_catalog.json_extract_path_text(input.data, 'username'))
+ ORDER BY input.id
+\gexec
+
+SELECT pg_catalog.format('REVOKE %I FROM %I', granted.rolname, grantee.rolname)
+  FROM input
+  JOIN pg_catalog.pg_roles grantee
+    ON grantee.rolname = pg_catalog.json_extract_path_text(input.data, 'username')
+  JOIN pg_catalog.pg_auth_members ON pg_auth_members.member = grantee.oid
+  JOIN pg_catalog.pg_roles granted ON granted.oid = pg_auth_members.roleid
+ WHERE pg_catalog.json_extract_path(input.data, 'roles') IS NOT NULL
+   AND granted.rolname NOT IN (
+       SELECT pg_catalog.json_array_elements_text(
+              pg_catalog.json_extract_path(input.data, 'roles')))
+ ORDER BY input.id, granted.rolname
+\gexec
 COMMIT;`))
 			return nil
 		}
@@ -153,6 +231,95 @@ COMMIT;`))
 		assert.Equal(t, calls, 1)
 	})
 
+	t.Run("Privileges", func(t *testing.T) {
+		cluster := new(v1beta1.PostgresCluster)
+		calls := 0
+		exec := func(
+			_ context.Context, stdin io.Reader, _, _ io.Writer, command ...string,
+		) error {
+			calls++
+
+			// The second call writes privileges inside databases.
+			if calls > 1 {
+				assert.Equal(t, command[0], "bash")
+				return nil
+			}
+
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+			assert.Assert(t, cmp.Contains(string(b), `
+\copy input (data) from stdin with (format text)
+{"databases":null,"options":"NOLOGIN","username":"group","verifier":""}
+{"database_privileges":"CONNECT","databases":["db1"],"options":"","roles":["group"],"username":"user-connect","verifier":""}
+{"database_privileges":"ALL PRIVILEGES","databases":["db1"],"options":"","roles":[],"username":"user-all","verifier":""}
+{"databases":["db1"],"options":"","username":"user-unmanaged","verifier":""}
+{"databases":["postgres"],"options":"LOGIN SUPERUSER","username":"postgres","verifier":""}
+\.
+`))
+			return nil
+		}
+
+		var users []v1beta1.PostgresUserSpec
+		require.UnmarshalInto(t, &users, `[
+			{ name: group, options: NOLOGIN },
+			{ name: user-connect, databases: [db1], privileges: { roles: [group] } },
+			{ name: user-all, databases: [db1], privileges: { database: All } },
+			{ name: user-unmanaged, databases: [db1] },
+			{ name: postgres, privileges: { roles: [group] } },
+		]`)
+
+		assert.NilError(t, WriteUsersInPostgreSQL(ctx, cluster, exec, users, nil))
+		assert.Equal(t, calls, 2)
+	})
+
+	t.Run("Rotation", func(t *testing.T) {
+		cluster := new(v1beta1.PostgresCluster)
+		require.UnmarshalInto(t, &cluster.Status.Users, `[
+			{ name: user-first, login: user-first },
+			{ name: user-rotated, login: user-rotated-alt },
+			{ name: user-grace, login: user-grace-alt, previousLogin: user-grace },
+			{ name: user-back, login: user-back, previousLogin: user-back-alt },
+		]`)
+
+		calls := 0
+		exec := func(
+			_ context.Context, stdin io.Reader, _, _ io.Writer, command ...string,
+		) error {
+			calls++
+
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+			assert.Assert(t, cmp.Contains(string(b), `
+\copy input (data) from stdin with (format text)
+{"alternate":"user-first-alt","alternate_verifier":null,"databases":null,"options":"","username":"user-first","verifier":"v1"}
+{"alternate":"user-rotated-alt","alternate_verifier":"v2","databases":null,"options":"","username":"user-rotated","verifier":null}
+{"alternate":"user-grace-alt","alternate_verifier":"v3","databases":null,"options":"","username":"user-grace"}
+{"alternate":"user-back-alt","databases":null,"options":"","username":"user-back","verifier":"v4"}
+{"databases":null,"options":"","username":"user-other","verifier":"v5"}
+\.
+`))
+			return nil
+		}
+
+		assert.NilError(t, WriteUsersInPostgreSQL(ctx, cluster, exec,
+			[]v1beta1.PostgresUserSpec{
+				{Name: "user-first"},
+				{Name: "user-rotated"},
+				{Name: "user-grace"},
+				{Name: "user-back"},
+				{Name: "user-other"},
+			},
+			map[string]string{
+				"user-first":   "v1",
+				"user-rotated": "v2",
+				"user-grace":   "v3",
+				"user-back":    "v4",
+				"user-other":   "v5",
+			},
+		))
+		assert.Equal(t, calls, 1)
+	})
+
 	t.Run("PostgresSuperuser", func(t *testing.T) {
 		calls := 0
 		cluster := new(v1beta1.PostgresCluster)
@@ -187,6 +354,111 @@ COMMIT;`))
 	})
 }
 
+func TestWriteUsersPrivilegesInPostgreSQL(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Unspecified", func(t *testing.T) {
+		calls := 0
+		exec := func(
+			_ context.Context, stdin io.Reader, _, _ io.Writer, command ...string,
+		) error {
+			calls++
+			return nil
+		}
+
+		assert.NilError(t, WriteUsersPrivilegesInPostgreSQL(ctx, exec, nil))
+		assert.NilError(t, WriteUsersPrivilegesInPostgreSQL(ctx, exec, []v1beta1.PostgresUserSpec{
+			{Name: "user-without-privileges", Databases: []string{"db1"}},
+			{Name: "postgres", Privileges: &v1beta1.PostgresUserPrivilegesSpec{}},
+		}))
+		assert.Equal(t, calls, 0, "expected no calls to exec")
+	})
+
+	t.Run("Specified", func(t *testing.T) {
+		expected := errors.New("pass-through")
+		calls := 0
+		exec := func(
+			_ context.Context, stdin io.Reader, _, _ io.Writer, command ...string,
+		) error {
+			calls++
+
+			// The SQL runs in every database.
+			assert.Equal(t, command[0], "bash")
+			assert.Equal(t, command[len(command)-1], `--set=users=[`+
+				`{"username":"group","schemas":[]},`+
+				`{"username":"reader","schemas":[`+
+				`{"database":"db1","schema":"app","owners":[],"schema_privileges":"USAGE","sequence_privileges":"SELECT","table_privileges":"SELECT"}]},`+
+				`{"username":"writer","schemas":[`+
+				`{"database":"db1","schema":"app","owners":["owner"],"schema_privileges":"USAGE","sequence_privileges":"SELECT, USAGE","table_privileges":"SELECT, INSERT, UPDATE, DELETE"}]}]`)
+
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+
+			sql := string(b)
+			assert.Assert(t, strings.HasPrefix(sql, `SET client_min_messages = WARNING;`))
+			assert.Assert(t, cmp.Contains(sql, "\nBEGIN;\n"))
+			assert.Assert(t, strings.HasSuffix(sql, "\nCOMMIT;"))
+
+			// Privileges are revoked before they are granted.
+			revoke := strings.Index(sql, `REVOKE ALL PRIVILEGES ON SCHEMA`)
+			grant := strings.Index(sql, `GRANT %s ON SCHEMA`)
+			assert.Assert(t, revoke > 0 && grant > revoke)
+
+			return expected
+		}
+
+		var users []v1beta1.PostgresUserSpec
+		require.UnmarshalInto(t, &users, `[
+			{ name: group, options: NOLOGIN, privileges: {} },
+			{ name: unmanaged },
+			{ name: reader, privileges: {
+				database: Connect,
+				roles: [group],
+				schemas: [{ database: db1, schema: app, access: ReadOnly }],
+			} },
+			{ name: writer, privileges: {
+				schemas: [{
+					database: db1, schema: app, access: ReadWrite,
+					defaultPrivilegesFor: [owner],
+				}],
+			} },
+		]`)
+
+		assert.Equal(t, expected, WriteUsersPrivilegesInPostgreSQL(ctx, exec, users))
+		assert.Equal(t, calls, 1)
+	})
+
+	t.Run("MissingSchemas", func(t *testing.T) {
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, _ io.Writer, command ...string,
+		) error {
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+
+			// Grants on schemas that do not exist are skipped.
+			sql := string(b)
+			assert.Assert(t, cmp.Contains(sql, "JOIN pg_catalog.pg_namespace ON nspname = schema"))
+			assert.Assert(t, cmp.Contains(sql, "JOIN pg_catalog.pg_roles ON rolname = owner"))
+
+			_, _ = io.WriteString(stdout, "db1.app\n\ndb2.\"Other\"\n")
+			return nil
+		}
+
+		var users []v1beta1.PostgresUserSpec
+		require.UnmarshalInto(t, &users, `[
+			{ name: reader, privileges: {
+				schemas: [{ database: db1, schema: app, access: ReadOnly }],
+			} },
+		]`)
+
+		err := WriteUsersPrivilegesInPostgreSQL(ctx, exec, users)
+
+		var missing *MissingSchemasError
+		assert.Assert(t, errors.As(err, &missing), "got %v", err)
+		assert.DeepEqual(t, missing.Schemas, []string{"db1.app", `db2."Other"`})
+	})
+}
+
 func TestWriteUsersSchemasInPostgreSQL(t *testing.T) {
 	ctx := context.Background()
 
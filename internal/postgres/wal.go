@@ -0,0 +1,128 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/crunchydata/postgres-operator/internal/logging"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+// CreateRestorePoint uses exec to create a named restore point in the WAL
+// stream and then switch to a new WAL file so the restore point is archived
+// promptly. It returns the location and time of the restore point.
+// - https://www.postgresql.org/docs/current/functions-admin.html#FUNCTIONS-ADMIN-BACKUP
+func CreateRestorePoint(
+	ctx context.Context, exec Executor, name string,
+) (string, time.Time, error) {
+	stdout, stderr, err := exec.Exec(ctx, strings.NewReader(strings.Join([]string{
+		// Prevent unexpected dereferences by emptying "search_path".
+		// - https://www.postgresql.org/docs/current/runtime-config-client.html#GUC-SEARCH-PATH
+		`SET search_path TO '';`,
+
+		// Store the results in psql variables rather than printing them.
+		// - https://www.postgresql.org/docs/current/app-psql.html#APP-PSQL-META-COMMAND-GSET
+		`SELECT pg_catalog.pg_create_restore_point(:'name') AS lsn,`,
+		`       pg_catalog.date_part('epoch', pg_catalog.clock_timestamp())::bigint AS epoch \gset`,
+		`SELECT pg_catalog.pg_switch_wal() \gset`,
+		`\echo :lsn :epoch`,
+	}, "\n")),
+		map[string]string{
+			"name": name,
+
+			"ON_ERROR_STOP": "on", // Abort when any one statement fails.
+			"QUIET":         "on", // Do not print successful statements to stdout.
+		})
+
+	var lsn string
+	var epoch int64
+	if err == nil {
+		fields := strings.Fields(stdout)
+		if len(fields) == 2 {
+			lsn = fields[0]
+			epoch, err = strconv.ParseInt(fields[1], 10, 64)
+		} else {
+			err = errors.Errorf("unexpected output %q", stdout)
+		}
+	}
+	if err != nil {
+		logging.FromContext(ctx).V(1).Info("unable to create PostgreSQL restore point",
+			"stdout", stdout, "stderr", stderr)
+		return "", time.Time{}, err
+	}
+
+	return lsn, time.Unix(epoch, 0).UTC(), nil
+}
+
+// WALArchiveStatus uses exec to read the statistics of the WAL archiver and to
+// count the WAL files that are waiting to be archived. The count comes from
+// the ".ready" files PostgreSQL writes for each one. The archiver counts WAL
+// that pgBackRest drops as archived, so the last of those is found in the end
+// of logFile, the log of asynchronous archiving.
+// - https://www.postgresql.org/docs/current/monitoring-stats.html#MONITORING-PG-STAT-ARCHIVER-VIEW
+// - https://www.postgresql.org/docs/current/wal-internals.html
+// - https://pgbackrest.org/configuration.html#section-archive/option-archive-push-queue-max
+func WALArchiveStatus(
+	ctx context.Context, exec Executor, logFile string,
+) (*v1beta1.PGBackRestArchiveStatus, error) {
+	stdout, stderr, err := exec.Exec(ctx, strings.NewReader(strings.Join([]string{
+		// Prevent unexpected dereferences by emptying "search_path".
+		// - https://www.postgresql.org/docs/current/runtime-config-client.html#GUC-SEARCH-PATH
+		`SET search_path TO '';`,
+
+		// Print only the values of each row, without alignment or headers.
+		// - https://www.postgresql.org/docs/current/app-psql.html#APP-PSQL-META-COMMANDS-PSET
+		`\pset format unaligned`,
+		`\pset tuples_only on`,
+
+		`SELECT pg_catalog.json_build_object(`,
+		`       'pending', (`,
+		`         SELECT pg_catalog.count(*)`,
+		`           FROM pg_catalog.pg_ls_dir('pg_wal/archive_status') AS f`,
+		`          WHERE f LIKE '%.ready'),`,
+		`       'archivedCount', archived_count,`,
+		`       'lastArchivedWAL', last_archived_wal,`,
+		`       'lastArchivedTime', last_archived_time,`,
+		`       'failedCount', failed_count,`,
+		`       'lastFailedWAL', last_failed_wal,`,
+		`       'lastFailedTime', last_failed_time,`,
+
+		// Read at most the last 64KiB of the log. That may start in the middle
+		// of a character, so read bytes and escape those that are not ASCII.
+		// The greedy expression captures the last WAL file that was dropped.
+		`       'lastDroppedWAL', (`,
+		`         SELECT (pg_catalog.regexp_match(pg_catalog.encode(`,
+		`                   pg_catalog.pg_read_binary_file(:'logfile',`,
+		`                     GREATEST(f.size - 65536, 0), 65536, true), 'escape'),`,
+		`                 '.*dropped WAL file ''([0-9A-F]{24})'''))[1]`,
+		`           FROM pg_catalog.pg_stat_file(:'logfile', true) AS f))`,
+		`  FROM pg_catalog.pg_stat_archiver;`,
+	}, "\n")),
+		map[string]string{
+			"ON_ERROR_STOP": "on", // Abort when any one statement fails.
+			"QUIET":         "on", // Do not print successful statements to stdout.
+
+			"logfile": logFile,
+		})
+
+	var status v1beta1.PGBackRestArchiveStatus
+	if err == nil {
+		err = errors.WithStack(json.Unmarshal([]byte(stdout), &status))
+	}
+	if err != nil {
+		logging.FromContext(ctx).V(1).Info("unable to read PostgreSQL WAL archiver",
+			"stdout", stdout, "stderr", stderr)
+		return nil, err
+	}
+
+	return &status, nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+)
+
+func TestCreateRestorePoint(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Error", func(t *testing.T) {
+		expected := errors.New("pass-through")
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			assert.Assert(t, cmp.Contains(command, `--set=name=before-upgrade`))
+			assert.Assert(t, cmp.Contains(command, `--set=ON_ERROR_STOP=on`))
+			return expected
+		}
+
+		_, _, err := CreateRestorePoint(ctx, exec, "before-upgrade")
+		assert.Equal(t, expected, err)
+	})
+
+	t.Run("Created", func(t *testing.T) {
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+			assert.Assert(t, strings.Contains(string(b), `pg_create_restore_point(:'name')`))
+			assert.Assert(t, strings.Contains(string(b), `pg_switch_wal()`))
+
+			_, _ = stdout.Write([]byte("0/3000090 1735776000\n"))
+			return nil
+		}
+
+		lsn, at, err := CreateRestorePoint(ctx, exec, "before-upgrade")
+		assert.NilError(t, err)
+		assert.Equal(t, lsn, "0/3000090")
+		assert.Equal(t, at, time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC))
+	})
+
+	t.Run("Unexpected", func(t *testing.T) {
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			_, _ = stdout.Write([]byte("nope\n"))
+			return nil
+		}
+
+		_, _, err := CreateRestorePoint(ctx, exec, "before-upgrade")
+		assert.ErrorContains(t, err, "unexpected output")
+	})
+}
+
+func TestWALArchiveStatus(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Error", func(t *testing.T) {
+		expected := errors.New("pass-through")
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			assert.Assert(t, cmp.Contains(command, `--set=ON_ERROR_STOP=on`))
+			return expected
+		}
+
+		_, err := WALArchiveStatus(ctx, exec, "/tmp/some.log")
+		assert.Equal(t, expected, err)
+	})
+
+	t.Run("Statistics", func(t *testing.T) {
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+			assert.Assert(t, strings.Contains(string(b), `pg_catalog.pg_stat_archiver`))
+			assert.Assert(t, strings.Contains(string(b), `'%.ready'`))
+			assert.Assert(t, strings.Contains(string(b), `dropped WAL file`))
+			assert.Assert(t, cmp.Contains(command, `--set=logfile=/tmp/some.log`))
+
+			_, _ = stdout.Write([]byte(`{"pending" : 7, "archivedCount" : 120, ` +
+				`"lastArchivedWAL" : "000000010000000000000078", ` +
+				`"lastArchivedTime" : "2025-01-02T03:04:05.678901+00:00", ` +
+				`"failedCount" : 0, "lastFailedWAL" : null, "lastFailedTime" : null, ` +
+				`"lastDroppedWAL" : "000000010000000000000070"}` + "\n"))
+			return nil
+		}
+
+		status, err := WALArchiveStatus(ctx, exec, "/tmp/some.log")
+		assert.NilError(t, err)
+		assert.Equal(t, status.Pending, int64(7))
+		assert.Equal(t, status.ArchivedCount, int64(120))
+		assert.Equal(t, status.LastArchivedWAL, "000000010000000000000078")
+		assert.Assert(t, status.LastArchivedTime.Equal(&metav1.Time{
+			Time: time.Date(2025, time.January, 2, 3, 4, 5, 678901000, time.UTC),
+		}))
+		assert.Assert(t, status.LastFailedTime == nil)
+		assert.Equal(t, status.LastDroppedWAL, "000000010000000000000070")
+	})
+
+	t.Run("Unexpected", func(t *testing.T) {
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			_, _ = stdout.Write([]byte("nope\n"))
+			return nil
+		}
+
+		_, err := WALArchiveStatus(ctx, exec, "/tmp/some.log")
+		assert.ErrorContains(t, err, "invalid character")
+	})
+}
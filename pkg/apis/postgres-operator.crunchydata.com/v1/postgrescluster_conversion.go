@@ -0,0 +1,100 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+// AnnotationUserInterface holds the v1beta1 "spec.userInterface" field, as
+// JSON, while a PostgresCluster is represented as v1. That field is not
+// available in v1, and keeping it here makes conversion lossless.
+const AnnotationUserInterface = "postgres-operator.crunchydata.com/v1beta1-user-interface"
+
+var _ conversion.Convertible = (*PostgresCluster)(nil)
+
+// The v1 API has the same JSON representation as v1beta1 except for the
+// fields listed below. Conversion goes through JSON so that every other field
+// is copied exactly, including any that are added later to both versions.
+//
+//  - spec.userInterface moves to and from [AnnotationUserInterface].
+
+// ConvertTo implements [conversion.Convertible] by converting src into a
+// v1beta1 PostgresCluster.
+func (src *PostgresCluster) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*v1beta1.PostgresCluster)
+	if !ok {
+		return fmt.Errorf("expected a v1beta1 PostgresCluster but got %T", hub)
+	}
+
+	data, err := json.Marshal(src)
+	if err == nil {
+		*dst = v1beta1.PostgresCluster{}
+		err = json.Unmarshal(data, dst)
+	}
+	if err != nil {
+		return err
+	}
+
+	dst.SetGroupVersionKind(v1beta1.GroupVersion.WithKind("PostgresCluster"))
+
+	// Restore the v1beta1 field from its annotation. A value in the v1 spec
+	// takes precedence, though v1 validation should prevent that.
+	if value, ok := dst.Annotations[AnnotationUserInterface]; ok {
+		if dst.Spec.UserInterface == nil {
+			if err := json.Unmarshal([]byte(value), &dst.Spec.UserInterface); err != nil {
+				return fmt.Errorf("unable to convert %q annotation: %w", AnnotationUserInterface, err)
+			}
+		}
+
+		delete(dst.Annotations, AnnotationUserInterface)
+		if len(dst.Annotations) == 0 {
+			dst.Annotations = nil
+		}
+	}
+
+	return nil
+}
+
+// ConvertFrom implements [conversion.Convertible] by converting a v1beta1
+// PostgresCluster into dst.
+func (dst *PostgresCluster) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*v1beta1.PostgresCluster)
+	if !ok {
+		return fmt.Errorf("expected a v1beta1 PostgresCluster but got %T", hub)
+	}
+
+	data, err := json.Marshal(src)
+	if err == nil {
+		*dst = PostgresCluster{}
+		err = json.Unmarshal(data, dst)
+	}
+	if err != nil {
+		return err
+	}
+
+	dst.SetGroupVersionKind(GroupVersion.WithKind("PostgresCluster"))
+
+	// Move the v1beta1 field into its annotation.
+	if dst.Spec.UserInterface != nil {
+		value, err := json.Marshal(dst.Spec.UserInterface)
+		if err != nil {
+			return err
+		}
+
+		if dst.Annotations == nil {
+			dst.Annotations = make(map[string]string, 1)
+		}
+		dst.Annotations[AnnotationUserInterface] = string(value)
+		dst.Spec.UserInterface = nil
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
+	metafuzzer "k8s.io/apimachinery/pkg/apis/meta/fuzzer"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/randfill"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+func TestPostgresClusterConversion(t *testing.T) {
+	t.Run("UserInterface", func(t *testing.T) {
+		var hub v1beta1.PostgresCluster
+		assert.NilError(t, yaml.UnmarshalStrict([]byte(`{
+			apiVersion: postgres-operator.crunchydata.com/v1beta1,
+			kind: PostgresCluster,
+			metadata: { name: hippo },
+			spec: {
+				postgresVersion: 16,
+				userInterface: { pgAdmin: { image: some-image, dataVolumeClaimSpec: {} } },
+			},
+		}`), &hub))
+
+		var cluster PostgresCluster
+		assert.NilError(t, cluster.ConvertFrom(&hub))
+		assert.Equal(t, cluster.APIVersion, "postgres-operator.crunchydata.com/v1")
+		assert.Equal(t, cluster.Kind, "PostgresCluster")
+		assert.Equal(t, cluster.Name, "hippo")
+		assert.Equal(t, cluster.Spec.PostgresVersion, 16)
+		assert.Assert(t, cluster.Spec.UserInterface == nil)
+		assert.Equal(t, cluster.Annotations[AnnotationUserInterface],
+			`{"pgAdmin":{"dataVolumeClaimSpec":{"resources":{}},"image":"some-image"}}`)
+
+		var result v1beta1.PostgresCluster
+		assert.NilError(t, cluster.ConvertTo(&result))
+		assert.Equal(t, result.APIVersion, "postgres-operator.crunchydata.com/v1beta1")
+		assert.Assert(t, result.Annotations == nil)
+		assert.DeepEqual(t, result, hub)
+	})
+
+	t.Run("InvalidAnnotation", func(t *testing.T) {
+		var cluster PostgresCluster
+		cluster.Annotations = map[string]string{AnnotationUserInterface: "}"}
+
+		var result v1beta1.PostgresCluster
+		assert.ErrorContains(t, cluster.ConvertTo(&result), AnnotationUserInterface)
+	})
+
+	t.Run("WrongHub", func(t *testing.T) {
+		var cluster PostgresCluster
+		assert.ErrorContains(t, cluster.ConvertFrom(&notPostgresCluster{}), "v1beta1 PostgresCluster")
+		assert.ErrorContains(t, cluster.ConvertTo(&notPostgresCluster{}), "v1beta1 PostgresCluster")
+	})
+}
+
+type notPostgresCluster struct{ v1beta1.PGAdmin }
+
+func (*notPostgresCluster) Hub() {}
+
+// postgresClusterFuzzer returns a [randfill.Filler] that populates API objects
+// with values that survive a round trip through JSON.
+func postgresClusterFuzzer(t testing.TB, seed int64) *randfill.Filler {
+	scheme := runtime.NewScheme()
+	assert.NilError(t, v1beta1.AddToScheme(scheme))
+	assert.NilError(t, AddToScheme(scheme))
+
+	funcs := fuzzer.MergeFuzzerFuncs(metafuzzer.Funcs,
+		func(serializer.CodecFactory) []any {
+			return []any{
+				// Fill durations with values that can be parsed.
+				func(d *v1beta1.Duration, c randfill.Continue) {
+					next, err := v1beta1.NewDuration(fmt.Sprintf("%dm", c.Intn(1000)))
+					assert.NilError(t, err)
+					*d = *next
+				},
+
+				// Fill schemaless fields with simple JSON values.
+				func(s *v1beta1.SchemalessObject, c randfill.Continue) {
+					if c.Bool() {
+						*s = v1beta1.SchemalessObject{
+							c.String(0): c.String(0),
+							c.String(0): map[string]any{c.String(0): c.Bool()},
+						}
+					}
+				},
+			}
+		},
+	)
+
+	return fuzzer.FuzzerFor(funcs, rand.NewSource(seed), serializer.NewCodecFactory(scheme))
+}
+
+// fillFromJSON populates object with random values then decodes it from JSON,
+// the same way objects are decoded when they are sent to a conversion webhook.
+func fillFromJSON[T any](t testing.TB, filler *randfill.Filler, object *T) {
+	var random T
+	filler.Fill(&random)
+
+	data, err := json.Marshal(random)
+	assert.NilError(t, err)
+	assert.NilError(t, json.Unmarshal(data, object))
+}
+
+func TestPostgresClusterConversionRoundTrip(t *testing.T) {
+	const iterations = 200
+	filler := postgresClusterFuzzer(t, rand.Int63())
+
+	// The Kubernetes API stores objects as JSON, so conversion is lossless
+	// when the JSON of the result matches the JSON of the original.
+	asJSON := func(t testing.TB, object any) any {
+		data, err := json.Marshal(object)
+		assert.NilError(t, err)
+
+		var result any
+		assert.NilError(t, json.Unmarshal(data, &result))
+		return result
+	}
+
+	t.Run("v1beta1", func(t *testing.T) {
+		for range iterations {
+			var original v1beta1.PostgresCluster
+			fillFromJSON(t, filler, &original)
+			original.SetGroupVersionKind(v1beta1.GroupVersion.WithKind("PostgresCluster"))
+
+			var cluster PostgresCluster
+			var result v1beta1.PostgresCluster
+			assert.NilError(t, cluster.ConvertFrom(original.DeepCopy()))
+			assert.Assert(t, cluster.Spec.UserInterface == nil)
+			assert.NilError(t, cluster.ConvertTo(&result))
+
+			assert.DeepEqual(t, asJSON(t, original), asJSON(t, result))
+		}
+	})
+
+	t.Run("v1", func(t *testing.T) {
+		for range iterations {
+			var original PostgresCluster
+			fillFromJSON(t, filler, &original)
+			original.SetGroupVersionKind(GroupVersion.WithKind("PostgresCluster"))
+
+			// This field is not allowed in v1.
+			original.Spec.UserInterface = nil
+
+			var hub v1beta1.PostgresCluster
+			var result PostgresCluster
+			assert.NilError(t, original.DeepCopy().ConvertTo(&hub))
+			assert.NilError(t, result.ConvertFrom(&hub))
+
+			assert.DeepEqual(t, asJSON(t, original), asJSON(t, result))
+		}
+	})
+}
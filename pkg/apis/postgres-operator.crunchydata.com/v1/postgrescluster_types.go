@@ -56,6 +56,18 @@ type PostgresClusterSpec struct {
 	// +optional
 	CustomReplicationClientTLSSecret *corev1.SecretProjection `json:"customReplicationTLSSecret,omitempty"`
 
+	// Use cert-manager to issue the PostgreSQL server certificate, the
+	// replication client certificate, and the PgBouncer frontend certificate
+	// rather than the operator's certificate authority. Custom TLS secrets
+	// take precedence. The operator's certificates are used until cert-manager
+	// has issued all of them. When trust-manager is installed, the authority of
+	// the server certificate is distributed to the namespace through a Bundle.
+	// Removing this deletes the Certificates, their Secrets, and the Bundle.
+	// More info: https://cert-manager.io/docs/usage/certificate
+	// ---
+	// +optional
+	CertManager *v1beta1.CertManagerSpec `json:"certManager,omitempty"`
+
 	// DatabaseInitSQL defines a ConfigMap containing custom SQL that will
 	// be run after the cluster is initialized. This ConfigMap must be in the same
 	// namespace as the cluster.
@@ -68,6 +80,16 @@ type PostgresClusterSpec struct {
 	// +optional
 	DisableDefaultPodScheduling *bool `json:"disableDefaultPodScheduling,omitempty"`
 
+	// Extensions to install and keep updated in PostgreSQL. Removing an
+	// extension from this list does NOT drop it.
+	// More info: https://www.postgresql.org/docs/current/extend-extensions.html
+	// ---
+	// +kubebuilder:validation:MaxItems=64
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Extensions []v1beta1.PostgresExtensionSpec `json:"extensions,omitempty"`
+
 	// The image name to use for PostgreSQL containers. When omitted, the value
 	// comes from an operator environment variable. For standard PostgreSQL images,
 	// the format is RELATED_IMAGE_POSTGRES_{postgresVersion},
@@ -194,6 +216,10 @@ type PostgresClusterSpec struct {
 	// The default creates one user that can access one database matching the
 	// PostgresCluster name. An empty list creates no users. Removing a user
 	// from this list does NOT drop the user nor revoke their access.
+	// ---
+	// Users with rotating passwords also have a login role named "<name>-alt".
+	// +kubebuilder:validation:XValidation:rule=`self.all(u, !has(u.password) || !has(u.password.rotation) || !self.exists(v, v.name == u.name + '-alt'))`,message="the name of a user cannot be the name of another user with \"-alt\" appended when that user has rotating passwords"
+	//
 	// +listType=map
 	// +listMapKey=name
 	// +kubebuilder:validation:MaxItems=64
@@ -292,6 +318,11 @@ type PostgresClusterDataSource struct {
 	// +optional
 	Options []string `json:"options,omitempty"`
 
+	// The point in the WAL stream at which to stop recovery. When set, the
+	// options cannot include "--type" nor "--target".
+	// +optional
+	Target *v1beta1.PGBackRestRecoveryTarget `json:"target,omitempty"`
+
 	// Resource requirements for the pgBackRest restore Job.
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resources,omitzero"`
@@ -353,9 +384,30 @@ type Backups struct {
 // PostgresClusterStatus defines the observed state of PostgresCluster
 type PostgresClusterStatus struct {
 
+	// The Secret of the first user with the ServiceBinding connection format.
+	// This makes the PostgresCluster a Provisioned Service.
+	// More info: https://servicebinding.io/spec/core/1.1.0/#provisioned-service
+	// +optional
+	Binding *corev1.LocalObjectReference `json:"binding,omitempty"`
+
+	// Certificates that the cluster presents or trusts, and when they expire.
+	// +listType=atomic
+	// +optional
+	Certificates []v1beta1.CertificateStatus `json:"certificates,omitempty"`
+
 	// Identifies the databases that have been installed into PostgreSQL.
 	DatabaseRevision string `json:"databaseRevision,omitempty"`
 
+	// Versions of the extensions in "spec.extensions" that are available in
+	// and installed into PostgreSQL.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Extensions []v1beta1.PostgresExtensionStatus `json:"extensions,omitempty"`
+
+	// Identifies the extensions that have been installed into PostgreSQL.
+	ExtensionsRevision string `json:"extensionsRevision,omitempty"`
+
 	// Current state of PostgreSQL instances.
 	// +listType=map
 	// +listMapKey=name
@@ -384,6 +436,10 @@ type PostgresClusterStatus struct {
 	// +optional
 	Proxy PostgresProxyStatus `json:"proxy,omitzero"`
 
+	// Progress of the most recent rotation of the root certificate authority.
+	// +optional
+	RootCertificateRotation *v1beta1.RootCertificateRotationStatus `json:"rootCertificateRotation,omitempty"`
+
 	// The instance that should be started first when bootstrapping and/or starting a
 	// PostgresCluster.
 	// +optional
@@ -400,6 +456,14 @@ type PostgresClusterStatus struct {
 	// Identifies the users that have been installed into PostgreSQL.
 	UsersRevision string `json:"usersRevision,omitempty"`
 
+	// Current state of PostgreSQL users whose passwords rotate.
+	// ---
+	// +kubebuilder:validation:MaxItems=64
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Users []v1beta1.PostgresUserStatus `json:"users,omitempty"`
+
 	// Current state of PostgreSQL cluster monitoring tool configuration
 	// +optional
 	Monitoring MonitoringStatus `json:"monitoring,omitzero"`
@@ -0,0 +1,39 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+type CertManagerSpec struct {
+	// The cert-manager issuer that signs certificates. The issuer must include
+	// its certificate authority in the "ca.crt" key of the Secrets it writes.
+	// ---
+	// +required
+	IssuerRef CertManagerIssuerReference `json:"issuerRef"`
+}
+
+// CertManagerIssuerReference refers to a cert-manager Issuer or ClusterIssuer.
+// More info: https://cert-manager.io/docs/usage/issuer
+type CertManagerIssuerReference struct {
+	// The name of the issuer. An Issuer must be in the same namespace as
+	// the cluster.
+	// ---
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +required
+	Name string `json:"name"`
+
+	// The kind of the issuer, such as Issuer or ClusterIssuer.
+	// ---
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:default=Issuer
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// The API group of the issuer.
+	// ---
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:default=cert-manager.io
+	// +optional
+	Group string `json:"group,omitempty"`
+}
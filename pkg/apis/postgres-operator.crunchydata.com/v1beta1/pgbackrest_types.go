@@ -6,6 +6,7 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -82,6 +83,20 @@ type PGBackRestScheduledBackupStatus struct {
 	// The number of Pods for the manual backup Job that reached the "Failed" phase.
 	// +optional
 	Failed int32 `json:"failed,omitempty"`
+
+	// Whether or not the Job is waiting for other backups to finish before it
+	// starts. This happens when the operator limits concurrent backups.
+	// +optional
+	Queued bool `json:"queued,omitempty"`
+
+	// The position of the queued Job among all those waiting to start, beginning at one.
+	// +optional
+	QueuePosition int32 `json:"queuePosition,omitempty"`
+
+	// The next time the CronJob is scheduled to create a backup Job. It is
+	// represented in RFC3339 form and is in UTC.
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
 }
 
 // PGBackRestArchive defines a pgBackRest archive configuration
@@ -103,6 +118,12 @@ type PGBackRestArchive struct {
 	// +optional
 	Global map[string]string `json:"global,omitempty"`
 
+	// Defines how PostgreSQL instances push WAL to repositories. pgBackRest
+	// archives WAL asynchronously, so PostgreSQL is not held up by a slow repository.
+	// More info: https://pgbackrest.org/user-guide.html#async-archiving
+	// +optional
+	ArchivePush *PGBackRestArchivePush `json:"archivePush,omitempty"`
+
 	// The image name to use for pgBackRest containers.  Utilized to run
 	// pgBackRest repository hosts and backups. The image may also be set using
 	// the RELATED_IMAGE_PGBACKREST environment variable
@@ -113,6 +134,10 @@ type PGBackRestArchive struct {
 	// +optional
 	Jobs *BackupJobs `json:"jobs,omitempty"`
 
+	// Defines when the backup schedules of every repository run.
+	// +optional
+	Scheduling *PGBackRestBackupScheduling `json:"scheduling,omitempty"`
+
 	// Defines a pgBackRest repository
 	// +kubebuilder:validation:MinItems=1
 	// +listType=map
@@ -129,6 +154,11 @@ type PGBackRestArchive struct {
 	// +optional
 	Manual *PGBackRestManualBackup `json:"manual,omitempty"`
 
+	// Defines details for removing backups from a repository on demand. The
+	// expire Job is triggered by the "pgbackrest-expire" annotation.
+	// +optional
+	Expire *PGBackRestManualExpire `json:"expire,omitempty"`
+
 	// Defines details for performing an in-place restore using pgBackRest
 	// +optional
 	Restore *PGBackRestRestore `json:"restore,omitempty"`
@@ -138,6 +168,45 @@ type PGBackRestArchive struct {
 	Sidecars *PGBackRestSidecars `json:"sidecars,omitempty"`
 }
 
+// PGBackRestArchivePush defines how pgBackRest archives the WAL of PostgreSQL instances.
+type PGBackRestArchivePush struct {
+	// The number of processes that push WAL to repositories at the same time.
+	// Defaults to 1.
+	// More info: https://pgbackrest.org/configuration.html#section-general/option-process-max
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=999
+	// +optional
+	ProcessMax *int32 `json:"processMax,omitempty"`
+
+	// The amount of WAL that can wait to be archived. When more than this
+	// accumulates, pgBackRest discards it so PostgreSQL does not run out of
+	// space. Point-in-time recovery is not possible across discarded WAL, and
+	// a new backup is needed to protect the cluster again.
+	// More info: https://pgbackrest.org/configuration.html#section-archive/option-archive-push-queue-max
+	// +optional
+	QueueMax *resource.Quantity `json:"queueMax,omitempty"`
+
+	// A dedicated volume for the spool of asynchronous archiving. Defaults to
+	// a directory alongside WAL.
+	// +optional
+	Spool *PGBackRestSpoolVolume `json:"spool,omitempty"`
+}
+
+// PGBackRestSpoolVolume defines the volume of the pgBackRest spool on PostgreSQL instances.
+// ---
+// +kubebuilder:validation:XValidation:rule=`has(self.emptyDir) != has(self.volumeClaimSpec)`,message="exactly one of emptyDir or volumeClaimSpec is required"
+type PGBackRestSpoolVolume struct {
+	// A directory that shares the lifetime of each instance Pod.
+	// More info: https://kubernetes.io/docs/concepts/storage/volumes/#emptydir
+	// +optional
+	EmptyDir *corev1.EmptyDirVolumeSource `json:"emptyDir,omitempty"`
+
+	// An ephemeral volume that is created and deleted with each instance Pod.
+	// More info: https://kubernetes.io/docs/concepts/storage/ephemeral-volumes
+	// +optional
+	VolumeClaimSpec *VolumeClaimSpec `json:"volumeClaimSpec,omitempty"`
+}
+
 // PGBackRestSidecars defines the configuration for pgBackRest sidecar containers
 type PGBackRestSidecars struct {
 	// Defines the configuration for the pgBackRest sidecar container
@@ -177,6 +246,28 @@ type BackupJobs struct {
 	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
 }
 
+// PGBackRestBackupScheduling defines options of every backup schedule of a cluster.
+type PGBackRestBackupScheduling struct {
+	// The time zone of backup schedules, such as "America/New_York". Defaults
+	// to the time zone of the Kubernetes controller manager, which is usually UTC.
+	// It must be a name in the IANA time zone database.
+	// More info: https://kubernetes.io/docs/concepts/workloads/controllers/cron-jobs/#time-zones
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=64
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
+
+	// Moves backup schedules later by up to this many minutes so that many
+	// clusters do not start backups at the same time. The delay is derived
+	// from the UID of the cluster; it is the same for every schedule of the
+	// cluster and does not change. Schedules stay within the hour they name;
+	// those that run at minute 50 with a delay of 15 run at minute 59 instead.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=59
+	// +optional
+	JitterMinutes *int32 `json:"jitterMinutes,omitempty"`
+}
+
 // PGBackRestManualBackup contains information that is used for creating a
 // pgBackRest backup that is invoked manually (i.e. it's unscheduled).
 type PGBackRestManualBackup struct {
@@ -191,6 +282,30 @@ type PGBackRestManualBackup struct {
 	Options []string `json:"options,omitempty"`
 }
 
+// PGBackRestManualExpire contains information that is used for removing backups from a
+// pgBackRest repository on demand. Without set or retention, the retention policy of the
+// repository is applied.
+// ---
+// +kubebuilder:validation:XValidation:rule=`!has(self.set) || !has(self.retention)`,message="only one of set or retention can be specified"
+type PGBackRestManualExpire struct {
+	// The name of the pgBackRest repo to remove backups from.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=^repo[1-4]
+	RepoName string `json:"repoName"`
+
+	// The label of a backup to remove, such as "20250102-030405F". Differential
+	// and incremental backups that depend on it are removed too.
+	// More info: https://pgbackrest.org/command.html#command-expire/category-command/option-set
+	// +kubebuilder:validation:Pattern=`^[0-9]{8}-[0-9]{6}F(_[0-9]{8}-[0-9]{6}[DI])?$`
+	// +optional
+	Set string `json:"set,omitempty"`
+
+	// Retention to apply in place of the repository's for this expire only.
+	// Fields that are not set here keep their values from the repository.
+	// +optional
+	Retention *PGBackRestRetention `json:"retention,omitempty"`
+}
+
 // PGBackRestRepoHost represents a pgBackRest dedicated repository host
 type PGBackRestRepoHost struct {
 
@@ -266,6 +381,14 @@ type PGBackRestBackupSchedules struct {
 	// +optional
 	// +kubebuilder:validation:MinLength=6
 	Incremental *string `json:"incremental,omitempty"`
+
+	// Defines the Cron schedule for checking that the backups and WAL in the
+	// repository are usable with "pgbackrest verify".
+	// Follows the standard Cron schedule syntax:
+	// https://k8s.io/docs/concepts/workloads/controllers/cron-jobs/#cron-schedule-syntax
+	// +optional
+	// +kubebuilder:validation:MinLength=6
+	Verify *string `json:"verify,omitempty"`
 }
 
 // PGBackRestStatus defines the status of pgBackRest within a PostgresCluster
@@ -275,6 +398,10 @@ type PGBackRestStatus struct {
 	// +optional
 	ManualBackup *PGBackRestJobStatus `json:"manualBackup,omitempty"`
 
+	// Status information for backups removed on demand
+	// +optional
+	Expire *PGBackRestJobStatus `json:"expire,omitempty"`
+
 	// Status information for scheduled backups
 	// +optional
 	ScheduledBackups []PGBackRestScheduledBackupStatus `json:"scheduledBackups,omitempty"`
@@ -292,9 +419,123 @@ type PGBackRestStatus struct {
 	// Status information for in-place restores
 	// +optional
 	Restore *PGBackRestJobStatus `json:"restore,omitempty"`
+
+	// When "pgbackrest info" last ran to report the backups in each repository.
+	// The backups are unchanged when it fails.
+	// +optional
+	CatalogTime *metav1.Time `json:"catalogTime,omitempty"`
+
+	// Status information for the most recent verification of each repository
+	// +optional
+	// +listType=map
+	// +listMapKey=repo
+	Verifications []PGBackRestVerificationStatus `json:"verifications,omitempty"`
+
+	// Named restore points created by the operator, oldest first. A restore
+	// can target one of these using its name.
+	// +kubebuilder:validation:MaxItems=20
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	RestorePoints []PGBackRestRestorePointStatus `json:"restorePoints,omitempty"`
+
+	// Status information for WAL archiving of the primary instance
+	// +optional
+	Archive *PGBackRestArchiveStatus `json:"archive,omitempty"`
+}
+
+// PGBackRestArchiveStatus describes WAL archiving of the primary PostgreSQL instance.
+// More info: https://www.postgresql.org/docs/current/monitoring-stats.html#MONITORING-PG-STAT-ARCHIVER-VIEW
+type PGBackRestArchiveStatus struct {
+
+	// The number of WAL files waiting to be archived
+	// +optional
+	Pending int64 `json:"pending"`
+
+	// The number of WAL files archived successfully
+	// +optional
+	ArchivedCount int64 `json:"archivedCount"`
+
+	// The name of the WAL file most recently archived successfully
+	// +optional
+	LastArchivedWAL string `json:"lastArchivedWAL,omitempty"`
+
+	// When a WAL file was most recently archived successfully
+	// +optional
+	LastArchivedTime *metav1.Time `json:"lastArchivedTime,omitempty"`
+
+	// The number of failed attempts to archive WAL files
+	// +optional
+	FailedCount int64 `json:"failedCount"`
+
+	// The name of the WAL file of the most recent failed attempt
+	// +optional
+	LastFailedWAL string `json:"lastFailedWAL,omitempty"`
+
+	// When a WAL file most recently failed to archive
+	// +optional
+	LastFailedTime *metav1.Time `json:"lastFailedTime,omitempty"`
+
+	// The name of the WAL file most recently dropped by pgBackRest because
+	// more than archive-push-queue-max was waiting to be archived
+	// More info: https://pgbackrest.org/configuration.html#section-archive/option-archive-push-queue-max
+	// +optional
+	LastDroppedWAL string `json:"lastDroppedWAL,omitempty"`
+
+	// When the most recently dropped WAL file was first observed
+	// +optional
+	LastDroppedTime *metav1.Time `json:"lastDroppedTime,omitempty"`
+
+	// When these values were observed
+	// +optional
+	ObservedTime *metav1.Time `json:"observedTime,omitempty"`
+}
+
+// PGBackRestRestorePointStatus describes a named restore point in the WAL stream.
+type PGBackRestRestorePointStatus struct {
+
+	// The name of the restore point
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// The write-ahead log location of the restore point
+	// +kubebuilder:validation:Required
+	LSN string `json:"lsn"`
+
+	// When the restore point was created
+	// +kubebuilder:validation:Required
+	Time metav1.Time `json:"time"`
+}
+
+// PGBackRestVerificationStatus is the result of "pgbackrest verify" in a repository.
+type PGBackRestVerificationStatus struct {
+
+	// The name of the pgBackRest repository
+	// +kubebuilder:validation:Required
+	RepoName string `json:"repo"`
+
+	// The name of the verification Job
+	// +kubebuilder:validation:Required
+	JobName string `json:"jobName"`
+
+	// When the verification finished
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Whether or not every backup and WAL file in the repository is usable
+	// +kubebuilder:validation:Required
+	Verified bool `json:"verified"`
+
+	// Files that pgBackRest found to be missing or corrupt, when any are reported
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=atomic
+	// +optional
+	CorruptFiles []string `json:"corruptFiles,omitempty"`
 }
 
 // PGBackRestRepo represents a pgBackRest repository.  Only one of its members may be specified.
+// ---
+// +kubebuilder:validation:XValidation:rule=`has(self.encryption) == has(oldSelf.encryption) && (!has(self.encryption) || (self.encryption.cipherType == oldSelf.encryption.cipherType && has(self.encryption.passphrase) == has(oldSelf.encryption.passphrase)))`,message="encryption cannot change on an existing repository"
 type PGBackRestRepo struct {
 	// Please note that as a Union type that follows OpenAPI 3.0 'oneOf' semantics, the following KEP
 	// will be applicable once implemented:
@@ -311,6 +552,17 @@ type PGBackRestRepo struct {
 	// +optional
 	BackupSchedules *PGBackRestBackupSchedules `json:"schedules,omitempty"`
 
+	// Defines how long backups and WAL are kept in the repository. Settings for
+	// this repository in "global" take precedence over these.
+	// +optional
+	Retention *PGBackRestRetention `json:"retention,omitempty"`
+
+	// Encrypts the files in the repository. Encryption cannot be enabled nor
+	// disabled on a repository that already contains backups.
+	// More info: https://pgbackrest.org/user-guide.html#quickstart/configure-encryption
+	// +optional
+	Encryption *PGBackRestRepoEncryption `json:"encryption,omitempty"`
+
 	// Represents a pgBackRest repository that is created using Azure storage
 	// +optional
 	Azure *RepoAzure `json:"azure,omitempty"`
@@ -324,11 +576,72 @@ type PGBackRestRepo struct {
 	// +optional
 	S3 *RepoS3 `json:"s3,omitempty"`
 
+	// Represents a pgBackRest repository on an SFTP server
+	// +optional
+	SFTP *RepoSFTP `json:"sftp,omitempty"`
+
 	// Represents a pgBackRest repository that is created using a PersistentVolumeClaim
 	// +optional
 	Volume *RepoPVC `json:"volume,omitempty"`
 }
 
+// PGBackRestRepoEncryption defines how pgBackRest encrypts a repository.
+type PGBackRestRepoEncryption struct {
+	// The cipher used to encrypt the repository. Defaults to "aes-256-cbc".
+	// More info: https://pgbackrest.org/configuration.html#section-repository/option-repo-cipher-type
+	// +kubebuilder:validation:Enum={aes-256-cbc}
+	// +kubebuilder:default="aes-256-cbc"
+	// +optional
+	CipherType string `json:"cipherType,omitempty"`
+
+	// The passphrase of an encrypted repository. This is required to restore
+	// from an encrypted repository in dataSource.pgbackrest. When omitted in
+	// spec.backups, the operator generates a passphrase and keeps it in the
+	// Secret "<cluster>-pgbackrest-cipher", which is not deleted with the cluster.
+	// +optional
+	Passphrase *SecretKeyRef `json:"passphrase,omitempty"`
+}
+
+// PGBackRestRetention defines which backups and WAL pgBackRest keeps in a repository.
+// Anything older expires after each successful backup.
+// - https://pgbackrest.org/configuration.html#section-repository/option-repo-retention-full
+// ---
+// +kubebuilder:validation:XValidation:rule=`!has(self.fullType) || has(self.full)`,message="fullType requires full"
+// +kubebuilder:validation:XValidation:rule=`!has(self.archiveType) || self.archiveType != 'incr' || has(self.archive)`,message="archive is required when archiveType is incr"
+// +kubebuilder:validation:XValidation:rule=`!has(self.archiveType) || self.archiveType != 'diff' || has(self.archive) || has(self.diff)`,message="archive or diff is required when archiveType is diff"
+type PGBackRestRetention struct {
+
+	// The number of full backups to keep. When fullType is "time", this is
+	// the number of days to keep full backups instead.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=9999999
+	// +optional
+	Full *int32 `json:"full,omitempty"`
+
+	// Whether full is a number of backups or a number of days. Defaults to "count".
+	// +kubebuilder:validation:Enum={count,time}
+	// +optional
+	FullType string `json:"fullType,omitempty"`
+
+	// The number of differential backups to keep.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=9999999
+	// +optional
+	Diff *int32 `json:"diff,omitempty"`
+
+	// The number of backups of archiveType for which to keep WAL. Defaults
+	// to the number of full or differential backups being kept.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=9999999
+	// +optional
+	Archive *int32 `json:"archive,omitempty"`
+
+	// The type of backup counted by archive. Defaults to "full".
+	// +kubebuilder:validation:Enum={full,diff,incr}
+	// +optional
+	ArchiveType string `json:"archiveType,omitempty"`
+}
+
 // RepoHostStatus defines the status of a pgBackRest repository host
 type RepoHostStatus struct {
 	metav1.TypeMeta `json:",inline"`
@@ -380,6 +693,45 @@ type RepoS3 struct {
 	Region string `json:"region"`
 }
 
+// RepoSFTP represents a pgBackRest repository on an SFTP server.
+// More info: https://pgbackrest.org/configuration.html#section-repository/option-repo-sftp-host
+type RepoSFTP struct {
+
+	// The hostname or IP address of the SFTP server
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Host string `json:"host"`
+
+	// The port of the SFTP server. Defaults to 22.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+
+	// The user to log in as on the SFTP server
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	User string `json:"user"`
+
+	// The absolute path of the repository on the SFTP server. Defaults to
+	// "/pgbackrest/" followed by the name of the repository.
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// The SHA256 fingerprint of the host key of the SFTP server as printed by
+	// "ssh-keygen -l", for example "SHA256:uNiVztksCsDhcc0u9e8BujQXVUpKZIDTMczCvj3tD2s".
+	// pgBackRest refuses to connect to a server that presents a different key.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^SHA256:[A-Za-z0-9+/]{43}$`
+	HostKeyFingerprint string `json:"hostKeyFingerprint"`
+
+	// A Secret key that holds the private key of the user. The key cannot
+	// be protected by a passphrase.
+	// +kubebuilder:validation:Required
+	PrivateKey SecretKeyRef `json:"privateKey"`
+}
+
 // RepoStatus the status of a pgBackRest repository
 type RepoStatus struct {
 
@@ -408,9 +760,141 @@ type RepoStatus struct {
 	// commands accordingly.
 	// +optional
 	RepoOptionsHash string `json:"repoOptionsHash,omitempty"`
+
+	// The retention policy in effect for the repository, including defaults
+	// and any settings in "global". This is absent when backups never expire.
+	// +optional
+	Retention *PGBackRestRetention `json:"retention,omitempty"`
+
+	// Retention options for the repository in "global" that pgBackRest does
+	// not accept. They are not part of the retention policy above.
+	// +listType=atomic
+	// +optional
+	InvalidRetention []string `json:"invalidRetention,omitempty"`
+
+	// The most recent backups in the repository, oldest first, as reported
+	// by "pgbackrest info"
+	// +kubebuilder:validation:MaxItems=10
+	// +listType=atomic
+	// +optional
+	Backups []PGBackRestBackupStatus `json:"backups,omitempty"`
+
+	// The times to which the repository can restore
+	// +optional
+	RecoveryWindow *PGBackRestRecoveryWindow `json:"recoveryWindow,omitempty"`
+}
+
+// PGBackRestBackupStatus describes a backup in a pgBackRest repository.
+type PGBackRestBackupStatus struct {
+
+	// The pgBackRest label of the backup
+	// +kubebuilder:validation:Required
+	Label string `json:"label"`
+
+	// The pgBackRest backup type: full, diff, or incr
+	// +kubebuilder:validation:Required
+	Type string `json:"type"`
+
+	// When the backup started
+	// +kubebuilder:validation:Required
+	StartTime metav1.Time `json:"startTime"`
+
+	// When the backup finished
+	// +kubebuilder:validation:Required
+	StopTime metav1.Time `json:"stopTime"`
+
+	// The first WAL segment needed to make the backup consistent
+	// +optional
+	WALStart string `json:"walStart,omitempty"`
+
+	// The last WAL segment needed to make the backup consistent
+	// +optional
+	WALStop string `json:"walStop,omitempty"`
+
+	// The size of the PostgreSQL data directory, in bytes, when the backup was taken
+	// +optional
+	DatabaseSize int64 `json:"databaseSize,omitempty"`
+
+	// The size of the backup in the repository, in bytes, after compression
+	// +optional
+	RepoSize int64 `json:"repoSize,omitempty"`
+}
+
+// PGBackRestRecoveryWindow describes the times to which a pgBackRest repository can restore.
+type PGBackRestRecoveryWindow struct {
+
+	// The earliest time to which the repository can restore. This is when its
+	// oldest backup finished.
+	// +kubebuilder:validation:Required
+	Start metav1.Time `json:"start"`
+
+	// When the newest backup in the repository finished. The repository can
+	// restore to later times using WAL archived through walStop.
+	// +kubebuilder:validation:Required
+	End metav1.Time `json:"end"`
+
+	// The oldest WAL segment archived in the repository
+	// +optional
+	WALStart string `json:"walStart,omitempty"`
+
+	// The newest WAL segment archived in the repository
+	// +optional
+	WALStop string `json:"walStop,omitempty"`
+}
+
+// PGBackRestRecoveryTarget defines where pgBackRest stops recovery during a
+// restore. Without time, lsn, transactionID, or name, recovery continues to
+// the end of the WAL stream.
+// - https://pgbackrest.org/command.html#command-restore/category-command/option-type
+// - https://www.postgresql.org/docs/current/runtime-config-wal.html#RUNTIME-CONFIG-WAL-RECOVERY-TARGET
+// ---
+// +kubebuilder:validation:MinProperties=1
+// +kubebuilder:validation:XValidation:rule=`[has(self.time), has(self.lsn), has(self.transactionID), has(self.name)].filter(x, x).size() <= 1`,message="only one of time, lsn, transactionID, or name can be set"
+// +kubebuilder:validation:XValidation:rule=`!has(self.exclusive) || has(self.time) || has(self.lsn) || has(self.transactionID)`,message="exclusive requires time, lsn, or transactionID"
+// +structType=atomic
+type PGBackRestRecoveryTarget struct {
+
+	// Recover through this time, such as "2025-01-02T15:04:05Z".
+	// +optional
+	Time *metav1.Time `json:"time,omitempty"`
+
+	// Recover through this write-ahead log location, such as "0/3000060".
+	// ---
+	// +kubebuilder:validation:MaxLength=17
+	// +kubebuilder:validation:Pattern=`^[0-9A-Fa-f]{1,8}/[0-9A-Fa-f]{1,8}$`
+	// +optional
+	LSN string `json:"lsn,omitempty"`
+
+	// Recover through this transaction ID.
+	// ---
+	// +kubebuilder:validation:Minimum=3
+	// +optional
+	TransactionID *int64 `json:"transactionID,omitempty"`
+
+	// Recover to this restore point, created by pg_create_restore_point() or
+	// the "pgbackrest-restore-point" annotation of a PostgresCluster.
+	// ---
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_.:@-]+$`
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Recover along this timeline: "current", "latest", or a timeline ID.
+	// ---
+	// +kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:Pattern=`^(current|latest|[0-9]+|0x[0-9A-Fa-f]+)$`
+	// +optional
+	Timeline string `json:"timeline,omitempty"`
+
+	// Whether to stop just before the target rather than just after it.
+	// Defaults to false.
+	// +optional
+	Exclusive *bool `json:"exclusive,omitempty"`
 }
 
 // PGBackRestDataSource defines a pgBackRest configuration specifically for restoring from cloud-based data source
+// ---
+// +kubebuilder:validation:XValidation:rule=`!has(self.repo.encryption) || has(self.repo.encryption.passphrase)`,message="encryption requires a passphrase"
 type PGBackRestDataSource struct {
 	// Projected volumes containing custom pgBackRest configuration.  These files are mounted
 	// under "/etc/pgbackrest/conf.d" alongside any pgBackRest configuration generated by the
@@ -6,6 +6,7 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -169,6 +170,8 @@ type PostgresHBARuleSpec struct {
 // +kubebuilder:validation:MaxLength=63
 type PostgresIdentifier = string
 
+// ---
+// +kubebuilder:validation:XValidation:rule=`!has(self.rotation) || !has(self.secretKeyRef)`,message=`cannot rotate a password from secretKeyRef`
 type PostgresPasswordSpec struct {
 	// Type of password to generate. Defaults to ASCII. Valid options are ASCII
 	// and AlphaNumeric.
@@ -183,6 +186,25 @@ type PostgresPasswordSpec struct {
 	// +kubebuilder:validation:Enum={ASCII,AlphaNumeric}
 	// +required
 	Type string `json:"type"`
+
+	// A key in a Secret, in the same namespace as the cluster, that holds the
+	// password for this user. When this is set, the password is read from the
+	// Secret rather than generated, and changes to it are applied to PostgreSQL.
+	// This lets another system, such as External Secrets or Sealed Secrets,
+	// manage the password.
+	// ---
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+
+	// Replace the generated password periodically or when the user's Secret
+	// has a "postgres-operator.crunchydata.com/rotate-password" annotation
+	// with a new value. The new password belongs to one of two login roles
+	// that alternate: the user and another with "-alt" appended to its name.
+	// Sessions of the "-alt" role act as the user. The previous password
+	// remains valid until the grace period ends.
+	// ---
+	// +optional
+	Rotation *PostgresPasswordRotationSpec `json:"rotation,omitempty"`
 }
 
 // PostgresPasswordSpec types.
@@ -191,6 +213,68 @@ const (
 	PostgresPasswordTypeASCII        = "ASCII"
 )
 
+type PostgresPasswordRotationSpec struct {
+	// How often to generate a new password. When omitted, the password is
+	// replaced only on request.
+	// ---
+	// NOTE: This rejects fractional numbers: https://github.com/kubernetes/kube-openapi/issues/523
+	// +kubebuilder:validation:Pattern=`^(PT)?( *[0-9]+ *(?i:(h|hr|d|w|wk)|(hour|day|week)s?))+$`
+	//
+	// `controller-gen` needs to know "Type=string" to allow a "Pattern".
+	// +kubebuilder:validation:Type=string
+	//
+	// Set a max length to keep rule costs low.
+	// +kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:XValidation:rule=`duration("1h") <= self`,message="must be at least one hour"
+	//
+	// +optional
+	Interval *Duration `json:"interval,omitempty"`
+
+	// How long the previous password remains valid after a new one is
+	// generated. Another rotation cannot begin until this has passed.
+	// Defaults to one hour.
+	// ---
+	// NOTE: This rejects fractional numbers: https://github.com/kubernetes/kube-openapi/issues/523
+	// +kubebuilder:validation:Pattern=`^(PT)?( *[0-9]+ *(?i:(s|m|h|hr|d|w|wk)|(sec|min|hour|day|week)s?))+$`
+	//
+	// `controller-gen` needs to know "Type=string" to allow a "Pattern".
+	// +kubebuilder:validation:Type=string
+	//
+	// Set a max length to keep rule costs low.
+	// +kubebuilder:validation:MaxLength=20
+	//
+	// +optional
+	GracePeriod *Duration `json:"gracePeriod,omitempty"`
+}
+
+// PostgresUserStatus is the observed state of a PostgreSQL user whose
+// password rotates.
+type PostgresUserStatus struct {
+	// The name of the user in the PostgresCluster spec.
+	// +required
+	Name PostgresIdentifier `json:"name"`
+
+	// The PostgreSQL role whose password is in the user's Secret.
+	// +optional
+	Login string `json:"login,omitempty"`
+
+	// The PostgreSQL role whose previous password is valid until the grace
+	// period ends.
+	// +optional
+	PreviousLogin string `json:"previousLogin,omitempty"`
+
+	// The last time a new password was generated.
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+
+	// The value of the rotate-password annotation that was last acted upon.
+	// +optional
+	RotationTrigger string `json:"rotationTrigger,omitempty"`
+}
+
+// ---
+// The "-alt" role of a rotating password must fit in a PostgreSQL identifier.
+// +kubebuilder:validation:XValidation:rule=`!has(self.password) || !has(self.password.rotation) || size(self.name) <= 59`,message=`name must be at most 59 characters to rotate its password`
 type PostgresUserSpec struct {
 	// The name of this PostgreSQL user. The value may contain only lowercase
 	// letters, numbers, and hyphen so that it fits into Kubernetes metadata.
@@ -207,8 +291,8 @@ type PostgresUserSpec struct {
 	Name PostgresIdentifier `json:"name"`
 
 	// Databases to which this user can connect and create objects. Removing a
-	// database from this list does NOT revoke access. This field is ignored for
-	// the "postgres" user.
+	// database from this list does NOT revoke access unless "privileges" is
+	// set. This field is ignored for the "postgres" user.
 	// ---
 	// +listType=set
 	// +optional
@@ -225,8 +309,201 @@ type PostgresUserSpec struct {
 	// +optional
 	Options string `json:"options,omitempty"`
 
+	// Whether or not to issue a client certificate for this user from the
+	// cluster's certificate authority. The certificate, its private key, and
+	// the authority are stored in the user's Secret as "tls.crt", "tls.key",
+	// and "ca.crt". The common name of the certificate is the name of the user,
+	// and connections by this user over TLS must present it rather than
+	// a password. Since a password cannot be used, the Secret omits the "uri",
+	// "jdbc-uri", PgBouncer, and "pgpass" connection details, and the other
+	// formats have no password. The certificate is renewed before it expires.
+	// This cannot be enabled when the cluster uses cert-manager.
+	// More info: https://www.postgresql.org/docs/current/auth-cert.html
+	// ---
+	// +optional
+	ClientCertificate *bool `json:"clientCertificate,omitempty"`
+
+	// Additional formats of connection details to include in the user's
+	// Secret. DotNet adds an ADO.NET connection string in "dotnet".
+	// KeywordValue adds a libpq connection string in "dsn". PGPass adds a
+	// libpq password file in "pgpass". PGService adds a libpq connection
+	// service file in "pg_service.conf". ServiceBinding adds the "type",
+	// "provider", "username", and "database" keys of the Service Binding
+	// specification so the Secret can be projected into workloads.
+	// More info: https://servicebinding.io/spec/core/1.1.0/#well-known-secret-entries
+	// ---
+	// +kubebuilder:validation:MaxItems=5
+	// +kubebuilder:validation:items:Enum={DotNet,KeywordValue,PGPass,PGService,ServiceBinding}
+	// +listType=set
+	// +optional
+	ConnectionFormats []string `json:"connectionFormats,omitempty"`
+
 	// Properties of the password generated for this user.
 	// ---
 	// +optional
 	Password *PostgresPasswordSpec `json:"password,omitempty"`
+
+	// Privileges and role memberships of this user. When this is set, the
+	// user has exactly these privileges: any others it has on databases,
+	// schemas, tables, and sequences it does not own are revoked. When this is
+	// omitted, the user has all privileges on its databases and nothing is
+	// revoked. This field is ignored for the "postgres" user.
+	// More info: https://www.postgresql.org/docs/current/ddl-priv.html
+	// ---
+	// +optional
+	Privileges *PostgresUserPrivilegesSpec `json:"privileges,omitempty"`
+}
+
+// PostgresUserSpec connection formats.
+const (
+	PostgresConnectionFormatDotNet         = "DotNet"
+	PostgresConnectionFormatKeywordValue   = "KeywordValue"
+	PostgresConnectionFormatPGPass         = "PGPass"
+	PostgresConnectionFormatPGService      = "PGService"
+	PostgresConnectionFormatServiceBinding = "ServiceBinding"
+)
+
+type PostgresUserPrivilegesSpec struct {
+	// The privileges granted on each of the user's databases. The default,
+	// Connect, allows the user to connect but not to create schemas. All also
+	// allows creating schemas. Temporary tables are allowed by either, unless
+	// TEMPORARY is revoked from PUBLIC in the database.
+	// More info: https://www.postgresql.org/docs/current/sql-grant.html
+	// ---
+	// Kubernetes assumes the evaluation cost of an enum value is very large.
+	// TODO(k8s-1.29): Drop MaxLength after Kubernetes 1.29; https://issue.k8s.io/119511
+	// +kubebuilder:validation:MaxLength=15
+	//
+	// +kubebuilder:default=Connect
+	// +kubebuilder:validation:Enum={All,Connect}
+	// +optional
+	Database string `json:"database,omitempty"`
+
+	// Roles in which this user is a member. The roles must already exist, for
+	// example, as another user with the NOLOGIN option. Memberships that are
+	// not in this list are revoked.
+	// More info: https://www.postgresql.org/docs/current/role-membership.html
+	// ---
+	// +kubebuilder:validation:MaxItems=64
+	// +listType=set
+	// +optional
+	Roles []PostgresIdentifier `json:"roles,omitempty"`
+
+	// Access to schemas and the tables and sequences in them.
+	// ---
+	// +kubebuilder:validation:MaxItems=64
+	// +listType=map
+	// +listMapKey=database
+	// +listMapKey=schema
+	// +optional
+	Schemas []PostgresSchemaPrivilegesSpec `json:"schemas,omitempty"`
+}
+
+// PostgresUserPrivilegesSpec database privileges.
+const (
+	PostgresDatabasePrivilegesAll     = "All"
+	PostgresDatabasePrivilegesConnect = "Connect"
+)
+
+type PostgresSchemaPrivilegesSpec struct {
+	// The database that contains the schema.
+	// ---
+	// +required
+	Database PostgresIdentifier `json:"database"`
+
+	// The name of the schema. The schema must already exist, for example, in
+	// the "schemas" of a PostgresDatabase. Privileges on a schema that does
+	// not exist are granted after it is created.
+	// ---
+	// +required
+	Schema PostgresIdentifier `json:"schema"`
+
+	// The access granted to the schema and all tables and sequences in it.
+	// ReadOnly allows reading tables and sequences. ReadWrite also allows
+	// inserting, updating, and deleting rows and advancing sequences.
+	// ---
+	// Kubernetes assumes the evaluation cost of an enum value is very large.
+	// TODO(k8s-1.29): Drop MaxLength after Kubernetes 1.29; https://issue.k8s.io/119511
+	// +kubebuilder:validation:MaxLength=15
+	//
+	// +kubebuilder:validation:Enum={ReadOnly,ReadWrite}
+	// +required
+	Access string `json:"access"`
+
+	// Roles that create tables and sequences in the schema. The same access
+	// is granted on objects these roles create in the future.
+	// More info: https://www.postgresql.org/docs/current/sql-alterdefaultprivileges.html
+	// ---
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=set
+	// +optional
+	DefaultPrivilegesFor []PostgresIdentifier `json:"defaultPrivilegesFor,omitempty"`
+}
+
+// PostgresSchemaPrivilegesSpec access levels.
+const (
+	PostgresSchemaAccessReadOnly  = "ReadOnly"
+	PostgresSchemaAccessReadWrite = "ReadWrite"
+)
+
+type PostgresExtensionSpec struct {
+	// The name of the extension. The extension must be available in the
+	// PostgreSQL image.
+	// More info: https://www.postgresql.org/docs/current/sql-createextension.html
+	// ---
+	// +required
+	Name PostgresIdentifier `json:"name"`
+
+	// The version of the extension to install. When empty, the default version
+	// of the extension in the PostgreSQL image is installed and kept current.
+	// Changing this value updates the extension.
+	// More info: https://www.postgresql.org/docs/current/sql-alterextension.html
+	// ---
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Databases in which to install the extension. When empty, the extension
+	// is installed in every database that allows connections, including
+	// "template1". Removing a database from this list does NOT drop the
+	// extension from it.
+	// ---
+	// +kubebuilder:validation:MaxItems=64
+	// +listType=set
+	// +optional
+	Databases []PostgresIdentifier `json:"databases,omitempty"`
+
+	// The schema in which to install the extension. When empty, PostgreSQL
+	// chooses the schema. This is used only when the extension is installed.
+	// ---
+	// +optional
+	Schema PostgresIdentifier `json:"schema,omitempty"`
+
+	// Shared libraries to load when PostgreSQL starts, in addition to any
+	// that the operator knows this extension requires. PostgreSQL restarts
+	// when this changes.
+	// More info: https://www.postgresql.org/docs/current/runtime-config-client.html#GUC-SHARED-PRELOAD-LIBRARIES
+	// ---
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=63
+	// +kubebuilder:validation:items:Pattern=`^[^,'"\s]+$`
+	// +listType=set
+	// +optional
+	SharedPreloadLibraries []string `json:"sharedPreloadLibraries,omitempty"`
+}
+
+type PostgresExtensionStatus struct {
+	// The name of the extension.
+	Name string `json:"name"`
+
+	// The default version of the extension in the PostgreSQL image. This is
+	// empty when the extension is not available.
+	// +optional
+	AvailableVersion string `json:"availableVersion,omitempty"`
+
+	// The version of the extension installed in each database, by database name.
+	// +optional
+	InstalledVersions map[string]string `json:"installedVersions,omitempty"`
 }
@@ -0,0 +1,13 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import "sigs.k8s.io/controller-runtime/pkg/conversion"
+
+var _ conversion.Hub = (*PostgresCluster)(nil)
+
+// Hub implements [conversion.Hub]. PostgresCluster is stored as v1beta1, so
+// every other version converts to and from this one.
+func (*PostgresCluster) Hub() {}
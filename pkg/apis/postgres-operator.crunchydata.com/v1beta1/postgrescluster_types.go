@@ -54,6 +54,18 @@ type PostgresClusterSpec struct {
 	// +optional
 	CustomReplicationClientTLSSecret *corev1.SecretProjection `json:"customReplicationTLSSecret,omitempty"`
 
+	// Use cert-manager to issue the PostgreSQL server certificate, the
+	// replication client certificate, and the PgBouncer frontend certificate
+	// rather than the operator's certificate authority. Custom TLS secrets
+	// take precedence. The operator's certificates are used until cert-manager
+	// has issued all of them. When trust-manager is installed, the authority of
+	// the server certificate is distributed to the namespace through a Bundle.
+	// Removing this deletes the Certificates, their Secrets, and the Bundle.
+	// More info: https://cert-manager.io/docs/usage/certificate
+	// ---
+	// +optional
+	CertManager *CertManagerSpec `json:"certManager,omitempty"`
+
 	// DatabaseInitSQL defines a ConfigMap containing custom SQL that will
 	// be run after the cluster is initialized. This ConfigMap must be in the same
 	// namespace as the cluster.
@@ -66,6 +78,16 @@ type PostgresClusterSpec struct {
 	// +optional
 	DisableDefaultPodScheduling *bool `json:"disableDefaultPodScheduling,omitempty"`
 
+	// Extensions to install and keep updated in PostgreSQL. Removing an
+	// extension from this list does NOT drop it.
+	// More info: https://www.postgresql.org/docs/current/extend-extensions.html
+	// ---
+	// +kubebuilder:validation:MaxItems=64
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Extensions []PostgresExtensionSpec `json:"extensions,omitempty"`
+
 	// The image name to use for PostgreSQL containers. When omitted, the value
 	// comes from an operator environment variable. For standard PostgreSQL images,
 	// the format is RELATED_IMAGE_POSTGRES_{postgresVersion},
@@ -191,6 +213,10 @@ type PostgresClusterSpec struct {
 	// The default creates one user that can access one database matching the
 	// PostgresCluster name. An empty list creates no users. Removing a user
 	// from this list does NOT drop the user nor revoke their access.
+	// ---
+	// Users with rotating passwords also have a login role named "<name>-alt".
+	// +kubebuilder:validation:XValidation:rule=`self.all(u, !has(u.password) || !has(u.password.rotation) || !self.exists(v, v.name == u.name + '-alt'))`,message="the name of a user cannot be the name of another user with \"-alt\" appended when that user has rotating passwords"
+	//
 	// +listType=map
 	// +listMapKey=name
 	// +kubebuilder:validation:MaxItems=64
@@ -289,6 +315,11 @@ type PostgresClusterDataSource struct {
 	// +optional
 	Options []string `json:"options,omitempty"`
 
+	// The point in the WAL stream at which to stop recovery. When set, the
+	// options cannot include "--type" nor "--target".
+	// +optional
+	Target *PGBackRestRecoveryTarget `json:"target,omitempty"`
+
 	// Resource requirements for the pgBackRest restore Job.
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resources,omitzero"`
@@ -350,9 +381,30 @@ type Backups struct {
 // PostgresClusterStatus defines the observed state of PostgresCluster
 type PostgresClusterStatus struct {
 
+	// The Secret of the first user with the ServiceBinding connection format.
+	// This makes the PostgresCluster a Provisioned Service.
+	// More info: https://servicebinding.io/spec/core/1.1.0/#provisioned-service
+	// +optional
+	Binding *corev1.LocalObjectReference `json:"binding,omitempty"`
+
+	// Certificates that the cluster presents or trusts, and when they expire.
+	// +listType=atomic
+	// +optional
+	Certificates []CertificateStatus `json:"certificates,omitempty"`
+
 	// Identifies the databases that have been installed into PostgreSQL.
 	DatabaseRevision string `json:"databaseRevision,omitempty"`
 
+	// Versions of the extensions in "spec.extensions" that are available in
+	// and installed into PostgreSQL.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Extensions []PostgresExtensionStatus `json:"extensions,omitempty"`
+
+	// Identifies the extensions that have been installed into PostgreSQL.
+	ExtensionsRevision string `json:"extensionsRevision,omitempty"`
+
 	// Current state of PostgreSQL instances.
 	// +listType=map
 	// +listMapKey=name
@@ -381,6 +433,10 @@ type PostgresClusterStatus struct {
 	// +optional
 	Proxy PostgresProxyStatus `json:"proxy,omitzero"`
 
+	// Progress of the most recent rotation of the root certificate authority.
+	// +optional
+	RootCertificateRotation *RootCertificateRotationStatus `json:"rootCertificateRotation,omitempty"`
+
 	// The instance that should be started first when bootstrapping and/or starting a
 	// PostgresCluster.
 	// +optional
@@ -397,6 +453,14 @@ type PostgresClusterStatus struct {
 	// Identifies the users that have been installed into PostgreSQL.
 	UsersRevision string `json:"usersRevision,omitempty"`
 
+	// Current state of PostgreSQL users whose passwords rotate.
+	// ---
+	// +kubebuilder:validation:MaxItems=64
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Users []PostgresUserStatus `json:"users,omitempty"`
+
 	// Current state of PostgreSQL cluster monitoring tool configuration
 	// +optional
 	Monitoring MonitoringStatus `json:"monitoring,omitzero"`
@@ -626,6 +690,66 @@ type PostgresProxyStatus struct {
 	PGBouncer PGBouncerPodStatus `json:"pgBouncer,omitzero"`
 }
 
+// CertificateStatus describes a certificate that the cluster presents or trusts.
+type CertificateStatus struct {
+	// What the certificate is used for: "root", "postgres-server",
+	// "replication-client", "pgbouncer-frontend", "pgbouncer-backend",
+	// "pgbackrest-client", or "pgbackrest-server".
+	// +required
+	Name string `json:"name"`
+
+	// The name of the Secret that contains the certificate.
+	// +required
+	Secret string `json:"secret"`
+
+	// The distinguished name of the authority that issued the certificate.
+	// +optional
+	Issuer string `json:"issuer,omitempty"`
+
+	// When the certificate expires.
+	// +required
+	NotAfter metav1.Time `json:"notAfter"`
+
+	// Whether the certificate expires within 30 days.
+	// +optional
+	Expiring bool `json:"expiring,omitempty"`
+}
+
+// RootCertificateRotationStatus describes a rotation of the root certificate
+// authority. The root is shared by every PostgresCluster in a namespace.
+type RootCertificateRotationStatus struct {
+	// The phase of the rotation. During "Trusting", both the current and the
+	// new authority are trusted. During "Reissuing", certificates are issued by
+	// the new authority while both are trusted. When "Complete", only the new
+	// authority is trusted. Each phase begins after every PostgresCluster in
+	// the namespace has loaded the certificates of the one before it.
+	// +kubebuilder:validation:Enum={Trusting,Reissuing,Complete}
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// A hash of the authorities trusted in the current phase, recorded after
+	// every certificate of this cluster has been issued and every running
+	// instance has loaded them.
+	// +optional
+	Loaded string `json:"loaded,omitempty"`
+
+	// When the current phase began.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// The value of the rotate-root-certificate annotation that started the
+	// most recent rotation, if any.
+	// +optional
+	Trigger string `json:"trigger,omitempty"`
+}
+
+// RootCertificateRotationStatus phases.
+const (
+	RootCertificateRotationTrusting  = "Trusting"
+	RootCertificateRotationReissuing = "Reissuing"
+	RootCertificateRotationComplete  = "Complete"
+)
+
 // PostgresStandbySpec defines if/how the cluster should be a hot standby.
 type PostgresStandbySpec struct {
 	// Whether or not the PostgreSQL cluster should be read-only. When this is
@@ -0,0 +1,281 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PostgresDatabaseSpec defines the desired state of a database in PostgreSQL.
+// ---
+// The ICU locale applies only when the locale provider is ICU.
+// +kubebuilder:validation:XValidation:rule=`!has(self.icuLocale) || (has(self.localeProvider) && self.localeProvider == "icu")`,message=`icuLocale requires the "icu" localeProvider`
+//
+// The databases that PostgreSQL needs cannot be dropped.
+// +kubebuilder:validation:XValidation:rule=`!has(self.name) || !has(self.deletionPolicy) || self.deletionPolicy != "Delete" || !(self.name in ["postgres", "template0", "template1"])`,message=`cannot drop the "postgres", "template0", or "template1" database`
+type PostgresDatabaseSpec struct {
+
+	// The name of the PostgresCluster, in the same namespace, that hosts this
+	// database. This cannot be changed.
+	// ---
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule=`self == oldSelf`,message="immutable"
+	// +required
+	ClusterName string `json:"clusterName"`
+
+	// The name of the database in PostgreSQL. Defaults to the name of this
+	// PostgresDatabase. This cannot be changed.
+	// ---
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:XValidation:rule=`self == oldSelf`,message="immutable"
+	// +optional
+	Name PostgresIdentifier `json:"name,omitempty"`
+
+	// The role that owns the database. The role must already exist, for
+	// example, in the "spec.users" of the PostgresCluster. When empty, the
+	// owner is not changed and new databases are owned by the superuser.
+	// More info: https://www.postgresql.org/docs/current/sql-alterdatabase.html
+	// ---
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	Owner PostgresIdentifier `json:"owner,omitempty"`
+
+	// The number of concurrent connections allowed to this database. The
+	// default, -1, means no limit.
+	// More info: https://www.postgresql.org/docs/current/sql-createdatabase.html
+	// ---
+	// +kubebuilder:validation:Minimum=-1
+	// +optional
+	ConnectionLimit *int32 `json:"connectionLimit,omitempty"`
+
+	// The character set encoding of the database. This is used only when the
+	// database is created and cannot be changed.
+	// More info: https://www.postgresql.org/docs/current/multibyte.html
+	// ---
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:XValidation:rule=`self == oldSelf`,message="immutable"
+	// +optional
+	Encoding string `json:"encoding,omitempty"`
+
+	// The locale of the database, such as "en_US.UTF-8". This is used only
+	// when the database is created and cannot be changed. Requires PostgreSQL 13
+	// or later.
+	// More info: https://www.postgresql.org/docs/current/locale.html
+	// ---
+	// +kubebuilder:validation:MaxLength=127
+	// +kubebuilder:validation:XValidation:rule=`self == oldSelf`,message="immutable"
+	// +optional
+	Locale string `json:"locale,omitempty"`
+
+	// The provider of collations in the database. This is used only when the
+	// database is created and cannot be changed. Requires PostgreSQL 15 or later.
+	// More info: https://www.postgresql.org/docs/current/locale.html#LOCALE-PROVIDERS
+	// ---
+	// Kubernetes assumes the evaluation cost of an enum value is very large.
+	// TODO(k8s-1.29): Drop MaxLength after Kubernetes 1.29; https://issue.k8s.io/119511
+	// +kubebuilder:validation:MaxLength=15
+	//
+	// +kubebuilder:validation:Enum={icu,libc}
+	// +kubebuilder:validation:XValidation:rule=`self == oldSelf`,message="immutable"
+	// +optional
+	LocaleProvider string `json:"localeProvider,omitempty"`
+
+	// The ICU locale of the database, such as "und-u-ks-level2". This is used
+	// only when the database is created and cannot be changed.
+	// More info: https://www.postgresql.org/docs/current/collation.html#COLLATION-MANAGING-CREATE-ICU
+	// ---
+	// +kubebuilder:validation:MaxLength=127
+	// +kubebuilder:validation:XValidation:rule=`self == oldSelf`,message="immutable"
+	// +optional
+	ICULocale string `json:"icuLocale,omitempty"`
+
+	// The name of the database to copy when this database is created. This is
+	// used only when the database is created and cannot be changed. Defaults
+	// to "template1".
+	// More info: https://www.postgresql.org/docs/current/manage-ag-templatedbs.html
+	// ---
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:XValidation:rule=`self == oldSelf`,message="immutable"
+	// +optional
+	Template PostgresIdentifier `json:"template,omitempty"`
+
+	// Schemas to create in the database. Removing a schema from this list
+	// does NOT drop it.
+	// More info: https://www.postgresql.org/docs/current/ddl-schemas.html
+	// ---
+	// +kubebuilder:validation:MaxItems=64
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Schemas []PostgresDatabaseSchema `json:"schemas,omitempty"`
+
+	// Extensions to create in the database. The extension must be available in
+	// the PostgreSQL image. Removing an extension from this list does NOT drop it.
+	// More info: https://www.postgresql.org/docs/current/sql-createextension.html
+	// ---
+	// +kubebuilder:validation:MaxItems=64
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Extensions []PostgresDatabaseExtension `json:"extensions,omitempty"`
+
+	// What happens to the database in PostgreSQL when this PostgresDatabase
+	// is deleted. The default, Retain, leaves the database and its data in
+	// place. Delete drops the database, and all its data, from PostgreSQL
+	// when this PostgresDatabase created it. A database that already existed
+	// is retained.
+	// ---
+	// Kubernetes assumes the evaluation cost of an enum value is very large.
+	// TODO(k8s-1.29): Drop MaxLength after Kubernetes 1.29; https://issue.k8s.io/119511
+	// +kubebuilder:validation:MaxLength=15
+	//
+	// +kubebuilder:default=Retain
+	// +kubebuilder:validation:Enum={Delete,Retain}
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+}
+
+// PostgresDatabaseSpec deletion policies.
+const (
+	PostgresDatabaseDeletionPolicyDelete = "Delete"
+	PostgresDatabaseDeletionPolicyRetain = "Retain"
+)
+
+type PostgresDatabaseSchema struct {
+	// The name of the schema.
+	// ---
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:XValidation:rule=`!self.startsWith("pg_")`,message=`cannot start with "pg_"`
+	// +required
+	Name PostgresIdentifier `json:"name"`
+
+	// The role that owns the schema. When empty, the owner is not changed and
+	// new schemas are owned by the superuser.
+	// ---
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	Owner PostgresIdentifier `json:"owner,omitempty"`
+}
+
+type PostgresDatabaseExtension struct {
+	// The name of the extension.
+	// ---
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +required
+	Name PostgresIdentifier `json:"name"`
+
+	// The schema in which to create the extension. When empty, PostgreSQL
+	// chooses the schema. This is used only when the extension is created.
+	// ---
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	Schema PostgresIdentifier `json:"schema,omitempty"`
+}
+
+// PostgresDatabaseStatus defines the observed state of a database in PostgreSQL.
+type PostgresDatabaseStatus struct {
+	// conditions represent the observations of PostgresDatabase's current state.
+	// Known .status.conditions.type is: "Ready"
+	// ---
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:io.kubernetes.conditions"}
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Whether or not the database exists in PostgreSQL.
+	// +optional
+	Exists *bool `json:"exists,omitempty"`
+
+	// Whether or not this PostgresDatabase created the database in PostgreSQL.
+	// Only a database it created is dropped by the Delete deletion policy.
+	// +optional
+	Created bool `json:"created,omitempty"`
+
+	// The disk space used by the database, as reported by PostgreSQL.
+	// More info: https://www.postgresql.org/docs/current/functions-admin.html#FUNCTIONS-ADMIN-DBSIZE
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// observedGeneration represents the .metadata.generation on which the status was based.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// PostgresDatabaseStatus condition types.
+const (
+	PostgresDatabaseReady = "Ready"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+versionName=v1beta1
+//+kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.spec.clusterName`
+//+kubebuilder:printcolumn:name="Database",type=string,JSONPath=`.spec.name`
+//+kubebuilder:printcolumn:name="Exists",type=boolean,JSONPath=`.status.exists`
+//+kubebuilder:printcolumn:name="Size",type=string,JSONPath=`.status.size`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// PostgresDatabase is the Schema for the postgresdatabases API
+// ---
+// The name of the database defaults to the name of this object.
+// +kubebuilder:validation:XValidation:rule=`!has(self.spec) || has(self.spec.name) || !has(self.spec.deletionPolicy) || self.spec.deletionPolicy != "Delete" || !(self.metadata.name in ["postgres", "template0", "template1"])`,message=`cannot drop the "postgres", "template0", or "template1" database`
+type PostgresDatabase struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// +optional
+	Spec PostgresDatabaseSpec `json:"spec,omitzero"`
+	// +optional
+	Status PostgresDatabaseStatus `json:"status,omitzero"`
+}
+
+// Default implements "sigs.k8s.io/controller-runtime/pkg/webhook.Defaulter" so
+// a webhook can be registered for the type.
+// - https://book.kubebuilder.io/reference/webhook-overview.html
+func (d *PostgresDatabase) Default() {
+	if len(d.APIVersion) == 0 {
+		d.APIVersion = GroupVersion.String()
+	}
+	if len(d.Kind) == 0 {
+		d.Kind = "PostgresDatabase"
+	}
+	if len(d.Spec.Name) == 0 {
+		d.Spec.Name = d.Name
+	}
+	if len(d.Spec.DeletionPolicy) == 0 {
+		d.Spec.DeletionPolicy = PostgresDatabaseDeletionPolicyRetain
+	}
+}
+
+func NewPostgresDatabase() *PostgresDatabase {
+	d := &PostgresDatabase{}
+	d.SetGroupVersionKind(GroupVersion.WithKind("PostgresDatabase"))
+	return d
+}
+
+//+kubebuilder:object:root=true
+
+// PostgresDatabaseList contains a list of PostgresDatabase
+type PostgresDatabaseList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []PostgresDatabase `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PostgresDatabase{}, &PostgresDatabaseList{})
+}
@@ -0,0 +1,198 @@
+// Copyright 2021 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PostgresRestoreTestSpec defines how to periodically prove that the backups
+// of a PostgresCluster can be restored.
+type PostgresRestoreTestSpec struct {
+
+	// Metadata added to the PostgresCluster created for each test.
+	// +optional
+	Metadata *Metadata `json:"metadata,omitempty"`
+
+	// The name of the PostgresCluster, in the same namespace, whose backups
+	// are restored.
+	// ---
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	ClusterName string `json:"clusterName"`
+
+	// The name of the pgBackRest repository from which to restore.
+	// ---
+	// +kubebuilder:validation:Pattern=^repo[1-4]
+	// +required
+	RepoName string `json:"repoName"`
+
+	// Command line options to include when running the pgBackRest restore
+	// command, such as "--type=time" and "--target=...". The restore recovers
+	// to the end of the WAL stream when there are none.
+	// https://pgbackrest.org/command.html#command-restore
+	// +optional
+	Options []string `json:"options,omitempty"`
+
+	// Defines the Cron schedule for restore tests.
+	// Follows the standard Cron schedule syntax:
+	// https://k8s.io/docs/concepts/workloads/controllers/cron-jobs/#cron-schedule-syntax
+	// ---
+	// +kubebuilder:validation:MinLength=6
+	// +required
+	Schedule string `json:"schedule"`
+
+	// SQL that must run without error in the "postgres" database of the
+	// restored cluster for the test to succeed. The SQL can "\connect" to
+	// other databases. Statements are canceled when the test times out.
+	// +optional
+	Validation *corev1.ConfigMapKeySelector `json:"validation,omitempty"`
+
+	// How long a test can take before it fails. Defaults to two hours.
+	// ---
+	// NOTE: This rejects fractional numbers: https://github.com/kubernetes/kube-openapi/issues/523
+	// +kubebuilder:validation:Pattern=`^(PT)?( *[0-9]+ *(?i:(s|m|h|hr|d|w|wk)|(sec|min|hour|day|week)s?))+$`
+	//
+	// `controller-gen` needs to know "Type=string" to allow a "Pattern".
+	// +kubebuilder:validation:Type=string
+	//
+	// Set a max length to keep rule costs low.
+	// +kubebuilder:validation:MaxLength=20
+	//
+	// +optional
+	Timeout *Duration `json:"timeout,omitempty"`
+
+	// Whether or not to stop starting new tests. A test that has started
+	// continues until it finishes.
+	// +optional
+	Suspend *bool `json:"suspend,omitempty"`
+}
+
+// PostgresRestoreTestRun describes one restore test.
+type PostgresRestoreTestRun struct {
+
+	// The name of the PostgresCluster created for the test.
+	// +required
+	ClusterName string `json:"clusterName"`
+
+	// When the test started.
+	// +required
+	StartTime metav1.Time `json:"startTime"`
+
+	// When the test finished.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// How long the test took, from the start of the restore until validation finished.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// The point to which the backups were restored, from the restore options.
+	// +optional
+	RecoveryTarget string `json:"recoveryTarget,omitempty"`
+
+	// Whether or not the cluster was restored and validated.
+	// +optional
+	Succeeded bool `json:"succeeded"`
+
+	// Why the test failed, when it did.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// PostgresRestoreTestStatus defines the observed state of PostgresRestoreTest
+type PostgresRestoreTestStatus struct {
+	// conditions represent the observations of PostgresRestoreTest's current state.
+	// Known .status.conditions.type is: "Succeeded"
+	// ---
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:io.kubernetes.conditions"}
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// The test that is running, if any.
+	// +optional
+	Active *PostgresRestoreTestRun `json:"active,omitempty"`
+
+	// The most recent test that finished.
+	// +optional
+	LastRun *PostgresRestoreTestRun `json:"lastRun,omitempty"`
+
+	// When the most recent successful test finished.
+	// +optional
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+
+	// When the next test is scheduled to start.
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+
+	// observedGeneration represents the .metadata.generation on which the status was based.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// PostgresRestoreTestStatus condition types.
+const (
+	PostgresRestoreTestSucceeded = "Succeeded"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+versionName=v1beta1
+//+kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.spec.clusterName`
+//+kubebuilder:printcolumn:name="Schedule",type=string,JSONPath=`.spec.schedule`
+//+kubebuilder:printcolumn:name="Succeeded",type=boolean,JSONPath=`.status.lastRun.succeeded`
+//+kubebuilder:printcolumn:name="Last Success",type=date,JSONPath=`.status.lastSuccessfulTime`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// PostgresRestoreTest is the Schema for the postgresrestoretests API
+type PostgresRestoreTest struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// +optional
+	Spec PostgresRestoreTestSpec `json:"spec,omitzero"`
+	// +optional
+	Status PostgresRestoreTestStatus `json:"status,omitzero"`
+}
+
+// Default implements "sigs.k8s.io/controller-runtime/pkg/webhook.Defaulter" so
+// a webhook can be registered for the type.
+// - https://book.kubebuilder.io/reference/webhook-overview.html
+func (t *PostgresRestoreTest) Default() {
+	if len(t.APIVersion) == 0 {
+		t.APIVersion = GroupVersion.String()
+	}
+	if len(t.Kind) == 0 {
+		t.Kind = "PostgresRestoreTest"
+	}
+	if t.Spec.Timeout == nil {
+		t.Spec.Timeout, _ = NewDuration("2h")
+	}
+}
+
+func NewPostgresRestoreTest() *PostgresRestoreTest {
+	t := &PostgresRestoreTest{}
+	t.SetGroupVersionKind(GroupVersion.WithKind("PostgresRestoreTest"))
+	return t
+}
+
+//+kubebuilder:object:root=true
+
+// PostgresRestoreTestList contains a list of PostgresRestoreTest
+type PostgresRestoreTestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []PostgresRestoreTest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PostgresRestoreTest{}, &PostgresRestoreTestList{})
+}
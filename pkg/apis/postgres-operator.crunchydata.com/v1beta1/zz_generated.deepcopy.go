@@ -112,6 +112,53 @@ func (in *Backups) DeepCopy() *Backups {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerIssuerReference) DeepCopyInto(out *CertManagerIssuerReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerIssuerReference.
+func (in *CertManagerIssuerReference) DeepCopy() *CertManagerIssuerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerIssuerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerSpec) DeepCopyInto(out *CertManagerSpec) {
+	*out = *in
+	out.IssuerRef = in.IssuerRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerSpec.
+func (in *CertManagerSpec) DeepCopy() *CertManagerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateStatus) DeepCopyInto(out *CertificateStatus) {
+	*out = *in
+	in.NotAfter.DeepCopyInto(&out.NotAfter)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateStatus.
+func (in *CertificateStatus) DeepCopy() *CertificateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterUpgrade) DeepCopyInto(out *ClusterUpgrade) {
 	*out = *in
@@ -1109,11 +1156,21 @@ func (in *PGBackRestArchive) DeepCopyInto(out *PGBackRestArchive) {
 			(*out)[key] = val
 		}
 	}
+	if in.ArchivePush != nil {
+		in, out := &in.ArchivePush, &out.ArchivePush
+		*out = new(PGBackRestArchivePush)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Jobs != nil {
 		in, out := &in.Jobs, &out.Jobs
 		*out = new(BackupJobs)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Scheduling != nil {
+		in, out := &in.Scheduling, &out.Scheduling
+		*out = new(PGBackRestBackupScheduling)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Repos != nil {
 		in, out := &in.Repos, &out.Repos
 		*out = make([]PGBackRestRepo, len(*in))
@@ -1131,6 +1188,11 @@ func (in *PGBackRestArchive) DeepCopyInto(out *PGBackRestArchive) {
 		*out = new(PGBackRestManualBackup)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Expire != nil {
+		in, out := &in.Expire, &out.Expire
+		*out = new(PGBackRestManualExpire)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Restore != nil {
 		in, out := &in.Restore, &out.Restore
 		*out = new(PGBackRestRestore)
@@ -1153,6 +1215,67 @@ func (in *PGBackRestArchive) DeepCopy() *PGBackRestArchive {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBackRestArchivePush) DeepCopyInto(out *PGBackRestArchivePush) {
+	*out = *in
+	if in.ProcessMax != nil {
+		in, out := &in.ProcessMax, &out.ProcessMax
+		*out = new(int32)
+		**out = **in
+	}
+	if in.QueueMax != nil {
+		in, out := &in.QueueMax, &out.QueueMax
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Spool != nil {
+		in, out := &in.Spool, &out.Spool
+		*out = new(PGBackRestSpoolVolume)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestArchivePush.
+func (in *PGBackRestArchivePush) DeepCopy() *PGBackRestArchivePush {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBackRestArchivePush)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBackRestArchiveStatus) DeepCopyInto(out *PGBackRestArchiveStatus) {
+	*out = *in
+	if in.LastArchivedTime != nil {
+		in, out := &in.LastArchivedTime, &out.LastArchivedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFailedTime != nil {
+		in, out := &in.LastFailedTime, &out.LastFailedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastDroppedTime != nil {
+		in, out := &in.LastDroppedTime, &out.LastDroppedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ObservedTime != nil {
+		in, out := &in.ObservedTime, &out.ObservedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestArchiveStatus.
+func (in *PGBackRestArchiveStatus) DeepCopy() *PGBackRestArchiveStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBackRestArchiveStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PGBackRestBackupSchedules) DeepCopyInto(out *PGBackRestBackupSchedules) {
 	*out = *in
@@ -1171,6 +1294,11 @@ func (in *PGBackRestBackupSchedules) DeepCopyInto(out *PGBackRestBackupSchedules
 		*out = new(string)
 		**out = **in
 	}
+	if in.Verify != nil {
+		in, out := &in.Verify, &out.Verify
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestBackupSchedules.
@@ -1183,6 +1311,48 @@ func (in *PGBackRestBackupSchedules) DeepCopy() *PGBackRestBackupSchedules {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBackRestBackupScheduling) DeepCopyInto(out *PGBackRestBackupScheduling) {
+	*out = *in
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
+	}
+	if in.JitterMinutes != nil {
+		in, out := &in.JitterMinutes, &out.JitterMinutes
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestBackupScheduling.
+func (in *PGBackRestBackupScheduling) DeepCopy() *PGBackRestBackupScheduling {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBackRestBackupScheduling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBackRestBackupStatus) DeepCopyInto(out *PGBackRestBackupStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.StopTime.DeepCopyInto(&out.StopTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestBackupStatus.
+func (in *PGBackRestBackupStatus) DeepCopy() *PGBackRestBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBackRestBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PGBackRestDataSource) DeepCopyInto(out *PGBackRestDataSource) {
 	*out = *in
@@ -1279,6 +1449,72 @@ func (in *PGBackRestManualBackup) DeepCopy() *PGBackRestManualBackup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBackRestManualExpire) DeepCopyInto(out *PGBackRestManualExpire) {
+	*out = *in
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(PGBackRestRetention)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestManualExpire.
+func (in *PGBackRestManualExpire) DeepCopy() *PGBackRestManualExpire {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBackRestManualExpire)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBackRestRecoveryTarget) DeepCopyInto(out *PGBackRestRecoveryTarget) {
+	*out = *in
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
+	}
+	if in.TransactionID != nil {
+		in, out := &in.TransactionID, &out.TransactionID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Exclusive != nil {
+		in, out := &in.Exclusive, &out.Exclusive
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestRecoveryTarget.
+func (in *PGBackRestRecoveryTarget) DeepCopy() *PGBackRestRecoveryTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBackRestRecoveryTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBackRestRecoveryWindow) DeepCopyInto(out *PGBackRestRecoveryWindow) {
+	*out = *in
+	in.Start.DeepCopyInto(&out.Start)
+	in.End.DeepCopyInto(&out.End)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestRecoveryWindow.
+func (in *PGBackRestRecoveryWindow) DeepCopy() *PGBackRestRecoveryWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBackRestRecoveryWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PGBackRestRepo) DeepCopyInto(out *PGBackRestRepo) {
 	*out = *in
@@ -1287,6 +1523,16 @@ func (in *PGBackRestRepo) DeepCopyInto(out *PGBackRestRepo) {
 		*out = new(PGBackRestBackupSchedules)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(PGBackRestRetention)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(PGBackRestRepoEncryption)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Azure != nil {
 		in, out := &in.Azure, &out.Azure
 		*out = new(RepoAzure)
@@ -1302,6 +1548,11 @@ func (in *PGBackRestRepo) DeepCopyInto(out *PGBackRestRepo) {
 		*out = new(RepoS3)
 		**out = **in
 	}
+	if in.SFTP != nil {
+		in, out := &in.SFTP, &out.SFTP
+		*out = new(RepoSFTP)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Volume != nil {
 		in, out := &in.Volume, &out.Volume
 		*out = new(RepoPVC)
@@ -1319,6 +1570,26 @@ func (in *PGBackRestRepo) DeepCopy() *PGBackRestRepo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBackRestRepoEncryption) DeepCopyInto(out *PGBackRestRepoEncryption) {
+	*out = *in
+	if in.Passphrase != nil {
+		in, out := &in.Passphrase, &out.Passphrase
+		*out = new(SecretKeyRef)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestRepoEncryption.
+func (in *PGBackRestRepoEncryption) DeepCopy() *PGBackRestRepoEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBackRestRepoEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PGBackRestRepoHost) DeepCopyInto(out *PGBackRestRepoHost) {
 	*out = *in
@@ -1394,6 +1665,52 @@ func (in *PGBackRestRestore) DeepCopy() *PGBackRestRestore {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBackRestRestorePointStatus) DeepCopyInto(out *PGBackRestRestorePointStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestRestorePointStatus.
+func (in *PGBackRestRestorePointStatus) DeepCopy() *PGBackRestRestorePointStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBackRestRestorePointStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBackRestRetention) DeepCopyInto(out *PGBackRestRetention) {
+	*out = *in
+	if in.Full != nil {
+		in, out := &in.Full, &out.Full
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Diff != nil {
+		in, out := &in.Diff, &out.Diff
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Archive != nil {
+		in, out := &in.Archive, &out.Archive
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestRetention.
+func (in *PGBackRestRetention) DeepCopy() *PGBackRestRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBackRestRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PGBackRestScheduledBackupStatus) DeepCopyInto(out *PGBackRestScheduledBackupStatus) {
 	*out = *in
@@ -1405,6 +1722,10 @@ func (in *PGBackRestScheduledBackupStatus) DeepCopyInto(out *PGBackRestScheduled
 		in, out := &in.CompletionTime, &out.CompletionTime
 		*out = (*in).DeepCopy()
 	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestScheduledBackupStatus.
@@ -1442,6 +1763,30 @@ func (in *PGBackRestSidecars) DeepCopy() *PGBackRestSidecars {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBackRestSpoolVolume) DeepCopyInto(out *PGBackRestSpoolVolume) {
+	*out = *in
+	if in.EmptyDir != nil {
+		in, out := &in.EmptyDir, &out.EmptyDir
+		*out = new(corev1.EmptyDirVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolumeClaimSpec != nil {
+		in, out := &in.VolumeClaimSpec, &out.VolumeClaimSpec
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestSpoolVolume.
+func (in *PGBackRestSpoolVolume) DeepCopy() *PGBackRestSpoolVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBackRestSpoolVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PGBackRestStatus) DeepCopyInto(out *PGBackRestStatus) {
 	*out = *in
@@ -1450,6 +1795,11 @@ func (in *PGBackRestStatus) DeepCopyInto(out *PGBackRestStatus) {
 		*out = new(PGBackRestJobStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Expire != nil {
+		in, out := &in.Expire, &out.Expire
+		*out = new(PGBackRestJobStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ScheduledBackups != nil {
 		in, out := &in.ScheduledBackups, &out.ScheduledBackups
 		*out = make([]PGBackRestScheduledBackupStatus, len(*in))
@@ -1465,13 +1815,38 @@ func (in *PGBackRestStatus) DeepCopyInto(out *PGBackRestStatus) {
 	if in.Repos != nil {
 		in, out := &in.Repos, &out.Repos
 		*out = make([]RepoStatus, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Restore != nil {
 		in, out := &in.Restore, &out.Restore
 		*out = new(PGBackRestJobStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CatalogTime != nil {
+		in, out := &in.CatalogTime, &out.CatalogTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Verifications != nil {
+		in, out := &in.Verifications, &out.Verifications
+		*out = make([]PGBackRestVerificationStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RestorePoints != nil {
+		in, out := &in.RestorePoints, &out.RestorePoints
+		*out = make([]PGBackRestRestorePointStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Archive != nil {
+		in, out := &in.Archive, &out.Archive
+		*out = new(PGBackRestArchiveStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestStatus.
@@ -1485,21 +1860,45 @@ func (in *PGBackRestStatus) DeepCopy() *PGBackRestStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PGBouncerConfiguration) DeepCopyInto(out *PGBouncerConfiguration) {
+func (in *PGBackRestVerificationStatus) DeepCopyInto(out *PGBackRestVerificationStatus) {
 	*out = *in
-	if in.Files != nil {
-		in, out := &in.Files, &out.Files
-		*out = make([]corev1.VolumeProjection, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Global != nil {
-		in, out := &in.Global, &out.Global
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CorruptFiles != nil {
+		in, out := &in.CorruptFiles, &out.CorruptFiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestVerificationStatus.
+func (in *PGBackRestVerificationStatus) DeepCopy() *PGBackRestVerificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBackRestVerificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBouncerConfiguration) DeepCopyInto(out *PGBouncerConfiguration) {
+	*out = *in
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]corev1.VolumeProjection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Global != nil {
+		in, out := &in.Global, &out.Global
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 	if in.Databases != nil {
 		in, out := &in.Databases, &out.Databases
@@ -2005,6 +2404,11 @@ func (in *PostgresClusterDataSource) DeepCopyInto(out *PostgresClusterDataSource
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(PGBackRestRecoveryTarget)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Resources.DeepCopyInto(&out.Resources)
 	if in.Affinity != nil {
 		in, out := &in.Affinity, &out.Affinity
@@ -2101,6 +2505,11 @@ func (in *PostgresClusterSpec) DeepCopyInto(out *PostgresClusterSpec) {
 		*out = new(corev1.SecretProjection)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(CertManagerSpec)
+		**out = **in
+	}
 	if in.DatabaseInitSQL != nil {
 		in, out := &in.DatabaseInitSQL, &out.DatabaseInitSQL
 		*out = new(DatabaseInitSQL)
@@ -2111,6 +2520,13 @@ func (in *PostgresClusterSpec) DeepCopyInto(out *PostgresClusterSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make([]PostgresExtensionSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
 		*out = make([]corev1.LocalObjectReference, len(*in))
@@ -2210,6 +2626,25 @@ func (in *PostgresClusterSpec) DeepCopy() *PostgresClusterSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresClusterStatus) DeepCopyInto(out *PostgresClusterStatus) {
 	*out = *in
+	if in.Binding != nil {
+		in, out := &in.Binding, &out.Binding
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]CertificateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make([]PostgresExtensionStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.InstanceSets != nil {
 		in, out := &in.InstanceSets, &out.InstanceSets
 		*out = make([]PostgresInstanceSetStatus, len(*in))
@@ -2229,11 +2664,23 @@ func (in *PostgresClusterStatus) DeepCopyInto(out *PostgresClusterStatus) {
 		**out = **in
 	}
 	out.Proxy = in.Proxy
+	if in.RootCertificateRotation != nil {
+		in, out := &in.RootCertificateRotation, &out.RootCertificateRotation
+		*out = new(RootCertificateRotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.UserInterface != nil {
 		in, out := &in.UserInterface, &out.UserInterface
 		*out = new(PostgresUserInterfaceStatus)
 		**out = **in
 	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]PostgresUserStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	out.Monitoring = in.Monitoring
 	if in.DatabaseInitSQL != nil {
 		in, out := &in.DatabaseInitSQL, &out.DatabaseInitSQL
@@ -2288,6 +2735,208 @@ func (in *PostgresConfigSpec) DeepCopy() *PostgresConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresDatabase) DeepCopyInto(out *PostgresDatabase) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresDatabase.
+func (in *PostgresDatabase) DeepCopy() *PostgresDatabase {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresDatabase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PostgresDatabase) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresDatabaseExtension) DeepCopyInto(out *PostgresDatabaseExtension) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresDatabaseExtension.
+func (in *PostgresDatabaseExtension) DeepCopy() *PostgresDatabaseExtension {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresDatabaseExtension)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresDatabaseList) DeepCopyInto(out *PostgresDatabaseList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PostgresDatabase, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresDatabaseList.
+func (in *PostgresDatabaseList) DeepCopy() *PostgresDatabaseList {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresDatabaseList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PostgresDatabaseList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresDatabaseSchema) DeepCopyInto(out *PostgresDatabaseSchema) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresDatabaseSchema.
+func (in *PostgresDatabaseSchema) DeepCopy() *PostgresDatabaseSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresDatabaseSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresDatabaseSpec) DeepCopyInto(out *PostgresDatabaseSpec) {
+	*out = *in
+	if in.ConnectionLimit != nil {
+		in, out := &in.ConnectionLimit, &out.ConnectionLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Schemas != nil {
+		in, out := &in.Schemas, &out.Schemas
+		*out = make([]PostgresDatabaseSchema, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make([]PostgresDatabaseExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresDatabaseSpec.
+func (in *PostgresDatabaseSpec) DeepCopy() *PostgresDatabaseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresDatabaseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresDatabaseStatus) DeepCopyInto(out *PostgresDatabaseStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Exists != nil {
+		in, out := &in.Exists, &out.Exists
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresDatabaseStatus.
+func (in *PostgresDatabaseStatus) DeepCopy() *PostgresDatabaseStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresDatabaseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresExtensionSpec) DeepCopyInto(out *PostgresExtensionSpec) {
+	*out = *in
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]PostgresIdentifier, len(*in))
+		copy(*out, *in)
+	}
+	if in.SharedPreloadLibraries != nil {
+		in, out := &in.SharedPreloadLibraries, &out.SharedPreloadLibraries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresExtensionSpec.
+func (in *PostgresExtensionSpec) DeepCopy() *PostgresExtensionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresExtensionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresExtensionStatus) DeepCopyInto(out *PostgresExtensionStatus) {
+	*out = *in
+	if in.InstalledVersions != nil {
+		in, out := &in.InstalledVersions, &out.InstalledVersions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresExtensionStatus.
+func (in *PostgresExtensionStatus) DeepCopy() *PostgresExtensionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresExtensionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresHBARule) DeepCopyInto(out *PostgresHBARule) {
 	*out = *in
@@ -2442,9 +3091,44 @@ func (in *PostgresInstanceSetStatus) DeepCopy() *PostgresInstanceSetStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresPasswordRotationSpec) DeepCopyInto(out *PostgresPasswordRotationSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresPasswordRotationSpec.
+func (in *PostgresPasswordRotationSpec) DeepCopy() *PostgresPasswordRotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresPasswordRotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresPasswordSpec) DeepCopyInto(out *PostgresPasswordSpec) {
 	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(PostgresPasswordRotationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresPasswordSpec.
@@ -2493,6 +3177,190 @@ func (in *PostgresProxyStatus) DeepCopy() *PostgresProxyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresRestoreTest) DeepCopyInto(out *PostgresRestoreTest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresRestoreTest.
+func (in *PostgresRestoreTest) DeepCopy() *PostgresRestoreTest {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresRestoreTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PostgresRestoreTest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresRestoreTestList) DeepCopyInto(out *PostgresRestoreTestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PostgresRestoreTest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresRestoreTestList.
+func (in *PostgresRestoreTestList) DeepCopy() *PostgresRestoreTestList {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresRestoreTestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PostgresRestoreTestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresRestoreTestRun) DeepCopyInto(out *PostgresRestoreTestRun) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresRestoreTestRun.
+func (in *PostgresRestoreTestRun) DeepCopy() *PostgresRestoreTestRun {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresRestoreTestRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresRestoreTestSpec) DeepCopyInto(out *PostgresRestoreTestSpec) {
+	*out = *in
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(Metadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Validation != nil {
+		in, out := &in.Validation, &out.Validation
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.Suspend != nil {
+		in, out := &in.Suspend, &out.Suspend
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresRestoreTestSpec.
+func (in *PostgresRestoreTestSpec) DeepCopy() *PostgresRestoreTestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresRestoreTestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresRestoreTestStatus) DeepCopyInto(out *PostgresRestoreTestStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = new(PostgresRestoreTestRun)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastRun != nil {
+		in, out := &in.LastRun, &out.LastRun
+		*out = new(PostgresRestoreTestRun)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresRestoreTestStatus.
+func (in *PostgresRestoreTestStatus) DeepCopy() *PostgresRestoreTestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresRestoreTestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresSchemaPrivilegesSpec) DeepCopyInto(out *PostgresSchemaPrivilegesSpec) {
+	*out = *in
+	if in.DefaultPrivilegesFor != nil {
+		in, out := &in.DefaultPrivilegesFor, &out.DefaultPrivilegesFor
+		*out = make([]PostgresIdentifier, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresSchemaPrivilegesSpec.
+func (in *PostgresSchemaPrivilegesSpec) DeepCopy() *PostgresSchemaPrivilegesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresSchemaPrivilegesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresStandbySpec) DeepCopyInto(out *PostgresStandbySpec) {
 	*out = *in
@@ -2529,6 +3397,33 @@ func (in *PostgresUserInterfaceStatus) DeepCopy() *PostgresUserInterfaceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresUserPrivilegesSpec) DeepCopyInto(out *PostgresUserPrivilegesSpec) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]PostgresIdentifier, len(*in))
+		copy(*out, *in)
+	}
+	if in.Schemas != nil {
+		in, out := &in.Schemas, &out.Schemas
+		*out = make([]PostgresSchemaPrivilegesSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresUserPrivilegesSpec.
+func (in *PostgresUserPrivilegesSpec) DeepCopy() *PostgresUserPrivilegesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresUserPrivilegesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresUserSpec) DeepCopyInto(out *PostgresUserSpec) {
 	*out = *in
@@ -2537,10 +3432,25 @@ func (in *PostgresUserSpec) DeepCopyInto(out *PostgresUserSpec) {
 		*out = make([]PostgresIdentifier, len(*in))
 		copy(*out, *in)
 	}
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ConnectionFormats != nil {
+		in, out := &in.ConnectionFormats, &out.ConnectionFormats
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Password != nil {
 		in, out := &in.Password, &out.Password
 		*out = new(PostgresPasswordSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = new(PostgresUserPrivilegesSpec)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -2554,6 +3464,25 @@ func (in *PostgresUserSpec) DeepCopy() *PostgresUserSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresUserStatus) DeepCopyInto(out *PostgresUserStatus) {
+	*out = *in
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresUserStatus.
+func (in *PostgresUserStatus) DeepCopy() *PostgresUserStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresUserStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresVolumesSpec) DeepCopyInto(out *PostgresVolumesSpec) {
 	*out = *in
@@ -2672,9 +3601,52 @@ func (in *RepoS3) DeepCopy() *RepoS3 {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepoSFTP) DeepCopyInto(out *RepoSFTP) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	in.PrivateKey.DeepCopyInto(&out.PrivateKey)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepoSFTP.
+func (in *RepoSFTP) DeepCopy() *RepoSFTP {
+	if in == nil {
+		return nil
+	}
+	out := new(RepoSFTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RepoStatus) DeepCopyInto(out *RepoStatus) {
 	*out = *in
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(PGBackRestRetention)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InvalidRetention != nil {
+		in, out := &in.InvalidRetention, &out.InvalidRetention
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Backups != nil {
+		in, out := &in.Backups, &out.Backups
+		*out = make([]PGBackRestBackupStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RecoveryWindow != nil {
+		in, out := &in.RecoveryWindow, &out.RecoveryWindow
+		*out = new(PGBackRestRecoveryWindow)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepoStatus.
@@ -2687,6 +3659,25 @@ func (in *RepoStatus) DeepCopy() *RepoStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RootCertificateRotationStatus) DeepCopyInto(out *RootCertificateRotationStatus) {
+	*out = *in
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RootCertificateRotationStatus.
+func (in *RootCertificateRotationStatus) DeepCopy() *RootCertificateRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RootCertificateRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in SchemalessObject) DeepCopyInto(out *SchemalessObject) {
 	{